@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -177,6 +178,11 @@ type GetAlertOutput struct {
 
 // GetAlert gets the alert with specified id. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts~1{id}/get
 func (c *Client) GetAlert(input *GetAlertInput) (*GetAlertOutput, error) {
+	return c.GetAlertWithContext(context.Background(), input)
+}
+
+// GetAlertWithContext is the same as GetAlert with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertWithContext(ctx context.Context, input *GetAlertInput) (*GetAlertOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -190,7 +196,7 @@ func (c *Client) GetAlert(input *GetAlertInput) (*GetAlertOutput, error) {
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d?%s", apiRoutes.alerts, *input.AlertID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d?%s", apiRoutes.alerts, *input.AlertID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -245,6 +251,11 @@ type GetAlertsOutput struct {
 
 // GetAlerts lists existing alerts. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts/get
 func (c *Client) GetAlerts(input *GetAlertsInput) (*GetAlertsOutput, error) {
+	return c.GetAlertsWithContext(context.Background(), input)
+}
+
+// GetAlertsWithContext is the same as GetAlerts with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertsWithContext(ctx context.Context, input *GetAlertsInput) (*GetAlertsOutput, error) {
 	if input == nil {
 		input = &GetAlertsInput{}
 	}
@@ -283,7 +294,7 @@ func (c *Client) GetAlerts(input *GetAlertsInput) (*GetAlertsOutput, error) {
 		q.Add("assigned-to", *username)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.alerts, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.alerts, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -300,6 +311,32 @@ func (c *Client) GetAlerts(input *GetAlertsInput) (*GetAlertsOutput, error) {
 	return &GetAlertsOutput{Alerts: alerts}, nil
 }
 
+// GetAlertsPages iterates over all pages of a GetAlerts operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetAlertsPages(input *GetAlertsInput, fn func(page *GetAlertsOutput) bool, options ...PaginateOption) error {
+	return c.GetAlertsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetAlertsPagesWithContext is the same as GetAlertsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetAlertsPagesWithContext(ctx context.Context, input *GetAlertsInput, fn func(page *GetAlertsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetAlertsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*Alert, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetAlertsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Alerts, nil
+	}
+	return Paginate(ctx, fetch, func(page []*Alert) bool {
+		return fn(&GetAlertsOutput{Alerts: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // GetAlertsCountInput represents the input of a GetAlertsCount operation.
 type GetAlertsCountInput struct {
 	_ struct{}
@@ -331,6 +368,11 @@ type GetAlertsCountOutput struct {
 
 // GetAlertsCount gets the alert count. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts~1count/get
 func (c *Client) GetAlertsCount(input *GetAlertsCountInput) (*GetAlertsCountOutput, error) {
+	return c.GetAlertsCountWithContext(context.Background(), input)
+}
+
+// GetAlertsCountWithContext is the same as GetAlertsCount with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertsCountWithContext(ctx context.Context, input *GetAlertsCountInput) (*GetAlertsCountOutput, error) {
 	if input == nil {
 		input = &GetAlertsCountInput{}
 	}
@@ -359,7 +401,7 @@ func (c *Client) GetAlertsCount(input *GetAlertsCountInput) (*GetAlertsCountOutp
 		q.Add("assigned-to", *username)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/count?%s", apiRoutes.alerts, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/count?%s", apiRoutes.alerts, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -391,6 +433,11 @@ type GetAlertResponderOutput struct {
 
 // GetAlertResponder gets the responders on the alert with specified id. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts~1{id}~1suggested-responders/get
 func (c *Client) GetAlertResponder(input *GetAlertResponderInput) (*GetAlertResponderOutput, error) {
+	return c.GetAlertResponderWithContext(context.Background(), input)
+}
+
+// GetAlertResponderWithContext is the same as GetAlertResponder with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertResponderWithContext(ctx context.Context, input *GetAlertResponderInput) (*GetAlertResponderOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -407,7 +454,7 @@ func (c *Client) GetAlertResponder(input *GetAlertResponderInput) (*GetAlertResp
 		}
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/suggested-responders", apiRoutes.alerts, *input.AlertID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d/suggested-responders", apiRoutes.alerts, *input.AlertID))
 	if err != nil {
 		return nil, err
 	}
@@ -442,6 +489,11 @@ type AssignAlertOutput struct {
 
 // AssignAlert assigns an alert with specified id to specified entities. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts~1{id}~1assign/put
 func (c *Client) AssignAlert(input *AssignAlertInput) (*AssignAlertOutput, error) {
+	return c.AssignAlertWithContext(context.Background(), input)
+}
+
+// AssignAlertWithContext is the same as AssignAlert with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) AssignAlertWithContext(ctx context.Context, input *AssignAlertInput) (*AssignAlertOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -467,7 +519,7 @@ func (c *Client) AssignAlert(input *AssignAlertInput) (*AssignAlertOutput, error
 		q.Add("schedule-id", strconv.FormatInt(*input.ScheduleID, 10))
 	}
 
-	resp, err := c.httpClient.R().Put(fmt.Sprintf("%s/%d/assign?%s", apiRoutes.alerts, *input.AlertID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Put(fmt.Sprintf("%s/%d/assign?%s", apiRoutes.alerts, *input.AlertID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -498,6 +550,11 @@ type AcceptAlertOutput struct {
 
 // AcceptAlert accepts an alert with specified id. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts~1{id}~1accept/put
 func (c *Client) AcceptAlert(input *AcceptAlertInput) (*AcceptAlertOutput, error) {
+	return c.AcceptAlertWithContext(context.Background(), input)
+}
+
+// AcceptAlertWithContext is the same as AcceptAlert with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) AcceptAlertWithContext(ctx context.Context, input *AcceptAlertInput) (*AcceptAlertOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -505,7 +562,7 @@ func (c *Client) AcceptAlert(input *AcceptAlertInput) (*AcceptAlertOutput, error
 		return nil, errors.New("alert id is required")
 	}
 
-	resp, err := c.httpClient.R().Put(fmt.Sprintf("%s/%d/accept", apiRoutes.alerts, *input.AlertID))
+	resp, err := c.httpClient.R().SetContext(ctx).Put(fmt.Sprintf("%s/%d/accept", apiRoutes.alerts, *input.AlertID))
 	if err != nil {
 		return nil, err
 	}
@@ -536,6 +593,11 @@ type ResolveAlertOutput struct {
 
 // ResolveAlert resolves an alert with specified id. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts~1{id}~1resolve/put
 func (c *Client) ResolveAlert(input *ResolveAlertInput) (*ResolveAlertOutput, error) {
+	return c.ResolveAlertWithContext(context.Background(), input)
+}
+
+// ResolveAlertWithContext is the same as ResolveAlert with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) ResolveAlertWithContext(ctx context.Context, input *ResolveAlertInput) (*ResolveAlertOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -543,7 +605,7 @@ func (c *Client) ResolveAlert(input *ResolveAlertInput) (*ResolveAlertOutput, er
 		return nil, errors.New("alert id is required")
 	}
 
-	resp, err := c.httpClient.R().Put(fmt.Sprintf("%s/%d/resolve", apiRoutes.alerts, *input.AlertID))
+	resp, err := c.httpClient.R().SetContext(ctx).Put(fmt.Sprintf("%s/%d/resolve", apiRoutes.alerts, *input.AlertID))
 	if err != nil {
 		return nil, err
 	}
@@ -575,6 +637,11 @@ type GetAlertLogEntriesOutput struct {
 
 // GetAlertLogEntries gets log entries for the specified alert. https://api.ilert.com/api-docs/#tag/Alerts/paths/~1alerts~1{id}~1log-entries/get
 func (c *Client) GetAlertLogEntries(input *GetAlertLogEntriesInput) (*GetAlertLogEntriesOutput, error) {
+	return c.GetAlertLogEntriesWithContext(context.Background(), input)
+}
+
+// GetAlertLogEntriesWithContext is the same as GetAlertLogEntries with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertLogEntriesWithContext(ctx context.Context, input *GetAlertLogEntriesInput) (*GetAlertLogEntriesOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -591,7 +658,7 @@ func (c *Client) GetAlertLogEntries(input *GetAlertLogEntriesInput) (*GetAlertLo
 		}
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/log-entries", apiRoutes.alerts, *input.AlertID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d/log-entries", apiRoutes.alerts, *input.AlertID))
 	if err != nil {
 		return nil, err
 	}
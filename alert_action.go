@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,9 +22,9 @@ type AlertAction struct {
 	EscalationEndedDelaySec int            `json:"escalationEndedDelaySec,omitempty"` // between 0 and 7200, used with triggerType 'AlertEscalationEnded'
 	NotResolvedDelaySec     int            `json:"notResolvedDelaySec,omitempty"`     // between 0 and 7200, used with triggerType 'AlertNotResolved'
 	TriggerTypes            []string       `json:"triggerTypes,omitempty"`
-	CreatedAt               string         `json:"createdAt,omitempty"` // date time string in ISO 8601
-	UpdatedAt               string         `json:"updatedAt,omitempty"` // date time string in ISO 8601
-	Params                  interface{}    `json:"params"`
+	CreatedAt               string         `json:"createdAt,omitempty"`   // date time string in ISO 8601
+	UpdatedAt               string         `json:"updatedAt,omitempty"`   // date time string in ISO 8601
+	Params                  interface{}    `json:"params"`                // @deprecated, use NewAlertAction or SetParams, raw params are only checked at runtime
 	AlertFilter             *AlertFilter   `json:"alertFilter,omitempty"` // @deprecated
 	Teams                   *[]TeamShort   `json:"teams,omitempty"`
 	Conditions              string         `json:"conditions,omitempty"`
@@ -48,6 +49,9 @@ type AlertActionOutput struct {
 	AlertFilter             *AlertFilter             `json:"alertFilter,omitempty"` // @deprecated
 	Teams                   *[]TeamShort             `json:"teams,omitempty"`
 	Conditions              string                   `json:"conditions,omitempty"`
+
+	// the params decoded into the params type of the connector type e.g. *AlertActionParamsJira, nil for unsupported connector types or params that do not decode into it
+	TypedParams AlertActionParams `json:"-"`
 }
 
 // AlertActionOutputParams definition
@@ -403,12 +407,20 @@ type CreateAlertActionOutput struct {
 
 // CreateAlertAction creates a new alert action. https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions/post
 func (c *Client) CreateAlertAction(input *CreateAlertActionInput) (*CreateAlertActionOutput, error) {
+	return c.CreateAlertActionWithContext(context.Background(), input)
+}
+
+// CreateAlertActionWithContext is the same as CreateAlertAction with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateAlertActionWithContext(ctx context.Context, input *CreateAlertActionInput) (*CreateAlertActionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.AlertAction == nil {
 		return nil, errors.New("alert action input is required")
 	}
+	if err := input.AlertAction.checkParams(); err != nil {
+		return nil, err
+	}
 	if input.AlertAction.AlertSources != nil && len(*input.AlertAction.AlertSources) == 1 && (input.AlertAction.Teams == nil || len(*input.AlertAction.Teams) == 0) && input.AlertAction.Conditions == "" {
 		sourceId := (*input.AlertAction.AlertSources)[0].ID
 
@@ -418,7 +430,7 @@ func (c *Client) CreateAlertAction(input *CreateAlertActionInput) (*CreateAlertA
 		input.AlertAction.Teams = nil
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.AlertAction).Post(apiRoutes.alertActions)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.AlertAction).Post(apiRoutes.alertActions)
 	if err != nil {
 		return nil, err
 	}
@@ -450,6 +462,11 @@ type GetAlertActionOutput struct {
 
 // GetAlertAction gets the alert action with specified id. https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions~1{id}/get
 func (c *Client) GetAlertAction(input *GetAlertActionInput) (*GetAlertActionOutput, error) {
+	return c.GetAlertActionWithContext(context.Background(), input)
+}
+
+// GetAlertActionWithContext is the same as GetAlertAction with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertActionWithContext(ctx context.Context, input *GetAlertActionInput) (*GetAlertActionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -461,7 +478,7 @@ func (c *Client) GetAlertAction(input *GetAlertActionInput) (*GetAlertActionOutp
 		q.Add("version", strconv.Itoa(*input.Version))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%s?%s", apiRoutes.alertActions, *input.AlertActionID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%s?%s", apiRoutes.alertActions, *input.AlertActionID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -499,6 +516,11 @@ type GetAlertActionsOutput struct {
 
 // GetAlertActions lists existing alert actions. https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions/get
 func (c *Client) GetAlertActions(input *GetAlertActionsInput) (*GetAlertActionsOutput, error) {
+	return c.GetAlertActionsWithContext(context.Background(), input)
+}
+
+// GetAlertActionsWithContext is the same as GetAlertActions with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertActionsWithContext(ctx context.Context, input *GetAlertActionsInput) (*GetAlertActionsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -511,7 +533,7 @@ func (c *Client) GetAlertActions(input *GetAlertActionsInput) (*GetAlertActionsO
 		q.Add("max-results", "50")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.alertActions, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.alertActions, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -528,6 +550,32 @@ func (c *Client) GetAlertActions(input *GetAlertActionsInput) (*GetAlertActionsO
 	return &GetAlertActionsOutput{AlertActions: alertActions}, nil
 }
 
+// GetAlertActionsPages iterates over all pages of a GetAlertActions operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetAlertActionsPages(input *GetAlertActionsInput, fn func(page *GetAlertActionsOutput) bool, options ...PaginateOption) error {
+	return c.GetAlertActionsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetAlertActionsPagesWithContext is the same as GetAlertActionsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetAlertActionsPagesWithContext(ctx context.Context, input *GetAlertActionsInput, fn func(page *GetAlertActionsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetAlertActionsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*AlertActionOutput, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetAlertActionsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.AlertActions, nil
+	}
+	return Paginate(ctx, fetch, func(page []*AlertActionOutput) bool {
+		return fn(&GetAlertActionsOutput{AlertActions: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchAlertActionInput represents the input of a SearchAlertAction operation.
 type SearchAlertActionInput struct {
 	_               struct{}
@@ -542,6 +590,11 @@ type SearchAlertActionOutput struct {
 
 // SearchAlertAction gets the alert action with specified name.
 func (c *Client) SearchAlertAction(input *SearchAlertActionInput) (*SearchAlertActionOutput, error) {
+	return c.SearchAlertActionWithContext(context.Background(), input)
+}
+
+// SearchAlertActionWithContext is the same as SearchAlertAction with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchAlertActionWithContext(ctx context.Context, input *SearchAlertActionInput) (*SearchAlertActionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -549,7 +602,7 @@ func (c *Client) SearchAlertAction(input *SearchAlertActionInput) (*SearchAlertA
 		return nil, errors.New("alert action name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.alertActions, *input.AlertActionName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.alertActions, *input.AlertActionName))
 	if err != nil {
 		return nil, err
 	}
@@ -581,12 +634,20 @@ type UpdateAlertActionOutput struct {
 
 // UpdateAlertAction updates an existing alert action. https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions~1{id}/put
 func (c *Client) UpdateAlertAction(input *UpdateAlertActionInput) (*UpdateAlertActionOutput, error) {
+	return c.UpdateAlertActionWithContext(context.Background(), input)
+}
+
+// UpdateAlertActionWithContext is the same as UpdateAlertAction with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateAlertActionWithContext(ctx context.Context, input *UpdateAlertActionInput) (*UpdateAlertActionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.AlertAction == nil {
 		return nil, errors.New("alert action input is required")
 	}
+	if err := input.AlertAction.checkParams(); err != nil {
+		return nil, err
+	}
 	if input.AlertActionID == nil {
 		return nil, errors.New("alert action id is required")
 	}
@@ -599,7 +660,7 @@ func (c *Client) UpdateAlertAction(input *UpdateAlertActionInput) (*UpdateAlertA
 		input.AlertAction.Teams = nil
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.AlertAction).Put(fmt.Sprintf("%s/%s", apiRoutes.alertActions, *input.AlertActionID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.AlertAction).Put(fmt.Sprintf("%s/%s", apiRoutes.alertActions, *input.AlertActionID))
 	if err != nil {
 		return nil, err
 	}
@@ -629,6 +690,11 @@ type DeleteAlertActionOutput struct {
 
 // DeleteAlertAction deletes the specified alert action. https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions~1{id}/delete
 func (c *Client) DeleteAlertAction(input *DeleteAlertActionInput) (*DeleteAlertActionOutput, error) {
+	return c.DeleteAlertActionWithContext(context.Background(), input)
+}
+
+// DeleteAlertActionWithContext is the same as DeleteAlertAction with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteAlertActionWithContext(ctx context.Context, input *DeleteAlertActionInput) (*DeleteAlertActionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -636,7 +702,7 @@ func (c *Client) DeleteAlertAction(input *DeleteAlertActionInput) (*DeleteAlertA
 		return nil, errors.New("alert action id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%s", apiRoutes.alertActions, *input.AlertActionID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%s", apiRoutes.alertActions, *input.AlertActionID))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,328 @@
+package ilert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AlertActionParams is implemented by the per connector type params of an alert action e.g. AlertActionParamsJira,
+// both by values and pointers of the params types
+type AlertActionParams interface {
+	// ConnectorType returns the connector type the params belong to
+	ConnectorType() string
+	alertActionParams()
+}
+
+// ConnectorParams is implemented by the per connector type params of a connector e.g. ConnectorParamsJira,
+// both by values and pointers of the params types
+type ConnectorParams interface {
+	// ConnectorType returns the connector type the params belong to
+	ConnectorType() string
+	connectorParams()
+}
+
+// alertActionParamsTypes maps connector types to their alert action params
+var alertActionParamsTypes = map[string]func() AlertActionParams{
+	ConnectorTypes.AutomationRule:        func() AlertActionParams { return &AlertActionParamsAutomationRule{} },
+	ConnectorTypes.Autotask:              func() AlertActionParams { return &AlertActionParamsAutotask{} },
+	ConnectorTypes.DingTalk:              func() AlertActionParams { return &AlertActionParamsDingTalk{} },
+	ConnectorTypes.DingTalkAction:        func() AlertActionParams { return &AlertActionParamsDingTalkAction{} },
+	ConnectorTypes.Discord:               func() AlertActionParams { return &AlertActionParamsDiscord{} },
+	ConnectorTypes.Email:                 func() AlertActionParams { return &AlertActionParamsEmail{} },
+	ConnectorTypes.Github:                func() AlertActionParams { return &AlertActionParamsGithub{} },
+	ConnectorTypes.Jira:                  func() AlertActionParams { return &AlertActionParamsJira{} },
+	ConnectorTypes.MicrosoftTeams:        func() AlertActionParams { return &AlertActionParamsMicrosoftTeams{} },
+	ConnectorTypes.MicrosoftTeamsBot:     func() AlertActionParams { return &AlertActionParamsMicrosoftTeamsBot{} },
+	ConnectorTypes.MicrosoftTeamsWebhook: func() AlertActionParams { return &AlertActionParamsMicrosoftTeamsWebhook{} },
+	ConnectorTypes.Reroute:               func() AlertActionParams { return &AlertActionParamsReroute{} },
+	ConnectorTypes.ServiceNow:            func() AlertActionParams { return &AlertActionParamsServiceNow{} },
+	ConnectorTypes.Slack:                 func() AlertActionParams { return &AlertActionParamsSlack{} },
+	ConnectorTypes.SlackWebhook:          func() AlertActionParams { return &AlertActionParamsSlackWebhook{} },
+	ConnectorTypes.Telegram:              func() AlertActionParams { return &AlertActionParamsTelegram{} },
+	ConnectorTypes.Topdesk:               func() AlertActionParams { return &AlertActionParamsTopdesk{} },
+	ConnectorTypes.Webhook:               func() AlertActionParams { return &AlertActionParamsWebhook{} },
+	ConnectorTypes.Zammad:                func() AlertActionParams { return &AlertActionParamsZammad{} },
+	ConnectorTypes.Zendesk:               func() AlertActionParams { return &AlertActionParamsZendesk{} },
+}
+
+// connectorParamsTypes maps connector types to their connector params
+var connectorParamsTypes = map[string]func() ConnectorParams{
+	ConnectorTypes.Autotask:       func() ConnectorParams { return &ConnectorParamsAutotask{} },
+	ConnectorTypes.DingTalk:       func() ConnectorParams { return &ConnectorParamsDingTalk{} },
+	ConnectorTypes.Discord:        func() ConnectorParams { return &ConnectorParamsDiscord{} },
+	ConnectorTypes.Github:         func() ConnectorParams { return &ConnectorParamsGithub{} },
+	ConnectorTypes.Jira:           func() ConnectorParams { return &ConnectorParamsJira{} },
+	ConnectorTypes.Mattermost:     func() ConnectorParams { return &ConnectorParamsMattermost{} },
+	ConnectorTypes.MicrosoftTeams: func() ConnectorParams { return &ConnectorParamsMicrosoftTeams{} },
+	ConnectorTypes.ServiceNow:     func() ConnectorParams { return &ConnectorParamsServiceNow{} },
+	ConnectorTypes.Slack:          func() ConnectorParams { return &ConnectorParamsSlack{} },
+	ConnectorTypes.Topdesk:        func() ConnectorParams { return &ConnectorParamsTopdesk{} },
+	ConnectorTypes.Zammad:         func() ConnectorParams { return &ConnectorParamsZammad{} },
+	ConnectorTypes.Zendesk:        func() ConnectorParams { return &ConnectorParamsZendesk{} },
+}
+
+// DecodeAlertActionParams decodes the raw params of an alert action into the params type of its connector type
+func DecodeAlertActionParams(connectorType string, raw json.RawMessage) (AlertActionParams, error) {
+	newParams, ok := alertActionParamsTypes[connectorType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported alert action connector type %q", connectorType)
+	}
+	params := newParams()
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, params); err != nil {
+			return nil, fmt.Errorf("could not decode %s alert action params: %w", connectorType, err)
+		}
+	}
+	return params, nil
+}
+
+// DecodeConnectorParams decodes the raw params of a connector into the params type of its connector type
+func DecodeConnectorParams(connectorType string, raw json.RawMessage) (ConnectorParams, error) {
+	newParams, ok := connectorParamsTypes[connectorType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported connector type %q", connectorType)
+	}
+	params := newParams()
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, params); err != nil {
+			return nil, fmt.Errorf("could not decode %s connector params: %w", connectorType, err)
+		}
+	}
+	return params, nil
+}
+
+// NewAlertAction creates an alert action for the given connector, the connector type is taken from the params
+// so params and connector type cannot disagree
+func NewAlertAction(name string, connectorID string, params AlertActionParams) *AlertAction {
+	return (&AlertAction{Name: name, ConnectorID: connectorID}).SetParams(params)
+}
+
+// NewConnector creates a connector, the connector type is taken from the params so params and type cannot disagree
+func NewConnector(name string, params ConnectorParams) *Connector {
+	return (&Connector{Name: name}).SetParams(params)
+}
+
+// SetParams sets the params of an alert action together with their connector type
+func (a *AlertAction) SetParams(params AlertActionParams) *AlertAction {
+	a.ConnectorType = params.ConnectorType()
+	a.Params = params
+	return a
+}
+
+// SetParams sets the params of a connector together with their connector type
+func (c *Connector) SetParams(params ConnectorParams) *Connector {
+	c.Type = params.ConnectorType()
+	c.Params = params
+	return c
+}
+
+// checkParams returns an error if typed params do not belong to the connector type of the alert action
+func (a *AlertAction) checkParams() error {
+	if params, ok := a.Params.(AlertActionParams); ok && params.ConnectorType() != a.ConnectorType {
+		return fmt.Errorf("alert action params of type %s do not match connector type %s", params.ConnectorType(), a.ConnectorType)
+	}
+	return nil
+}
+
+// checkParams returns an error if typed params do not belong to the type of the connector
+func (c *Connector) checkParams() error {
+	if params, ok := c.Params.(ConnectorParams); ok && params.ConnectorType() != c.Type {
+		return fmt.Errorf("connector params of type %s do not match connector type %s", params.ConnectorType(), c.Type)
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes an alert action and its params, both into the union Params and the TypedParams of its connector type
+func (a *AlertActionOutput) UnmarshalJSON(data []byte) error {
+	type alertActionOutput AlertActionOutput
+	var raw struct {
+		ConnectorType string          `json:"connectorType"`
+		Params        json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, (*alertActionOutput)(a)); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.TypedParams = nil
+	// params the typed params cannot hold are still available in the union Params
+	if params, err := DecodeAlertActionParams(raw.ConnectorType, raw.Params); err == nil {
+		a.TypedParams = params
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a connector and its params, both into the union Params and the TypedParams of its type
+func (c *ConnectorOutput) UnmarshalJSON(data []byte) error {
+	type connectorOutput ConnectorOutput
+	var raw struct {
+		Type   string          `json:"type"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, (*connectorOutput)(c)); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	c.TypedParams = nil
+	// params the typed params cannot hold are still available in the union Params
+	if params, err := DecodeConnectorParams(raw.Type, raw.Params); err == nil {
+		c.TypedParams = params
+	}
+	return nil
+}
+
+// ConnectorType returns the connector type of automation rule alert action params
+func (AlertActionParamsAutomationRule) ConnectorType() string { return ConnectorTypes.AutomationRule }
+
+// ConnectorType returns the connector type of Autotask alert action params
+func (AlertActionParamsAutotask) ConnectorType() string { return ConnectorTypes.Autotask }
+
+// ConnectorType returns the connector type of DingTalk alert action params
+func (AlertActionParamsDingTalk) ConnectorType() string { return ConnectorTypes.DingTalk }
+
+// ConnectorType returns the connector type of DingTalk action alert action params
+func (AlertActionParamsDingTalkAction) ConnectorType() string { return ConnectorTypes.DingTalkAction }
+
+// ConnectorType returns the connector type of Discord alert action params
+func (AlertActionParamsDiscord) ConnectorType() string { return ConnectorTypes.Discord }
+
+// ConnectorType returns the connector type of email alert action params
+func (AlertActionParamsEmail) ConnectorType() string { return ConnectorTypes.Email }
+
+// ConnectorType returns the connector type of Github alert action params
+func (AlertActionParamsGithub) ConnectorType() string { return ConnectorTypes.Github }
+
+// ConnectorType returns the connector type of Jira alert action params
+func (AlertActionParamsJira) ConnectorType() string { return ConnectorTypes.Jira }
+
+// ConnectorType returns the connector type of Microsoft Teams alert action params
+func (AlertActionParamsMicrosoftTeams) ConnectorType() string { return ConnectorTypes.MicrosoftTeams }
+
+// ConnectorType returns the connector type of Microsoft Teams bot alert action params
+func (AlertActionParamsMicrosoftTeamsBot) ConnectorType() string {
+	return ConnectorTypes.MicrosoftTeamsBot
+}
+
+// ConnectorType returns the connector type of Microsoft Teams webhook alert action params
+func (AlertActionParamsMicrosoftTeamsWebhook) ConnectorType() string {
+	return ConnectorTypes.MicrosoftTeamsWebhook
+}
+
+// ConnectorType returns the connector type of reroute alert action params
+func (AlertActionParamsReroute) ConnectorType() string { return ConnectorTypes.Reroute }
+
+// ConnectorType returns the connector type of ServiceNow alert action params
+func (AlertActionParamsServiceNow) ConnectorType() string { return ConnectorTypes.ServiceNow }
+
+// ConnectorType returns the connector type of Slack alert action params
+func (AlertActionParamsSlack) ConnectorType() string { return ConnectorTypes.Slack }
+
+// ConnectorType returns the connector type of Slack webhook alert action params
+func (AlertActionParamsSlackWebhook) ConnectorType() string { return ConnectorTypes.SlackWebhook }
+
+// ConnectorType returns the connector type of Telegram alert action params
+func (AlertActionParamsTelegram) ConnectorType() string { return ConnectorTypes.Telegram }
+
+// ConnectorType returns the connector type of TOPdesk alert action params
+func (AlertActionParamsTopdesk) ConnectorType() string { return ConnectorTypes.Topdesk }
+
+// ConnectorType returns the connector type of webhook alert action params
+func (AlertActionParamsWebhook) ConnectorType() string { return ConnectorTypes.Webhook }
+
+// ConnectorType returns the connector type of Zammad alert action params
+func (AlertActionParamsZammad) ConnectorType() string { return ConnectorTypes.Zammad }
+
+// ConnectorType returns the connector type of Zendesk alert action params
+func (AlertActionParamsZendesk) ConnectorType() string { return ConnectorTypes.Zendesk }
+
+func (AlertActionParamsAutomationRule) alertActionParams()        {}
+func (AlertActionParamsAutotask) alertActionParams()              {}
+func (AlertActionParamsDingTalk) alertActionParams()              {}
+func (AlertActionParamsDingTalkAction) alertActionParams()        {}
+func (AlertActionParamsDiscord) alertActionParams()               {}
+func (AlertActionParamsEmail) alertActionParams()                 {}
+func (AlertActionParamsGithub) alertActionParams()                {}
+func (AlertActionParamsJira) alertActionParams()                  {}
+func (AlertActionParamsMicrosoftTeams) alertActionParams()        {}
+func (AlertActionParamsMicrosoftTeamsBot) alertActionParams()     {}
+func (AlertActionParamsMicrosoftTeamsWebhook) alertActionParams() {}
+func (AlertActionParamsReroute) alertActionParams()               {}
+func (AlertActionParamsServiceNow) alertActionParams()            {}
+func (AlertActionParamsSlack) alertActionParams()                 {}
+func (AlertActionParamsSlackWebhook) alertActionParams()          {}
+func (AlertActionParamsTelegram) alertActionParams()              {}
+func (AlertActionParamsTopdesk) alertActionParams()               {}
+func (AlertActionParamsWebhook) alertActionParams()               {}
+func (AlertActionParamsZammad) alertActionParams()                {}
+func (AlertActionParamsZendesk) alertActionParams()               {}
+
+// ConnectorType returns the connector type of Autotask connector params
+func (ConnectorParamsAutotask) ConnectorType() string { return ConnectorTypes.Autotask }
+
+// ConnectorType returns the connector type of DingTalk connector params
+func (ConnectorParamsDingTalk) ConnectorType() string { return ConnectorTypes.DingTalk }
+
+// ConnectorType returns the connector type of Discord connector params
+func (ConnectorParamsDiscord) ConnectorType() string { return ConnectorTypes.Discord }
+
+// ConnectorType returns the connector type of Github connector params
+func (ConnectorParamsGithub) ConnectorType() string { return ConnectorTypes.Github }
+
+// ConnectorType returns the connector type of Jira connector params
+func (ConnectorParamsJira) ConnectorType() string { return ConnectorTypes.Jira }
+
+// ConnectorType returns the connector type of Mattermost connector params
+func (ConnectorParamsMattermost) ConnectorType() string { return ConnectorTypes.Mattermost }
+
+// ConnectorType returns the connector type of Microsoft Teams connector params
+func (ConnectorParamsMicrosoftTeams) ConnectorType() string { return ConnectorTypes.MicrosoftTeams }
+
+// ConnectorType returns the connector type of ServiceNow connector params
+func (ConnectorParamsServiceNow) ConnectorType() string { return ConnectorTypes.ServiceNow }
+
+// ConnectorType returns the connector type of Slack connector params
+func (ConnectorParamsSlack) ConnectorType() string { return ConnectorTypes.Slack }
+
+// ConnectorType returns the connector type of TOPdesk connector params
+func (ConnectorParamsTopdesk) ConnectorType() string { return ConnectorTypes.Topdesk }
+
+// ConnectorType returns the connector type of Zammad connector params
+func (ConnectorParamsZammad) ConnectorType() string { return ConnectorTypes.Zammad }
+
+// ConnectorType returns the connector type of Zendesk connector params
+func (ConnectorParamsZendesk) ConnectorType() string { return ConnectorTypes.Zendesk }
+
+func (ConnectorParamsAutotask) connectorParams()       {}
+func (ConnectorParamsDingTalk) connectorParams()       {}
+func (ConnectorParamsDiscord) connectorParams()        {}
+func (ConnectorParamsGithub) connectorParams()         {}
+func (ConnectorParamsJira) connectorParams()           {}
+func (ConnectorParamsMattermost) connectorParams()     {}
+func (ConnectorParamsMicrosoftTeams) connectorParams() {}
+func (ConnectorParamsServiceNow) connectorParams()     {}
+func (ConnectorParamsSlack) connectorParams()          {}
+func (ConnectorParamsTopdesk) connectorParams()        {}
+func (ConnectorParamsZammad) connectorParams()         {}
+func (ConnectorParamsZendesk) connectorParams()        {}
+
+// UnmarshalJSON decodes Autotask alert action params, accepting the company id as string or number
+func (p *AlertActionParamsAutotask) UnmarshalJSON(data []byte) error {
+	type alertActionParamsAutotask AlertActionParamsAutotask
+	var raw struct {
+		*alertActionParamsAutotask
+		CompanyID json.RawMessage `json:"companyId,omitempty"`
+	}
+	raw.alertActionParamsAutotask = (*alertActionParamsAutotask)(p)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.CompanyID = strings.Trim(string(raw.CompanyID), `"`)
+	if p.CompanyID == "null" {
+		p.CompanyID = ""
+	}
+	return nil
+}
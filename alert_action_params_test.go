@@ -0,0 +1,176 @@
+package ilert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fill sets every field of v to a value derived from its name, so round trips of all fields can be compared
+func fill(v reflect.Value, name string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem(), name)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(v.Field(i), v.Type().Field(i).Name)
+			}
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0), name)
+	case reflect.String:
+		v.SetString(name)
+	case reflect.Int, reflect.Int64:
+		v.SetInt(int64(len(name)))
+	case reflect.Bool:
+		v.SetBool(true)
+	}
+}
+
+func TestAlertActionParamsRoundTrip(t *testing.T) {
+	for connectorType, newParams := range alertActionParamsTypes {
+		params := newParams()
+		fill(reflect.ValueOf(params).Elem(), connectorType)
+		if params.ConnectorType() != connectorType {
+			t.Errorf("%s: params report connector type %s", connectorType, params.ConnectorType())
+			continue
+		}
+		data, err := json.Marshal(NewAlertAction("notify", "connector", params))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var raw struct {
+			ConnectorType string          `json:"connectorType"`
+			Params        json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeAlertActionParams(raw.ConnectorType, raw.Params)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", connectorType, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(decoded, params) {
+			t.Errorf("%s: expected params %+v, got %+v", connectorType, params, decoded)
+		}
+	}
+}
+
+func TestConnectorParamsRoundTrip(t *testing.T) {
+	for connectorType, newParams := range connectorParamsTypes {
+		params := newParams()
+		fill(reflect.ValueOf(params).Elem(), connectorType)
+		if params.ConnectorType() != connectorType {
+			t.Errorf("%s: params report connector type %s", connectorType, params.ConnectorType())
+			continue
+		}
+		data, err := json.Marshal(NewConnector("tickets", params))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output := &ConnectorOutput{}
+		if err := json.Unmarshal(data, output); err != nil {
+			t.Errorf("%s: unexpected error %v", connectorType, err)
+			continue
+		}
+
+		if output.Type != connectorType {
+			t.Errorf("%s: expected the connector type, got %s", connectorType, output.Type)
+		}
+		if !reflect.DeepEqual(output.TypedParams, params) {
+			t.Errorf("%s: expected typed params %+v, got %+v", connectorType, params, output.TypedParams)
+		}
+	}
+}
+
+// failingParams are params of a connector type that never decode, like params the server added a field to
+// the typed params cannot hold
+type failingParams struct{}
+
+func (failingParams) ConnectorType() string { return "failing" }
+func (failingParams) alertActionParams()    {}
+func (failingParams) connectorParams()      {}
+
+func (*failingParams) UnmarshalJSON(data []byte) error {
+	return errors.New("cannot decode params")
+}
+
+func TestOutputsKeepUnionParamsWhenTypedParamsFail(t *testing.T) {
+	alertActionParamsTypes["failing"] = func() AlertActionParams { return &failingParams{} }
+	connectorParamsTypes["failing"] = func() ConnectorParams { return &failingParams{} }
+	defer delete(alertActionParamsTypes, "failing")
+	defer delete(connectorParamsTypes, "failing")
+
+	for _, connectorType := range []string{"failing", "unsupported"} {
+		action := &AlertActionOutput{}
+		data := fmt.Sprintf(`{"connectorType":%q,"params":{"companyId":42,"channelId":"C1"}}`, connectorType)
+		if err := json.Unmarshal([]byte(data), action); err != nil {
+			t.Errorf("%s alert action: unexpected error %v", connectorType, err)
+		} else if action.TypedParams != nil || action.Params == nil || action.Params.CompanyID != 42 || action.Params.ChannelID != "C1" {
+			t.Errorf("%s alert action: expected only the union params, got %+v and %+v", connectorType, action.Params, action.TypedParams)
+		}
+
+		connector := &ConnectorOutput{}
+		data = fmt.Sprintf(`{"type":%q,"params":{"url":"https://example.com"}}`, connectorType)
+		if err := json.Unmarshal([]byte(data), connector); err != nil {
+			t.Errorf("%s connector: unexpected error %v", connectorType, err)
+		} else if connector.TypedParams != nil || connector.Params.URL != "https://example.com" {
+			t.Errorf("%s connector: expected only the union params, got %+v and %+v", connectorType, connector.Params, connector.TypedParams)
+		}
+	}
+
+	var outputs []*AlertActionOutput
+	data := `[{"connectorType":"failing","params":{}},{"connectorType":"jira","params":{"project":"OPS"}}]`
+	if err := json.Unmarshal([]byte(data), &outputs); err != nil {
+		t.Fatalf("expected one alert action without typed params not to fail the list, got %v", err)
+	}
+	if params, ok := outputs[1].TypedParams.(*AlertActionParamsJira); !ok || params.Project != "OPS" {
+		t.Errorf("expected typed jira params, got %+v", outputs[1].TypedParams)
+	}
+}
+
+func TestCheckParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		action *AlertAction
+		err    bool
+	}{
+		{"matching", NewAlertAction("notify", "connector", &AlertActionParamsJira{Project: "OPS"}), false},
+		{"matching value", &AlertAction{ConnectorType: ConnectorTypes.Jira, Params: AlertActionParamsJira{}}, false},
+		{"mismatch", &AlertAction{ConnectorType: ConnectorTypes.Slack, Params: &AlertActionParamsJira{}}, true},
+		{"raw params", &AlertAction{ConnectorType: ConnectorTypes.Slack, Params: map[string]interface{}{"project": "OPS"}}, false},
+	}
+	for _, test := range tests {
+		if err := test.action.checkParams(); (err != nil) != test.err {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	connector := &Connector{Type: ConnectorTypes.Slack, Params: &ConnectorParamsJira{}}
+	if err := connector.checkParams(); err == nil {
+		t.Error("expected connector params of another type to fail")
+	}
+	if err := NewConnector("tickets", &ConnectorParamsJira{}).checkParams(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	client := NewClient()
+	_, err := client.CreateAlertAction(&CreateAlertActionInput{AlertAction: &AlertAction{ConnectorType: ConnectorTypes.Slack, Params: &AlertActionParamsJira{}}})
+	if err == nil || !strings.Contains(err.Error(), "do not match") {
+		t.Errorf("expected creating an alert action with mismatching params to fail, got %v", err)
+	}
+	_, err = client.UpdateConnector(&UpdateConnectorInput{ConnectorID: String("1"), Connector: connector})
+	if err == nil || !strings.Contains(err.Error(), "do not match") {
+		t.Errorf("expected updating a connector with mismatching params to fail, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -682,6 +683,11 @@ type CreateAlertSourceOutput struct {
 
 // CreateAlertSource creates a new alert source. https://api.ilert.com/api-docs/#tag/Alert-Sources/paths/~1alert-sources/post
 func (c *Client) CreateAlertSource(input *CreateAlertSourceInput) (*CreateAlertSourceOutput, error) {
+	return c.CreateAlertSourceWithContext(context.Background(), input)
+}
+
+// CreateAlertSourceWithContext is the same as CreateAlertSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateAlertSourceWithContext(ctx context.Context, input *CreateAlertSourceInput) (*CreateAlertSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -715,7 +721,7 @@ func (c *Client) CreateAlertSource(input *CreateAlertSourceInput) (*CreateAlertS
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.AlertSource).Post(fmt.Sprintf("%s?%s", apiRoutes.alertSources, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.AlertSource).Post(fmt.Sprintf("%s?%s", apiRoutes.alertSources, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -750,6 +756,11 @@ type GetAlertSourceOutput struct {
 
 // GetAlertSource gets the alert source with specified id. https://api.ilert.com/api-docs/#tag/Alert-Sources/paths/~1alert-sources~1{id}/get
 func (c *Client) GetAlertSource(input *GetAlertSourceInput) (*GetAlertSourceOutput, error) {
+	return c.GetAlertSourceWithContext(context.Background(), input)
+}
+
+// GetAlertSourceWithContext is the same as GetAlertSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertSourceWithContext(ctx context.Context, input *GetAlertSourceInput) (*GetAlertSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -763,7 +774,7 @@ func (c *Client) GetAlertSource(input *GetAlertSourceInput) (*GetAlertSourceOutp
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d?%s", apiRoutes.alertSources, *input.AlertSourceID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d?%s", apiRoutes.alertSources, *input.AlertSourceID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -800,6 +811,11 @@ type GetAlertSourcesOutput struct {
 
 // GetAlertSources lists existing alert sources. https://api.ilert.com/api-docs/#tag/Alert-Sources/paths/~1alert-sources/get
 func (c *Client) GetAlertSources(input *GetAlertSourcesInput) (*GetAlertSourcesOutput, error) {
+	return c.GetAlertSourcesWithContext(context.Background(), input)
+}
+
+// GetAlertSourcesWithContext is the same as GetAlertSources with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAlertSourcesWithContext(ctx context.Context, input *GetAlertSourcesInput) (*GetAlertSourcesOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -808,7 +824,7 @@ func (c *Client) GetAlertSources(input *GetAlertSourcesInput) (*GetAlertSourcesO
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.alertSources, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.alertSources, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -825,6 +841,32 @@ func (c *Client) GetAlertSources(input *GetAlertSourcesInput) (*GetAlertSourcesO
 	return &GetAlertSourcesOutput{AlertSources: alertSources}, nil
 }
 
+// GetAlertSourcesPages iterates over all pages of a GetAlertSources operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetAlertSourcesPages(input *GetAlertSourcesInput, fn func(page *GetAlertSourcesOutput) bool, options ...PaginateOption) error {
+	return c.GetAlertSourcesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetAlertSourcesPagesWithContext is the same as GetAlertSourcesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetAlertSourcesPagesWithContext(ctx context.Context, input *GetAlertSourcesInput, fn func(page *GetAlertSourcesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetAlertSourcesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*AlertSource, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetAlertSourcesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.AlertSources, nil
+	}
+	return Paginate(ctx, fetch, func(page []*AlertSource) bool {
+		return fn(&GetAlertSourcesOutput{AlertSources: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 50, options)...)
+}
+
 // SearchAlertSourceInput represents the input of a SearchAlertSource operation.
 type SearchAlertSourceInput struct {
 	_               struct{}
@@ -839,6 +881,11 @@ type SearchAlertSourceOutput struct {
 
 // SearchAlertSource gets the alert source with specified name.
 func (c *Client) SearchAlertSource(input *SearchAlertSourceInput) (*SearchAlertSourceOutput, error) {
+	return c.SearchAlertSourceWithContext(context.Background(), input)
+}
+
+// SearchAlertSourceWithContext is the same as SearchAlertSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchAlertSourceWithContext(ctx context.Context, input *SearchAlertSourceInput) (*SearchAlertSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -846,7 +893,7 @@ func (c *Client) SearchAlertSource(input *SearchAlertSourceInput) (*SearchAlertS
 		return nil, errors.New("alert source name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.alertSources, *input.AlertSourceName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.alertSources, *input.AlertSourceName))
 	if err != nil {
 		return nil, err
 	}
@@ -882,6 +929,11 @@ type UpdateAlertSourceOutput struct {
 
 // UpdateAlertSource updates an existing alert source. https://api.ilert.com/api-docs/#tag/Alert-Sources/paths/~1alert-sources~1{id}/put
 func (c *Client) UpdateAlertSource(input *UpdateAlertSourceInput) (*UpdateAlertSourceOutput, error) {
+	return c.UpdateAlertSourceWithContext(context.Background(), input)
+}
+
+// UpdateAlertSourceWithContext is the same as UpdateAlertSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateAlertSourceWithContext(ctx context.Context, input *UpdateAlertSourceInput) (*UpdateAlertSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -918,7 +970,7 @@ func (c *Client) UpdateAlertSource(input *UpdateAlertSourceInput) (*UpdateAlertS
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.AlertSource).Put(fmt.Sprintf("%s/%d?%s", apiRoutes.alertSources, *input.AlertSourceID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.AlertSource).Put(fmt.Sprintf("%s/%d?%s", apiRoutes.alertSources, *input.AlertSourceID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -948,6 +1000,11 @@ type DeleteAlertSourceOutput struct {
 
 // DeleteAlertSource deletes the specified alert source. https://api.ilert.com/api-docs/#tag/Alert-Sources/paths/~1alert-sources~1{id}/delete
 func (c *Client) DeleteAlertSource(input *DeleteAlertSourceInput) (*DeleteAlertSourceOutput, error) {
+	return c.DeleteAlertSourceWithContext(context.Background(), input)
+}
+
+// DeleteAlertSourceWithContext is the same as DeleteAlertSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteAlertSourceWithContext(ctx context.Context, input *DeleteAlertSourceInput) (*DeleteAlertSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -955,7 +1012,7 @@ func (c *Client) DeleteAlertSource(input *DeleteAlertSourceInput) (*DeleteAlertS
 		return nil, errors.New("alert source id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,595 @@
+package ilert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AlertChangeTypes defines the alert transitions detected by an AlertWatcher
+var AlertChangeTypes = struct {
+	Created         string
+	Accepted        string
+	Assigned        string
+	Resolved        string
+	PriorityChanged string
+}{
+	Created:         "CREATED",
+	Accepted:        "ACCEPTED",
+	Assigned:        "ASSIGNED",
+	Resolved:        "RESOLVED",
+	PriorityChanged: "PRIORITY_CHANGED",
+}
+
+// AlertChangeTypesAll defines all alert change types
+var AlertChangeTypesAll = []string{
+	AlertChangeTypes.Created,
+	AlertChangeTypes.Accepted,
+	AlertChangeTypes.Assigned,
+	AlertChangeTypes.Resolved,
+	AlertChangeTypes.PriorityChanged,
+}
+
+// AlertChange is a transition of an alert detected between two polls
+type AlertChange struct {
+	Type  string
+	Alert *Alert
+
+	// state of the alert before the change, nil for created alerts
+	Previous *AlertWatchState
+
+	// time of the poll that detected the change
+	Time time.Time
+
+	// state of the alert once the change is delivered, changes without type are applied but not delivered
+	next AlertWatchState
+
+	// whether the alert left the watched states and is no longer tracked once the change is delivered
+	untrack bool
+}
+
+// AlertWatchState is the state of a watched alert that is compared between polls
+type AlertWatchState struct {
+	Status       string `json:"status"`
+	Priority     string `json:"priority"`
+	AssignedToID int64  `json:"assignedToId,omitempty"`
+	ReportTime   string `json:"reportTime"` // Date time string in ISO format
+}
+
+// AlertWatcherCheckpoint is the persisted progress of an AlertWatcher
+type AlertWatcherCheckpoint struct {
+	// alerts reported since are listed on every poll, Date time string in ISO format
+	From string `json:"from"`
+
+	// id and report time of the newest alert seen
+	LastAlertID    int64  `json:"lastAlertId,omitempty"`
+	LastReportTime string `json:"lastReportTime,omitempty"`
+
+	// delivered state of all watched alerts by id
+	Alerts map[int64]AlertWatchState `json:"alerts"`
+}
+
+// AlertWatcherCheckpointStore loads and saves the checkpoint of an AlertWatcher
+type AlertWatcherCheckpointStore interface {
+	// Load returns the saved checkpoint, nil if there is none yet
+	Load(ctx context.Context) (*AlertWatcherCheckpoint, error)
+	Save(ctx context.Context, checkpoint *AlertWatcherCheckpoint) error
+}
+
+// AlertWatcher polls alerts and emits their transitions on a channel. Its checkpoint holds the state of
+// all watched alerts as delivered, so a watcher restarted from the same checkpoint store continues
+// without missing or repeating transitions.
+type AlertWatcher struct {
+	client       *Client
+	interval     time.Duration
+	states       []string
+	alertSources []int64
+	from         time.Time
+	store        AlertWatcherCheckpointStore
+	onError      func(err error)
+	changes      chan *AlertChange
+	checkpoint   *AlertWatcherCheckpoint
+
+	mu      sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// AlertWatcherOptions allows for options to be passed into the AlertWatcher for customization
+type AlertWatcherOptions func(*AlertWatcher)
+
+// WithAlertWatcherInterval sets the poll interval
+// Default: 30 seconds
+func WithAlertWatcherInterval(interval time.Duration) AlertWatcherOptions {
+	return func(w *AlertWatcher) {
+		w.interval = interval
+	}
+}
+
+// WithAlertWatcherStates only watches alerts in the given states, see AlertStatuses.
+// The transition of an alert out of the watched states is reported once, then the alert is no longer tracked.
+func WithAlertWatcherStates(states ...string) AlertWatcherOptions {
+	return func(w *AlertWatcher) {
+		w.states = append(w.states, states...)
+	}
+}
+
+// WithAlertWatcherAlertSources only watches alerts of the given alert sources
+func WithAlertWatcherAlertSources(alertSourceIDs ...int64) AlertWatcherOptions {
+	return func(w *AlertWatcher) {
+		w.alertSources = append(w.alertSources, alertSourceIDs...)
+	}
+}
+
+// WithAlertWatcherFrom watches alerts reported since the given time when there is no checkpoint yet,
+// alerts reported before are ignored
+// Default: the time of the first poll
+func WithAlertWatcherFrom(from time.Time) AlertWatcherOptions {
+	return func(w *AlertWatcher) {
+		w.from = from
+	}
+}
+
+// WithAlertWatcherCheckpointStore sets the store the checkpoint is loaded from and saved to after every poll
+// Default: none, the checkpoint is kept in memory
+func WithAlertWatcherCheckpointStore(store AlertWatcherCheckpointStore) AlertWatcherOptions {
+	return func(w *AlertWatcher) {
+		w.store = store
+	}
+}
+
+// WithAlertWatcherErrorCallback sets a callback that is invoked with the error of a failed poll
+func WithAlertWatcherErrorCallback(callback func(err error)) AlertWatcherOptions {
+	return func(w *AlertWatcher) {
+		w.onError = callback
+	}
+}
+
+// NewAlertWatcher creates a new alert watcher
+func NewAlertWatcher(client *Client, options ...AlertWatcherOptions) (*AlertWatcher, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	w := &AlertWatcher{
+		client:   client,
+		interval: 30 * time.Second,
+		changes:  make(chan *AlertChange),
+	}
+	for _, opt := range options {
+		opt(w)
+	}
+	if w.interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	return w, nil
+}
+
+// Changes returns the channel transitions are delivered on, it is closed when the watcher stops
+func (w *AlertWatcher) Changes() <-chan *AlertChange {
+	return w.changes
+}
+
+// Checkpoint returns a copy of the current checkpoint, nil before the first poll
+func (w *AlertWatcher) Checkpoint() *AlertWatcherCheckpoint {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.checkpoint == nil {
+		return nil
+	}
+	return w.checkpoint.copy()
+}
+
+// Start runs the watcher in the background until Stop is called
+func (w *AlertWatcher) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		return
+	}
+	w.started = true
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		w.run(ctx)
+	}(w.done)
+}
+
+// Stop stops a watcher started with Start and waits until the checkpoint is saved
+func (w *AlertWatcher) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Run polls immediately and then on every interval until the context is done, then closes the changes channel.
+// A watcher can only run once.
+func (w *AlertWatcher) Run(ctx context.Context) error {
+	w.mu.Lock()
+	if w.started {
+		w.mu.Unlock()
+		return errors.New("alert watcher already started")
+	}
+	w.started = true
+	w.mu.Unlock()
+	return w.run(ctx)
+}
+
+func (w *AlertWatcher) run(ctx context.Context) error {
+	defer close(w.changes)
+	for {
+		changes, err := w.poll(ctx)
+		if err == nil {
+			err = w.deliver(ctx, changes)
+		}
+		if err != nil && ctx.Err() == nil && w.onError != nil {
+			w.onError(err)
+		}
+
+		timer := time.NewTimer(w.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Poll lists the watched alerts once and returns their transitions since the last poll without sending them
+// on the changes channel. The checkpoint is advanced and saved as if the transitions were delivered.
+func (w *AlertWatcher) Poll(ctx context.Context) ([]*AlertChange, error) {
+	changes, err := w.poll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reported := make([]*AlertChange, 0, len(changes))
+	w.mu.Lock()
+	for _, change := range changes {
+		w.checkpoint.apply(change)
+		if change.Type != "" {
+			reported = append(reported, change)
+		}
+	}
+	w.mu.Unlock()
+	return reported, w.save(ctx)
+}
+
+// deliver sends changes on the channel and advances the checkpoint by every delivered change
+func (w *AlertWatcher) deliver(ctx context.Context, changes []*AlertChange) error {
+	var err error
+	for _, change := range changes {
+		if change.Type != "" {
+			select {
+			case w.changes <- change:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+		if err != nil {
+			break
+		}
+		w.mu.Lock()
+		w.checkpoint.apply(change)
+		w.mu.Unlock()
+	}
+	// saving must not be canceled with the context, or the delivered changes would be repeated
+	if saveErr := w.save(context.Background()); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// poll lists the watched alerts and compares them with the checkpoint
+func (w *AlertWatcher) poll(ctx context.Context) ([]*AlertChange, error) {
+	now := time.Now()
+	if err := w.load(ctx, now); err != nil {
+		return nil, err
+	}
+	w.mu.Lock()
+	checkpoint := w.checkpoint.copy()
+	w.mu.Unlock()
+
+	input := &GetAlertsInput{From: String(checkpoint.From)}
+	for _, state := range w.states {
+		input.States = append(input.States, String(state))
+	}
+	for _, alertSourceID := range w.alertSources {
+		input.AlertSources = append(input.AlertSources, Int64(alertSourceID))
+	}
+	alerts := make([]*Alert, 0)
+	listed := make(map[int64]bool)
+	err := w.client.GetAlertsPagesWithContext(ctx, input, func(page *GetAlertsOutput) bool {
+		for _, alert := range page.Alerts {
+			if !listed[alert.ID] {
+				listed[alert.ID] = true
+				alerts = append(alerts, alert)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// watched alerts that are no longer listed e.g. resolved while only open states are watched
+	for id, state := range checkpoint.Alerts {
+		if listed[id] || state.Status == AlertStatuses.Resolved {
+			continue
+		}
+		output, err := w.client.GetAlertWithContext(ctx, &GetAlertInput{AlertID: Int64(id)})
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, output.Alert)
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alertTimeBefore(alerts[i].ReportTime, alerts[j].ReportTime) {
+			return true
+		}
+		if alertTimeBefore(alerts[j].ReportTime, alerts[i].ReportTime) {
+			return false
+		}
+		return alerts[i].ID < alerts[j].ID
+	})
+	changes := make([]*AlertChange, 0)
+	for _, alert := range alerts {
+		alertChanges := alertChanges(checkpoint, alert, now)
+		if !w.watches(alert.Status) {
+			if len(alertChanges) == 0 {
+				alertChanges = append(alertChanges, &AlertChange{Alert: alert, Time: now, next: alertWatchState(alert)})
+			}
+			alertChanges[len(alertChanges)-1].untrack = true
+		}
+		changes = append(changes, alertChanges...)
+	}
+	return changes, nil
+}
+
+// watches reports whether alerts in the given status are watched
+func (w *AlertWatcher) watches(status string) bool {
+	if len(w.states) == 0 {
+		return true
+	}
+	for _, state := range w.states {
+		if state == status {
+			return true
+		}
+	}
+	return false
+}
+
+// alertChanges returns the transitions of an alert since its state in the checkpoint
+func alertChanges(checkpoint *AlertWatcherCheckpoint, alert *Alert, now time.Time) []*AlertChange {
+	current := alertWatchState(alert)
+	changes := make([]*AlertChange, 0)
+	add := func(changeType string, previous *AlertWatchState, next AlertWatchState) {
+		changes = append(changes, &AlertChange{Type: changeType, Alert: alert, Previous: previous, Time: now, next: next})
+	}
+
+	previous, ok := checkpoint.Alerts[alert.ID]
+	if !ok {
+		// created alerts report the transitions they already went through except for assignment and priority
+		state := current
+		state.Status = AlertStatuses.Pending
+		if current.Status == AlertStatuses.New {
+			state.Status = AlertStatuses.New
+		}
+		add(AlertChangeTypes.Created, nil, state)
+		switch current.Status {
+		case AlertStatuses.Accepted:
+			add(AlertChangeTypes.Accepted, &state, current)
+		case AlertStatuses.Resolved:
+			add(AlertChangeTypes.Resolved, &state, current)
+		default:
+			changes[0].next = current
+		}
+		return changes
+	}
+
+	state := previous
+	if current.Priority != state.Priority {
+		next := state
+		next.Priority = current.Priority
+		add(AlertChangeTypes.PriorityChanged, copyAlertWatchState(state), next)
+		state = next
+	}
+	if current.AssignedToID != state.AssignedToID && current.AssignedToID != 0 {
+		next := state
+		next.AssignedToID = current.AssignedToID
+		add(AlertChangeTypes.Assigned, copyAlertWatchState(state), next)
+		state = next
+	}
+	if current.Status != state.Status {
+		next := state
+		next.Status = current.Status
+		switch current.Status {
+		case AlertStatuses.Accepted:
+			add(AlertChangeTypes.Accepted, copyAlertWatchState(state), next)
+		case AlertStatuses.Resolved:
+			add(AlertChangeTypes.Resolved, copyAlertWatchState(state), next)
+		}
+		state = next
+	}
+	if len(changes) > 0 {
+		changes[len(changes)-1].next = current
+	} else if current != previous {
+		// transitions that are not reported e.g. unassignment are applied without being delivered
+		add("", &previous, current)
+	}
+	return changes
+}
+
+func alertWatchState(alert *Alert) AlertWatchState {
+	state := AlertWatchState{Status: alert.Status, Priority: alert.Priority, ReportTime: alert.ReportTime}
+	if alert.AssignedTo != nil {
+		state.AssignedToID = alert.AssignedTo.ID
+	}
+	return state
+}
+
+func copyAlertWatchState(state AlertWatchState) *AlertWatchState {
+	return &state
+}
+
+// load initializes the checkpoint from the store, or from the watcher options if the store has none
+func (w *AlertWatcher) load(ctx context.Context, now time.Time) error {
+	w.mu.Lock()
+	loaded := w.checkpoint != nil
+	w.mu.Unlock()
+	if loaded {
+		return nil
+	}
+	var checkpoint *AlertWatcherCheckpoint
+	if w.store != nil {
+		var err error
+		checkpoint, err = w.store.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("could not load alert watcher checkpoint: %w", err)
+		}
+	}
+	if checkpoint == nil {
+		from := w.from
+		if from.IsZero() {
+			from = now
+		}
+		checkpoint = &AlertWatcherCheckpoint{From: from.UTC().Format(time.RFC3339)}
+	}
+	if checkpoint.Alerts == nil {
+		checkpoint.Alerts = make(map[int64]AlertWatchState)
+	}
+	w.mu.Lock()
+	w.checkpoint = checkpoint
+	w.mu.Unlock()
+	return nil
+}
+
+func (w *AlertWatcher) save(ctx context.Context) error {
+	if w.store == nil {
+		return nil
+	}
+	checkpoint := w.Checkpoint()
+	if checkpoint == nil {
+		return nil
+	}
+	if err := w.store.Save(ctx, checkpoint); err != nil {
+		return fmt.Errorf("could not save alert watcher checkpoint: %w", err)
+	}
+	return nil
+}
+
+// apply advances the checkpoint by a delivered change
+func (c *AlertWatcherCheckpoint) apply(change *AlertChange) {
+	alert := change.Alert
+	if change.untrack {
+		delete(c.Alerts, alert.ID)
+	} else {
+		c.Alerts[alert.ID] = change.next
+	}
+	if alert.ID > c.LastAlertID {
+		c.LastAlertID = alert.ID
+	}
+	if alertTimeBefore(c.LastReportTime, alert.ReportTime) {
+		c.LastReportTime = alert.ReportTime
+	}
+
+	// alerts reported before the oldest unresolved alert are not listed anymore
+	from := c.LastReportTime
+	for _, state := range c.Alerts {
+		if state.Status != AlertStatuses.Resolved && alertTimeBefore(state.ReportTime, from) {
+			from = state.ReportTime
+		}
+	}
+	if alertTimeBefore(c.From, from) {
+		c.From = from
+	}
+	for id, state := range c.Alerts {
+		if state.Status == AlertStatuses.Resolved && alertTimeBefore(state.ReportTime, c.From) {
+			delete(c.Alerts, id)
+		}
+	}
+}
+
+// alertTimeBefore reports whether the ISO time a is before b, comparing the strings when either does not parse
+func alertTimeBefore(a, b string) bool {
+	ta, errA := time.Parse(time.RFC3339Nano, a)
+	tb, errB := time.Parse(time.RFC3339Nano, b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return ta.Before(tb)
+}
+
+func (c *AlertWatcherCheckpoint) copy() *AlertWatcherCheckpoint {
+	out := *c
+	out.Alerts = make(map[int64]AlertWatchState, len(c.Alerts))
+	for id, state := range c.Alerts {
+		out.Alerts[id] = state
+	}
+	return &out
+}
+
+// AlertWatcherFileCheckpointStore saves the checkpoint of an AlertWatcher as JSON file
+type AlertWatcherFileCheckpointStore struct {
+	path string
+}
+
+// NewAlertWatcherFileCheckpointStore creates a checkpoint store writing to the given file path
+func NewAlertWatcherFileCheckpointStore(path string) *AlertWatcherFileCheckpointStore {
+	return &AlertWatcherFileCheckpointStore{path: path}
+}
+
+// Load reads the checkpoint file, nil if it does not exist
+func (s *AlertWatcherFileCheckpointStore) Load(ctx context.Context) (*AlertWatcherCheckpoint, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &AlertWatcherCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint to a temporary file and renames it, so a crash never leaves a partial checkpoint
+func (s *AlertWatcherFileCheckpointStore) Save(ctx context.Context, checkpoint *AlertWatcherCheckpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,317 @@
+package ilert_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+// fakeClock is a manually advanced clock for the fake server
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func changeTypes(changes []*ilert.AlertChange) []string {
+	types := make([]string, 0, len(changes))
+	for _, change := range changes {
+		types = append(types, change.Type)
+	}
+	return types
+}
+
+func assertChangeTypes(t *testing.T, changes []*ilert.AlertChange, expected ...string) {
+	t.Helper()
+	got := changeTypes(changes)
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected changes %v, got %v", expected, got)
+	}
+}
+
+func countAlertLookups(srv *ilerttest.Server) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.Path, "/api/alerts/") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestAlertWatcherUntracksAlertsLeavingWatchedStates(t *testing.T) {
+	clock := newFakeClock()
+	srv := ilerttest.NewServer(ilerttest.WithClock(clock.Now))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+	watcher, err := ilert.NewAlertWatcher(client, ilert.WithAlertWatcherFrom(clock.Now()), ilert.WithAlertWatcherStates(ilert.AlertStatuses.Pending))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alertID := srv.SeedAlert(ilert.Alert{Summary: "db down"})
+
+	changes, err := watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Created)
+
+	if _, err := client.AcceptAlert(&ilert.AcceptAlertInput{AlertID: &alertID}); err != nil {
+		t.Fatal(err)
+	}
+	changes, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Accepted)
+	if n := len(watcher.Checkpoint().Alerts); n != 0 {
+		t.Fatalf("expected the accepted alert to be untracked, %d alerts tracked", n)
+	}
+
+	lookups := countAlertLookups(srv)
+	for i := 0; i < 3; i++ {
+		changes, err = watcher.Poll(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertChangeTypes(t, changes)
+	}
+	if n := countAlertLookups(srv) - lookups; n != 0 {
+		t.Errorf("expected no more lookups of the untracked alert, got %d", n)
+	}
+}
+
+func TestAlertWatcherComparesReportTimesAsTimes(t *testing.T) {
+	clock := newFakeClock()
+	srv := ilerttest.NewServer(ilerttest.WithClock(clock.Now))
+	defer srv.Close()
+	watcher, err := ilert.NewAlertWatcher(srv.Client(), ilert.WithAlertWatcherFrom(clock.Now().Add(-time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 07:30:00.5 UTC sorts after 08:00 UTC as a string
+	early := srv.SeedAlert(ilert.Alert{Summary: "early", ReportTime: "2024-03-01T09:30:00.5+02:00"})
+	late := srv.SeedAlert(ilert.Alert{Summary: "late", ReportTime: "2024-03-01T08:00:00Z"})
+
+	changes, err := watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 2 || changes[0].Alert.ID != early || changes[1].Alert.ID != late {
+		t.Fatalf("expected alert %d before %d, got %d changes", early, late, len(changes))
+	}
+	checkpoint := watcher.Checkpoint()
+	if checkpoint.LastReportTime != "2024-03-01T08:00:00Z" {
+		t.Errorf("expected the last report time of the late alert, got %s", checkpoint.LastReportTime)
+	}
+	if checkpoint.From != "2024-03-01T09:30:00.5+02:00" {
+		t.Errorf("expected the checkpoint to start at the oldest open alert, got %s", checkpoint.From)
+	}
+}
+
+func TestAlertWatcherReportsTransitionsAcrossPolls(t *testing.T) {
+	clock := newFakeClock()
+	srv := ilerttest.NewServer(ilerttest.WithClock(clock.Now))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+	user, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Email: "jane@example.com", Username: "jane"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher, err := ilert.NewAlertWatcher(client, ilert.WithAlertWatcherFrom(clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(time.Minute)
+	if _, err := client.CreateEvent(&ilert.CreateEventInput{Event: alertEvent("db-1")}); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Created)
+	alertID := changes[0].Alert.ID
+	if changes[0].Previous != nil {
+		t.Errorf("expected no previous state for a created alert, got %+v", changes[0].Previous)
+	}
+
+	clock.Advance(time.Minute)
+	if _, err := client.AcceptAlert(&ilert.AcceptAlertInput{AlertID: &alertID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AssignAlert(&ilert.AssignAlertInput{AlertID: &alertID, UserID: &user.User.ID}); err != nil {
+		t.Fatal(err)
+	}
+	changes, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Assigned, ilert.AlertChangeTypes.Accepted)
+	if previous := changes[1].Previous; previous == nil || previous.Status != ilert.AlertStatuses.Pending || previous.AssignedToID != user.User.ID {
+		t.Errorf("expected the acceptance to follow the assignment of a pending alert, got %+v", previous)
+	}
+
+	changes, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes)
+
+	clock.Advance(time.Minute)
+	if _, err := client.CreateEvent(&ilert.CreateEventInput{Event: &ilert.Event{APIKey: "key", EventType: ilert.EventTypes.Resolve, AlertKey: "db-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	changes, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Resolved)
+	if previous := changes[0].Previous; previous == nil || previous.Status != ilert.AlertStatuses.Accepted {
+		t.Errorf("expected the resolved alert to have been accepted, got %+v", previous)
+	}
+
+	changes, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes)
+}
+
+func TestAlertWatcherRestartsFromCheckpointStore(t *testing.T) {
+	clock := newFakeClock()
+	srv := ilerttest.NewServer(ilerttest.WithClock(clock.Now))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+	store := ilert.NewAlertWatcherFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	start := clock.Now()
+	restart := func() *ilert.AlertWatcher {
+		watcher, err := ilert.NewAlertWatcher(client, ilert.WithAlertWatcherFrom(start), ilert.WithAlertWatcherCheckpointStore(store))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return watcher
+	}
+	first := srv.SeedAlert(ilert.Alert{Summary: "db down"})
+
+	changes, err := restart().Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Created)
+
+	// transitions while no watcher runs are reported by the next one
+	clock.Advance(time.Hour)
+	if _, err := client.AcceptAlert(&ilert.AcceptAlertInput{AlertID: &first}); err != nil {
+		t.Fatal(err)
+	}
+	second := srv.SeedAlert(ilert.Alert{Summary: "disk full"})
+	changes, err = restart().Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Accepted, ilert.AlertChangeTypes.Created)
+	if changes[0].Alert.ID != first || changes[1].Alert.ID != second {
+		t.Errorf("expected alert %d to be accepted and %d to be created", first, second)
+	}
+
+	clock.Advance(time.Hour)
+	if _, err := client.ResolveAlert(&ilert.ResolveAlertInput{AlertID: &first}); err != nil {
+		t.Fatal(err)
+	}
+	changes, err = restart().Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Resolved)
+
+	changes, err = restart().Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes)
+}
+
+func TestAlertWatcherCancelDuringDeliverKeepsUndeliveredChanges(t *testing.T) {
+	clock := newFakeClock()
+	srv := ilerttest.NewServer(ilerttest.WithClock(clock.Now))
+	defer srv.Close()
+	store := ilert.NewAlertWatcherFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	start := clock.Now()
+	newWatcher := func() *ilert.AlertWatcher {
+		watcher, err := ilert.NewAlertWatcher(srv.Client(),
+			ilert.WithAlertWatcherFrom(start),
+			ilert.WithAlertWatcherCheckpointStore(store),
+			ilert.WithAlertWatcherInterval(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return watcher
+	}
+	first := srv.SeedAlert(ilert.Alert{Summary: "db down"})
+	clock.Advance(time.Minute)
+	second := srv.SeedAlert(ilert.Alert{Summary: "disk full"})
+
+	watcher := newWatcher()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+	change := <-watcher.Changes()
+	if change.Alert.ID != first {
+		t.Fatalf("expected alert %d to be delivered first, got %d", first, change.Alert.ID)
+	}
+	// the second change is never received
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the run to be canceled, got %v", err)
+	}
+	if _, ok := <-watcher.Changes(); ok {
+		t.Fatal("expected the changes channel to be closed")
+	}
+
+	checkpoint, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := checkpoint.Alerts[first]; !ok || len(checkpoint.Alerts) != 1 {
+		t.Fatalf("expected only the delivered alert in the saved checkpoint, got %+v", checkpoint.Alerts)
+	}
+	changes, err := newWatcher().Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChangeTypes(t, changes, ilert.AlertChangeTypes.Created)
+	if changes[0].Alert.ID != second {
+		t.Errorf("expected only alert %d to be repeated, got %d", second, changes[0].Alert.ID)
+	}
+}
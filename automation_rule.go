@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -50,13 +51,18 @@ type CreateAutomationRuleOutput struct {
 
 // Legacy API - please use alert-actions of type 'automation_rule' - for more information see https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions/post
 func (c *Client) CreateAutomationRule(input *CreateAutomationRuleInput) (*CreateAutomationRuleOutput, error) {
+	return c.CreateAutomationRuleWithContext(context.Background(), input)
+}
+
+// CreateAutomationRuleWithContext is the same as CreateAutomationRule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateAutomationRuleWithContext(ctx context.Context, input *CreateAutomationRuleInput) (*CreateAutomationRuleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.AutomationRule == nil {
 		return nil, errors.New("automationRule input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.AutomationRule).Post(apiRoutes.automationRules)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.AutomationRule).Post(apiRoutes.automationRules)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +102,11 @@ type GetAutomationRulesOutput struct {
 
 // Legacy API - please use alert-actions of type 'automation_rule' - for more information see https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions/post
 func (c *Client) GetAutomationRules(input *GetAutomationRulesInput) (*GetAutomationRulesOutput, error) {
+	return c.GetAutomationRulesWithContext(context.Background(), input)
+}
+
+// GetAutomationRulesWithContext is the same as GetAutomationRules with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAutomationRulesWithContext(ctx context.Context, input *GetAutomationRulesInput) (*GetAutomationRulesOutput, error) {
 	if input == nil {
 		input = &GetAutomationRulesInput{}
 	}
@@ -118,7 +129,7 @@ func (c *Client) GetAutomationRules(input *GetAutomationRulesInput) (*GetAutomat
 		q.Add("service", strconv.Itoa(*input.Service))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.automationRules, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.automationRules, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +146,32 @@ func (c *Client) GetAutomationRules(input *GetAutomationRulesInput) (*GetAutomat
 	return &GetAutomationRulesOutput{AutomationRules: automationRules}, nil
 }
 
+// GetAutomationRulesPages iterates over all pages of a GetAutomationRules operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetAutomationRulesPages(input *GetAutomationRulesInput, fn func(page *GetAutomationRulesOutput) bool, options ...PaginateOption) error {
+	return c.GetAutomationRulesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetAutomationRulesPagesWithContext is the same as GetAutomationRulesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetAutomationRulesPagesWithContext(ctx context.Context, input *GetAutomationRulesInput, fn func(page *GetAutomationRulesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetAutomationRulesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*AutomationRule, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetAutomationRulesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.AutomationRules, nil
+	}
+	return Paginate(ctx, fetch, func(page []*AutomationRule) bool {
+		return fn(&GetAutomationRulesOutput{AutomationRules: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // GetAutomationRuleInput represents the input of a GetAutomationRule operation.
 type GetAutomationRuleInput struct {
 	_                struct{}
@@ -149,6 +186,11 @@ type GetAutomationRuleOutput struct {
 
 // Legacy API - please use alert-actions of type 'automation_rule' - for more information see https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions/post
 func (c *Client) GetAutomationRule(input *GetAutomationRuleInput) (*GetAutomationRuleOutput, error) {
+	return c.GetAutomationRuleWithContext(context.Background(), input)
+}
+
+// GetAutomationRuleWithContext is the same as GetAutomationRule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetAutomationRuleWithContext(ctx context.Context, input *GetAutomationRuleInput) (*GetAutomationRuleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -160,7 +202,7 @@ func (c *Client) GetAutomationRule(input *GetAutomationRuleInput) (*GetAutomatio
 
 	var url = fmt.Sprintf("%s/%s?%s", apiRoutes.automationRules, *input.AutomationRuleID, q.Encode())
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +234,11 @@ type UpdateAutomationRuleOutput struct {
 
 // Legacy API - please use alert-actions of type 'automation_rule' - for more information see https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions/post
 func (c *Client) UpdateAutomationRule(input *UpdateAutomationRuleInput) (*UpdateAutomationRuleOutput, error) {
+	return c.UpdateAutomationRuleWithContext(context.Background(), input)
+}
+
+// UpdateAutomationRuleWithContext is the same as UpdateAutomationRule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateAutomationRuleWithContext(ctx context.Context, input *UpdateAutomationRuleInput) (*UpdateAutomationRuleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -204,7 +251,7 @@ func (c *Client) UpdateAutomationRule(input *UpdateAutomationRuleInput) (*Update
 
 	url := fmt.Sprintf("%s/%s", apiRoutes.automationRules, *input.AutomationRuleID)
 
-	resp, err := c.httpClient.R().SetBody(input.AutomationRule).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.AutomationRule).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -234,6 +281,11 @@ type DeleteAutomationRuleOutput struct {
 
 // Legacy API - please use alert-actions of type 'automation_rule' - for more information see https://api.ilert.com/api-docs/#tag/Alert-Actions/paths/~1alert-actions/post
 func (c *Client) DeleteAutomationRule(input *DeleteAutomationRuleInput) (*DeleteAutomationRuleOutput, error) {
+	return c.DeleteAutomationRuleWithContext(context.Background(), input)
+}
+
+// DeleteAutomationRuleWithContext is the same as DeleteAutomationRule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteAutomationRuleWithContext(ctx context.Context, input *DeleteAutomationRuleInput) (*DeleteAutomationRuleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -243,7 +295,7 @@ func (c *Client) DeleteAutomationRule(input *DeleteAutomationRuleInput) (*Delete
 
 	url := fmt.Sprintf("%s/%s", apiRoutes.automationRules, *input.AutomationRuleID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,240 @@
+// Package backup exports the configuration of an iLert account into a directory of json files and restores it,
+// e.g. into a new account, rewriting the ids of references between the restored resources. A Migrator copies
+// the configuration from one account into another directly.
+//
+// A backup directory holds a manifest.json and one directory per kind with a file per resource,
+// resources that belong to another resource are stored below the id of their parent:
+//
+//	manifest.json
+//	users/42.json
+//	user-email-contacts/42/7.json
+//	escalation-policies/3.json
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/internal/objects"
+)
+
+// FormatVersion is the version of the backup directory layout written by Export, Restore reads backups up to this version
+const FormatVersion = 1
+
+const manifestFile = "manifest.json"
+
+// Manifest describes a backup
+type Manifest struct {
+	FormatVersion int            `json:"formatVersion"`
+	ClientVersion string         `json:"clientVersion"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	Kinds         map[string]int `json:"kinds"` // number of resources per exported kind
+}
+
+// Exporter writes the resources of an iLert account into a backup directory
+type Exporter struct {
+	client *ilert.Client
+	kinds  []string
+}
+
+// ExporterOptions allows for options to be passed into the Exporter for customization
+type ExporterOptions func(*Exporter)
+
+// WithExportKinds only exports resources of the given kinds, one of KindsAll
+// Default: KindsAll
+func WithExportKinds(kinds ...string) ExporterOptions {
+	return func(e *Exporter) {
+		e.kinds = kinds
+	}
+}
+
+// NewExporter creates an exporter using the given client
+func NewExporter(client *ilert.Client, options ...ExporterOptions) *Exporter {
+	e := &Exporter{client: client, kinds: KindsAll}
+	for _, opt := range options {
+		opt(e)
+	}
+	return e
+}
+
+// Export writes all resources into the given directory, which must not exist or be empty.
+// The manifest is written last, a directory without manifest is an incomplete backup.
+// Backups contain secrets like api keys of metric data sources, files are only readable by the owner.
+func (e *Exporter) Export(ctx context.Context, dir string) (*Manifest, error) {
+	if e.client == nil {
+		return nil, errors.New("client is required")
+	}
+	if dir == "" {
+		return nil, errors.New("directory is required")
+	}
+	selected, err := selectKinds(e.kinds)
+	if err != nil {
+		return nil, err
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("directory %s is not empty", dir)
+	}
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		ClientVersion: ilert.Version,
+		CreatedAt:     time.Now().UTC(),
+		Kinds:         make(map[string]int),
+	}
+	ids := make(map[string][]string)
+	for _, k := range kinds {
+		if !selected[k.name] {
+			continue
+		}
+		resources, err := fetchResources(ctx, e.client, k, ids, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range resources {
+			if err := writeJSON(resourcePath(dir, k, res.parentID, res.id), res.obj); err != nil {
+				return nil, err
+			}
+		}
+		manifest.Kinds[k.name] = len(resources)
+	}
+
+	if err := writeJSON(filepath.Join(dir, manifestFile), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// resource is a resource of a backup or a source account
+type resource struct {
+	id       string
+	parentID string
+	obj      map[string]interface{}
+}
+
+// fetchResources fetches the resources of a kind and remembers their ids in ids, with full the resources are fetched
+// one by one for kinds whose list returns less. Resources of nested kinds are fetched for every resource of the parent
+// kind in ids, the parent kind is fetched if it is missing.
+func fetchResources(ctx context.Context, client *ilert.Client, k *kind, ids map[string][]string, full bool) ([]*resource, error) {
+	parentIDs := []string{""}
+	if k.parent != "" {
+		if _, ok := ids[k.parent]; !ok {
+			if _, err := fetchResources(ctx, client, kindByName(k.parent), ids, false); err != nil {
+				return nil, err
+			}
+		}
+		parentIDs = ids[k.parent]
+	}
+	resources := make([]*resource, 0)
+	ids[k.name] = make([]string, 0)
+	for _, parentID := range parentIDs {
+		items, err := k.list(ctx, client, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch %s: %w", k.name, err)
+		}
+		for _, item := range items {
+			obj, err := toJSONObject(item)
+			if err != nil {
+				return nil, err
+			}
+			id := objects.ObjectID(obj)
+			if id == "" {
+				continue
+			}
+			if full && k.get != nil {
+				item, err := k.get(ctx, client, id)
+				if err != nil {
+					return nil, fmt.Errorf("could not fetch %s %s: %w", k.name, id, err)
+				}
+				if obj, err = toJSONObject(item); err != nil {
+					return nil, err
+				}
+			}
+			resources = append(resources, &resource{id: id, parentID: parentID, obj: obj})
+			ids[k.name] = append(ids[k.name], id)
+		}
+	}
+	return resources, nil
+}
+
+// ReadManifest reads the manifest of the backup in the given directory
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s is not a complete backup, %s is missing", dir, manifestFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", manifestFile, err)
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d, supported up to %d", manifest.FormatVersion, FormatVersion)
+	}
+	return manifest, nil
+}
+
+// selectKinds validates the given kind names and returns them as set
+func selectKinds(names []string) (map[string]bool, error) {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		if kindByName(name) == nil {
+			return nil, fmt.Errorf("unknown kind %q", name)
+		}
+		selected[name] = true
+	}
+	return selected, nil
+}
+
+// resourcePath returns the file of a resource, resources of nested kinds are stored below their parent id
+func resourcePath(dir string, k *kind, parentID string, id string) string {
+	if parentID != "" {
+		return filepath.Join(dir, k.name, fileName(parentID), fileName(id)+".json")
+	}
+	return filepath.Join(dir, k.name, fileName(id)+".json")
+}
+
+// fileName escapes ids for use as file names
+func fileName(id string) string {
+	return url.PathEscape(id)
+}
+
+func writeJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// decodeJSON decodes json keeping numbers as json.Number
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// toJSONObject converts a value into its generic JSON representation
+func toJSONObject(v interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj := make(map[string]interface{})
+	if err := decodeJSON(content, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,265 @@
+package backup_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/backup"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+// account holds the ids of the resources created by seedAccount
+type account struct {
+	userID             int64
+	escalationPolicyID int64
+	alertSourceID      int64
+	eventFlowID        int64
+}
+
+// seedAccount creates a user, an escalation policy notifying the user, an alert source using the policy
+// and an event flow routing to the alert source
+func seedAccount(t *testing.T, client *ilert.Client) *account {
+	t.Helper()
+	user, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Email: "jane@example.com", Username: "jane", FirstName: "Jane"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy, err := client.CreateEscalationPolicy(&ilert.CreateEscalationPolicyInput{EscalationPolicy: &ilert.EscalationPolicy{
+		Name:            "Default",
+		EscalationRules: []ilert.EscalationRule{{User: &ilert.User{ID: user.User.ID}, EscalationTimeout: 15}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alertSource, err := client.CreateAlertSource(&ilert.CreateAlertSourceInput{AlertSource: &ilert.AlertSource{
+		Name:             "db",
+		IntegrationType:  "API",
+		EscalationPolicy: &ilert.EscalationPolicy{ID: policy.EscalationPolicy.ID},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow, err := ilert.NewEventFlowBuilder("Routing").Start(ilert.EventFlowRouteEvent("Pager", alertSource.AlertSource.ID)).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	createdFlow, err := client.CreateEventFlow(&ilert.CreateEventFlowInput{EventFlow: flow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &account{
+		userID:             user.User.ID,
+		escalationPolicyID: policy.EscalationPolicy.ID,
+		alertSourceID:      alertSource.AlertSource.ID,
+		eventFlowID:        createdFlow.EventFlow.ID,
+	}
+}
+
+// shiftIDs creates resources that are not part of the backup, so restored resources get other ids than in the source
+func shiftIDs(t *testing.T, client *ilert.Client) {
+	t.Helper()
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: name}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// restoredAccount looks up the resources of seedAccount in an account and checks their references
+func restoredAccount(t *testing.T, client *ilert.Client) *account {
+	t.Helper()
+	users, err := client.GetUsers(&ilert.GetUsersInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policies, err := client.GetEscalationPolicies(&ilert.GetEscalationPoliciesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alertSources, err := client.GetAlertSources(&ilert.GetAlertSourcesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flows, err := client.GetEventFlows(&ilert.GetEventFlowsInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users.Users) != 1 || len(policies.EscalationPolicies) != 1 || len(alertSources.AlertSources) != 1 || len(flows.EventFlows) != 1 {
+		t.Fatalf("expected one resource per kind, got %d users, %d policies, %d alert sources, %d event flows",
+			len(users.Users), len(policies.EscalationPolicies), len(alertSources.AlertSources), len(flows.EventFlows))
+	}
+	a := &account{
+		userID:             users.Users[0].ID,
+		escalationPolicyID: policies.EscalationPolicies[0].ID,
+		alertSourceID:      alertSources.AlertSources[0].ID,
+		eventFlowID:        flows.EventFlows[0].ID,
+	}
+
+	if rule := policies.EscalationPolicies[0].EscalationRules[0]; rule.User == nil || rule.User.ID != a.userID {
+		t.Errorf("expected the escalation rule to reference user %d, got %+v", a.userID, rule.User)
+	}
+	alertSource, err := client.GetAlertSource(&ilert.GetAlertSourceInput{AlertSourceID: &a.alertSourceID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy := alertSource.AlertSource.EscalationPolicy; policy == nil || policy.ID != a.escalationPolicyID {
+		t.Errorf("expected the alert source to reference escalation policy %d, got %+v", a.escalationPolicyID, policy)
+	}
+	if id := routedAlertSourceID(t, flows.EventFlows[0]); id != a.alertSourceID {
+		t.Errorf("expected the event flow to route to alert source %d, got %d", a.alertSourceID, id)
+	}
+	return a
+}
+
+// routedAlertSourceID returns the alert source id of the ROUTE_EVENT node an accepted event enters
+func routedAlertSourceID(t *testing.T, flow *ilert.EventFlowOutput) int64 {
+	t.Helper()
+	if flow.RootNode == nil || len(flow.RootNode.Branches) != 1 || flow.RootNode.Branches[0].Target == nil {
+		t.Fatalf("unexpected event flow %+v", flow)
+	}
+	content, err := json.Marshal(flow.RootNode.Branches[0].Target.Metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := &ilert.EventFlowNodeMetadata{}
+	if err := json.Unmarshal(content, metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata.AlertSourceID == nil {
+		t.Fatalf("expected a routed alert source, got %s", content)
+	}
+	return *metadata.AlertSourceID
+}
+
+func TestExportAndRestore(t *testing.T) {
+	ctx := context.Background()
+	source := ilerttest.NewServer()
+	defer source.Close()
+	seeded := seedAccount(t, source.Client())
+	dir := filepath.Join(t.TempDir(), "backup")
+
+	manifest, err := backup.NewExporter(source.Client()).Export(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kind := range []string{backup.Kinds.User, backup.Kinds.EscalationPolicy, backup.Kinds.AlertSource, backup.Kinds.EventFlow} {
+		if manifest.Kinds[kind] != 1 {
+			t.Errorf("expected 1 exported %s, got %d", kind, manifest.Kinds[kind])
+		}
+	}
+	read, err := backup.ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.FormatVersion != backup.FormatVersion || read.Kinds[backup.Kinds.User] != 1 {
+		t.Errorf("unexpected manifest %+v", read)
+	}
+
+	target := ilerttest.NewServer()
+	defer target.Close()
+	shiftIDs(t, target.Client())
+	report, err := backup.NewRestorer(target.Client()).Restore(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := restoredAccount(t, target.Client())
+	if restored.userID == seeded.userID || restored.alertSourceID == seeded.alertSourceID {
+		t.Fatal("expected the restored resources to get new ids")
+	}
+	ids := map[string][2]int64{
+		backup.Kinds.User:             {seeded.userID, restored.userID},
+		backup.Kinds.EscalationPolicy: {seeded.escalationPolicyID, restored.escalationPolicyID},
+		backup.Kinds.AlertSource:      {seeded.alertSourceID, restored.alertSourceID},
+		backup.Kinds.EventFlow:        {seeded.eventFlowID, restored.eventFlowID},
+	}
+	for kind, id := range ids {
+		if report.Created[kind] != 1 {
+			t.Errorf("expected 1 created %s, got %d", kind, report.Created[kind])
+		}
+		if got := report.IDs[kind][strconv.FormatInt(id[0], 10)]; got != strconv.FormatInt(id[1], 10) {
+			t.Errorf("expected %s %d to be mapped to %d, got %q", kind, id[0], id[1], got)
+		}
+	}
+	if len(report.Unresolved) != 0 || len(report.Failures) != 0 {
+		t.Errorf("unexpected unresolved references %v or failures %v", report.Unresolved, report.Failures)
+	}
+}
+
+func TestRestoreReusesExistingResources(t *testing.T) {
+	ctx := context.Background()
+	source := ilerttest.NewServer()
+	defer source.Close()
+	seedAccount(t, source.Client())
+	dir := filepath.Join(t.TempDir(), "backup")
+	if _, err := backup.NewExporter(source.Client()).Export(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	target := ilerttest.NewServer()
+	defer target.Close()
+	if _, err := backup.NewRestorer(target.Client()).Restore(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	first := restoredAccount(t, target.Client())
+
+	report, err := backup.NewRestorer(target.Client()).Restore(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second := restoredAccount(t, target.Client()); *second != *first {
+		t.Errorf("expected the rerun to keep the restored resources %+v, got %+v", first, second)
+	}
+	for _, kind := range []string{backup.Kinds.User, backup.Kinds.EscalationPolicy, backup.Kinds.AlertSource, backup.Kinds.EventFlow} {
+		if report.Created[kind] != 0 || report.Reused[kind] != 1 {
+			t.Errorf("expected the %s to be reused, created %d reused %d", kind, report.Created[kind], report.Reused[kind])
+		}
+	}
+}
+
+func TestRestoreSelectedKindsKeepsOtherReferences(t *testing.T) {
+	ctx := context.Background()
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	seeded := seedAccount(t, srv.Client())
+	dir := filepath.Join(t.TempDir(), "backup")
+	if _, err := backup.NewExporter(srv.Client()).Export(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Client().DeleteEventFlow(&ilert.DeleteEventFlowInput{EventFlowID: &seeded.eventFlowID}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := backup.NewRestorer(srv.Client(), backup.WithRestoreKinds(backup.Kinds.EventFlow)).Restore(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Created[backup.Kinds.EventFlow] != 1 || report.Created[backup.Kinds.AlertSource] != 0 {
+		t.Errorf("expected only the event flow to be created, got %v", report.Created)
+	}
+	restored := restoredAccount(t, srv.Client())
+	if restored.alertSourceID != seeded.alertSourceID {
+		t.Errorf("expected the alert source reference to keep its id %d, got %d", seeded.alertSourceID, restored.alertSourceID)
+	}
+}
+
+func TestExportRequiresEmptyDirectory(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	dir := t.TempDir()
+	if _, err := backup.NewExporter(srv.Client()).Export(context.Background(), dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := backup.NewExporter(srv.Client()).Export(context.Background(), dir); err == nil {
+		t.Error("expected an export into a non empty directory to fail")
+	}
+	if _, err := backup.NewExporter(srv.Client(), backup.WithExportKinds("unknown")).Export(context.Background(), filepath.Join(dir, "other")); err == nil {
+		t.Error("expected an unknown kind to fail")
+	}
+}
@@ -0,0 +1,1151 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/internal/objects"
+)
+
+// Kinds defines the resource kinds of a backup, each kind is stored in a directory of the same name
+var Kinds = struct {
+	User                       string
+	UserEmailContact           string
+	UserPhoneNumberContact     string
+	UserAlertPreference        string
+	UserDutyPreference         string
+	UserSubscriptionPreference string
+	UserUpdatePreference       string
+	Team                       string
+	Schedule                   string
+	SupportHour                string
+	EscalationPolicy           string
+	Connector                  string
+	MetricDataSource           string
+	Metric                     string
+	Service                    string
+	AlertSource                string
+	AlertAction                string
+	HeartbeatMonitor           string
+	DeploymentPipeline         string
+	IncidentTemplate           string
+	EventFlow                  string
+	CallFlow                   string
+	StatusPage                 string
+	StatusPageGroup            string
+}{
+	User:                       "users",
+	UserEmailContact:           "user-email-contacts",
+	UserPhoneNumberContact:     "user-phone-number-contacts",
+	UserAlertPreference:        "user-alert-preferences",
+	UserDutyPreference:         "user-duty-preferences",
+	UserSubscriptionPreference: "user-subscription-preferences",
+	UserUpdatePreference:       "user-update-preferences",
+	Team:                       "teams",
+	Schedule:                   "schedules",
+	SupportHour:                "support-hours",
+	EscalationPolicy:           "escalation-policies",
+	Connector:                  "connectors",
+	MetricDataSource:           "metric-data-sources",
+	Metric:                     "metrics",
+	Service:                    "services",
+	AlertSource:                "alert-sources",
+	AlertAction:                "alert-actions",
+	HeartbeatMonitor:           "heartbeat-monitors",
+	DeploymentPipeline:         "deployment-pipelines",
+	IncidentTemplate:           "incident-templates",
+	EventFlow:                  "event-flows",
+	CallFlow:                   "call-flows",
+	StatusPage:                 "status-pages",
+	StatusPageGroup:            "status-page-groups",
+}
+
+// KindsAll defines the resource kinds in the order they are restored, every kind comes after the kinds it references
+var KindsAll = []string{
+	Kinds.User,
+	Kinds.UserEmailContact,
+	Kinds.UserPhoneNumberContact,
+	Kinds.UserAlertPreference,
+	Kinds.UserDutyPreference,
+	Kinds.UserSubscriptionPreference,
+	Kinds.UserUpdatePreference,
+	Kinds.Team,
+	Kinds.Schedule,
+	Kinds.SupportHour,
+	Kinds.EscalationPolicy,
+	Kinds.Connector,
+	Kinds.MetricDataSource,
+	Kinds.Metric,
+	Kinds.Service,
+	Kinds.AlertSource,
+	Kinds.AlertAction,
+	Kinds.HeartbeatMonitor,
+	Kinds.DeploymentPipeline,
+	Kinds.IncidentTemplate,
+	Kinds.EventFlow,
+	Kinds.CallFlow,
+	Kinds.StatusPage,
+	Kinds.StatusPageGroup,
+}
+
+// kind describes how resources of a kind are fetched, created and how they reference other resources
+type kind struct {
+	name string
+
+	// kind of the resource the resources belong to e.g. the user of a contact, empty for top level kinds
+	parent string
+
+	// optionally returns the identity of a resource, existing resources with the same identity are reused on restore
+	identity func(obj map[string]interface{}) string
+
+	// rewrites the ids of references to other resources
+	remap func(m *remapper, obj map[string]interface{})
+
+	// fields that may reference resources created later, they are left out on create and set with an update at the end
+	deferred []string
+
+	list   func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error)
+	get    func(ctx context.Context, client *ilert.Client, id string) (interface{}, error) // optional, fetches the full resource if list returns less
+	create func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error)
+	update func(ctx context.Context, client *ilert.Client, id string, body []byte) error // required for top level kinds
+}
+
+var kinds = []*kind{
+	{
+		name:     Kinds.User,
+		identity: objects.UserIdentity,
+		remap:    func(m *remapper, obj map[string]interface{}) {},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetUsersPagesWithContext(ctx, &ilert.GetUsersInput{}, func(page *ilert.GetUsersOutput) bool {
+				for _, item := range page.Users {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			user := &ilert.User{}
+			if err := json.Unmarshal(body, user); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserWithContext(ctx, &ilert.CreateUserInput{User: user})
+			if err != nil {
+				return nil, err
+			}
+			return output.User, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			user := &ilert.User{}
+			if err := json.Unmarshal(body, user); err != nil {
+				return err
+			}
+			userID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateUserWithContext(ctx, &ilert.UpdateUserInput{UserID: ilert.Int64(userID), User: user})
+			return err
+		},
+	},
+	{
+		name:     Kinds.UserEmailContact,
+		parent:   Kinds.User,
+		identity: objects.ContactIdentity,
+		remap:    func(m *remapper, obj map[string]interface{}) {},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetUserEmailContactsWithContext(ctx, &ilert.GetUserEmailContactsInput{UserID: ilert.Int64(userID)})
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(output.UserEmailContacts))
+			for _, item := range output.UserEmailContacts {
+				result = append(result, item)
+			}
+			return result, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			contact := &ilert.UserEmailContact{}
+			if err := json.Unmarshal(body, contact); err != nil {
+				return nil, err
+			}
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserEmailContactWithContext(ctx, &ilert.CreateUserEmailContactInput{UserID: ilert.Int64(userID), UserEmailContact: contact})
+			if err != nil {
+				return nil, err
+			}
+			return output.UserEmailContact, nil
+		},
+	},
+	{
+		name:     Kinds.UserPhoneNumberContact,
+		parent:   Kinds.User,
+		identity: objects.ContactIdentity,
+		remap:    func(m *remapper, obj map[string]interface{}) {},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetUserPhoneNumberContactsWithContext(ctx, &ilert.GetUserPhoneNumberContactsInput{UserID: ilert.Int64(userID)})
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(output.UserPhoneNumberContacts))
+			for _, item := range output.UserPhoneNumberContacts {
+				result = append(result, item)
+			}
+			return result, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			contact := &ilert.UserPhoneNumberContact{}
+			if err := json.Unmarshal(body, contact); err != nil {
+				return nil, err
+			}
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserPhoneNumberContactWithContext(ctx, &ilert.CreateUserPhoneNumberContactInput{UserID: ilert.Int64(userID), UserPhoneNumberContact: contact})
+			if err != nil {
+				return nil, err
+			}
+			return output.UserPhoneNumberContact, nil
+		},
+	},
+	{
+		name:   Kinds.UserAlertPreference,
+		parent: Kinds.User,
+		remap:  remapPreferenceContact,
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetUserAlertPreferencesWithContext(ctx, &ilert.GetUserAlertPreferencesInput{UserID: ilert.Int64(userID)})
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(output.UserAlertPreferences))
+			for _, item := range output.UserAlertPreferences {
+				result = append(result, item)
+			}
+			return result, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			preference := &ilert.UserAlertPreference{}
+			if err := json.Unmarshal(body, preference); err != nil {
+				return nil, err
+			}
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserAlertPreferenceWithContext(ctx, &ilert.CreateUserAlertPreferenceInput{UserID: ilert.Int64(userID), UserAlertPreference: preference})
+			if err != nil {
+				return nil, err
+			}
+			return output.UserAlertPreference, nil
+		},
+	},
+	{
+		name:   Kinds.UserDutyPreference,
+		parent: Kinds.User,
+		remap:  remapPreferenceContact,
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetUserDutyPreferencesWithContext(ctx, &ilert.GetUserDutyPreferencesInput{UserID: ilert.Int64(userID)})
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(output.UserDutyPreferences))
+			for _, item := range output.UserDutyPreferences {
+				result = append(result, item)
+			}
+			return result, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			preference := &ilert.UserDutyPreference{}
+			if err := json.Unmarshal(body, preference); err != nil {
+				return nil, err
+			}
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserDutyPreferenceWithContext(ctx, &ilert.CreateUserDutyPreferenceInput{UserID: ilert.Int64(userID), UserDutyPreference: preference})
+			if err != nil {
+				return nil, err
+			}
+			return output.UserDutyPreference, nil
+		},
+	},
+	{
+		name:   Kinds.UserSubscriptionPreference,
+		parent: Kinds.User,
+		remap:  remapPreferenceContact,
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetUserSubscriptionPreferencesWithContext(ctx, &ilert.GetUserSubscriptionPreferencesInput{UserID: ilert.Int64(userID)})
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(output.UserSubscriptionPreferences))
+			for _, item := range output.UserSubscriptionPreferences {
+				result = append(result, item)
+			}
+			return result, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			preference := &ilert.UserSubscriptionPreference{}
+			if err := json.Unmarshal(body, preference); err != nil {
+				return nil, err
+			}
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserSubscriptionPreferenceWithContext(ctx, &ilert.CreateUserSubscriptionPreferenceInput{UserID: ilert.Int64(userID), UserSubscriptionPreference: preference})
+			if err != nil {
+				return nil, err
+			}
+			return output.UserSubscriptionPreference, nil
+		},
+	},
+	{
+		name:   Kinds.UserUpdatePreference,
+		parent: Kinds.User,
+		remap:  remapPreferenceContact,
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetUserUpdatePreferencesWithContext(ctx, &ilert.GetUserUpdatePreferencesInput{UserID: ilert.Int64(userID)})
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(output.UserUpdatePreferences))
+			for _, item := range output.UserUpdatePreferences {
+				result = append(result, item)
+			}
+			return result, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			preference := &ilert.UserUpdatePreference{}
+			if err := json.Unmarshal(body, preference); err != nil {
+				return nil, err
+			}
+			userID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserUpdatePreferenceWithContext(ctx, &ilert.CreateUserUpdatePreferenceInput{UserID: ilert.Int64(userID), UserUpdatePreference: preference})
+			if err != nil {
+				return nil, err
+			}
+			return output.UserUpdatePreference, nil
+		},
+	},
+	{
+		name:     Kinds.Team,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refList(obj, "members", func(member map[string]interface{}) bool {
+				return m.ref(member, "user", Kinds.User)
+			})
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetTeamsPagesWithContext(ctx, &ilert.GetTeamsInput{}, func(page *ilert.GetTeamsOutput) bool {
+				for _, item := range page.Teams {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			team := &ilert.Team{}
+			if err := json.Unmarshal(body, team); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateTeamWithContext(ctx, &ilert.CreateTeamInput{Team: team})
+			if err != nil {
+				return nil, err
+			}
+			return output.Team, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			team := &ilert.Team{}
+			if err := json.Unmarshal(body, team); err != nil {
+				return err
+			}
+			teamID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateTeamWithContext(ctx, &ilert.UpdateTeamInput{TeamID: ilert.Int64(teamID), Team: team})
+			return err
+		},
+	},
+	{
+		name:     Kinds.Schedule,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refList(obj, "scheduleLayers", func(layer map[string]interface{}) bool {
+				m.refs(layer, "users", Kinds.User)
+				return true
+			})
+			m.refList(obj, "shifts", func(shift map[string]interface{}) bool {
+				return m.ref(shift, "user", Kinds.User)
+			})
+			delete(obj, "currentShift")
+			delete(obj, "nextShift")
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetSchedulesPagesWithContext(ctx, &ilert.GetSchedulesInput{}, func(page *ilert.GetSchedulesOutput) bool {
+				for _, item := range page.Schedules {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			schedule := &ilert.Schedule{}
+			if err := json.Unmarshal(body, schedule); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateScheduleWithContext(ctx, &ilert.CreateScheduleInput{Schedule: schedule})
+			if err != nil {
+				return nil, err
+			}
+			return output.Schedule, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			schedule := &ilert.Schedule{}
+			if err := json.Unmarshal(body, schedule); err != nil {
+				return err
+			}
+			scheduleID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateScheduleWithContext(ctx, &ilert.UpdateScheduleInput{ScheduleID: ilert.Int64(scheduleID), Schedule: schedule})
+			return err
+		},
+	},
+	{
+		name:     Kinds.SupportHour,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetSupportHoursPagesWithContext(ctx, &ilert.GetSupportHoursInput{}, func(page *ilert.GetSupportHoursOutput) bool {
+				for _, item := range page.SupportHours {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			supportHour := &ilert.SupportHour{}
+			if err := json.Unmarshal(body, supportHour); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateSupportHourWithContext(ctx, &ilert.CreateSupportHourInput{SupportHour: supportHour})
+			if err != nil {
+				return nil, err
+			}
+			return output.SupportHour, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			supportHour := &ilert.SupportHour{}
+			if err := json.Unmarshal(body, supportHour); err != nil {
+				return err
+			}
+			supportHourID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateSupportHourWithContext(ctx, &ilert.UpdateSupportHourInput{SupportHourID: ilert.Int64(supportHourID), SupportHour: supportHour})
+			return err
+		},
+	},
+	{
+		name:     Kinds.EscalationPolicy,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refList(obj, "escalationRules", func(rule map[string]interface{}) bool {
+				m.ref(rule, "user", Kinds.User)
+				m.refs(rule, "users", Kinds.User)
+				m.ref(rule, "schedule", Kinds.Schedule)
+				m.refs(rule, "schedules", Kinds.Schedule)
+				m.refs(rule, "teams", Kinds.Team)
+				return true
+			})
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetEscalationPoliciesPagesWithContext(ctx, &ilert.GetEscalationPoliciesInput{}, func(page *ilert.GetEscalationPoliciesOutput) bool {
+				for _, item := range page.EscalationPolicies {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			policy := &ilert.EscalationPolicy{}
+			if err := json.Unmarshal(body, policy); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateEscalationPolicyWithContext(ctx, &ilert.CreateEscalationPolicyInput{EscalationPolicy: policy})
+			if err != nil {
+				return nil, err
+			}
+			return output.EscalationPolicy, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			policy := &ilert.EscalationPolicy{}
+			if err := json.Unmarshal(body, policy); err != nil {
+				return err
+			}
+			escalationPolicyID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateEscalationPolicyWithContext(ctx, &ilert.UpdateEscalationPolicyInput{EscalationPolicyID: ilert.Int64(escalationPolicyID), EscalationPolicy: policy})
+			return err
+		},
+	},
+	{
+		name:     Kinds.Connector,
+		identity: objects.NameIdentity,
+		remap:    func(m *remapper, obj map[string]interface{}) {},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetConnectorsPagesWithContext(ctx, &ilert.GetConnectorsInput{}, func(page *ilert.GetConnectorsOutput) bool {
+				for _, item := range page.Connectors {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			connector := &ilert.Connector{}
+			if err := json.Unmarshal(body, connector); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateConnectorWithContext(ctx, &ilert.CreateConnectorInput{Connector: connector})
+			if err != nil {
+				return nil, err
+			}
+			return output.Connector, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			connector := &ilert.Connector{}
+			if err := json.Unmarshal(body, connector); err != nil {
+				return err
+			}
+			_, err := client.UpdateConnectorWithContext(ctx, &ilert.UpdateConnectorInput{ConnectorID: ilert.String(id), Connector: connector})
+			return err
+		},
+	},
+	{
+		name:     Kinds.MetricDataSource,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetMetricDataSourcesPagesWithContext(ctx, &ilert.GetMetricDataSourcesInput{}, func(page *ilert.GetMetricDataSourcesOutput) bool {
+				for _, item := range page.MetricDataSources {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			dataSource := &ilert.MetricDataSource{}
+			if err := json.Unmarshal(body, dataSource); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateMetricDataSourceWithContext(ctx, &ilert.CreateMetricDataSourceInput{MetricDataSource: dataSource})
+			if err != nil {
+				return nil, err
+			}
+			return output.MetricDataSource, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			dataSource := &ilert.MetricDataSource{}
+			if err := json.Unmarshal(body, dataSource); err != nil {
+				return err
+			}
+			metricDataSourceID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateMetricDataSourceWithContext(ctx, &ilert.UpdateMetricDataSourceInput{MetricDataSourceID: ilert.Int64(metricDataSourceID), MetricDataSource: dataSource})
+			return err
+		},
+	},
+	{
+		name:     Kinds.Metric,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.ref(obj, "dataSource", Kinds.MetricDataSource)
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetMetricsPagesWithContext(ctx, &ilert.GetMetricsInput{}, func(page *ilert.GetMetricsOutput) bool {
+				for _, item := range page.Metrics {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			metric := &ilert.Metric{}
+			if err := json.Unmarshal(body, metric); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateMetricWithContext(ctx, &ilert.CreateMetricInput{Metric: metric})
+			if err != nil {
+				return nil, err
+			}
+			return output.Metric, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			metric := &ilert.Metric{}
+			if err := json.Unmarshal(body, metric); err != nil {
+				return err
+			}
+			metricID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateMetricWithContext(ctx, &ilert.UpdateMetricInput{MetricID: ilert.Int64(metricID), Metric: metric})
+			return err
+		},
+	},
+	{
+		name:     Kinds.Service,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			delete(obj, "uptime")
+			delete(obj, "incidents")
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetServicesPagesWithContext(ctx, &ilert.GetServicesInput{}, func(page *ilert.GetServicesOutput) bool {
+				for _, item := range page.Services {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			service := &ilert.Service{}
+			if err := json.Unmarshal(body, service); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateServiceWithContext(ctx, &ilert.CreateServiceInput{Service: service})
+			if err != nil {
+				return nil, err
+			}
+			return output.Service, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			service := &ilert.Service{}
+			if err := json.Unmarshal(body, service); err != nil {
+				return err
+			}
+			serviceID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateServiceWithContext(ctx, &ilert.UpdateServiceInput{ServiceID: ilert.Int64(serviceID), Service: service})
+			return err
+		},
+	},
+	{
+		name:     Kinds.AlertSource,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.ref(obj, "escalationPolicy", Kinds.EscalationPolicy)
+			m.ref(obj, "supportHours", Kinds.SupportHour)
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetAlertSourcesPagesWithContext(ctx, &ilert.GetAlertSourcesInput{}, func(page *ilert.GetAlertSourcesOutput) bool {
+				for _, item := range page.AlertSources {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			source := &ilert.AlertSource{}
+			if err := json.Unmarshal(body, source); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateAlertSourceWithContext(ctx, &ilert.CreateAlertSourceInput{AlertSource: source})
+			if err != nil {
+				return nil, err
+			}
+			return output.AlertSource, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			source := &ilert.AlertSource{}
+			if err := json.Unmarshal(body, source); err != nil {
+				return err
+			}
+			alertSourceID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateAlertSourceWithContext(ctx, &ilert.UpdateAlertSourceInput{AlertSourceID: ilert.Int64(alertSourceID), AlertSource: source})
+			return err
+		},
+	},
+	{
+		name:     Kinds.AlertAction,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refs(obj, "alertSources", Kinds.AlertSource)
+			m.ids(obj, "alertSourceIds", Kinds.AlertSource)
+			m.id(obj, "connectorId", Kinds.Connector)
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetAlertActionsPagesWithContext(ctx, &ilert.GetAlertActionsInput{}, func(page *ilert.GetAlertActionsOutput) bool {
+				for _, item := range page.AlertActions {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			action := &ilert.AlertAction{}
+			if err := json.Unmarshal(body, action); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateAlertActionWithContext(ctx, &ilert.CreateAlertActionInput{AlertAction: action})
+			if err != nil {
+				return nil, err
+			}
+			return output.AlertAction, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			action := &ilert.AlertAction{}
+			if err := json.Unmarshal(body, action); err != nil {
+				return err
+			}
+			_, err := client.UpdateAlertActionWithContext(ctx, &ilert.UpdateAlertActionInput{AlertActionID: ilert.String(id), AlertAction: action})
+			return err
+		},
+	},
+	{
+		name:     Kinds.HeartbeatMonitor,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.ref(obj, "alertSource", Kinds.AlertSource)
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetHeartbeatMonitorsPagesWithContext(ctx, &ilert.GetHeartbeatMonitorsInput{}, func(page *ilert.GetHeartbeatMonitorsOutput) bool {
+				for _, item := range page.HeartbeatMonitors {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			monitor := &ilert.HeartbeatMonitor{}
+			if err := json.Unmarshal(body, monitor); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateHeartbeatMonitorWithContext(ctx, &ilert.CreateHeartbeatMonitorInput{HeartbeatMonitor: monitor})
+			if err != nil {
+				return nil, err
+			}
+			return output.HeartbeatMonitor, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			monitor := &ilert.HeartbeatMonitor{}
+			if err := json.Unmarshal(body, monitor); err != nil {
+				return err
+			}
+			heartbeatMonitorID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateHeartbeatMonitorWithContext(ctx, &ilert.UpdateHeartbeatMonitorInput{HeartbeatMonitorID: ilert.Int64(heartbeatMonitorID), HeartbeatMonitor: monitor})
+			return err
+		},
+	},
+	{
+		name:     Kinds.DeploymentPipeline,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetDeploymentPipelinesPagesWithContext(ctx, &ilert.GetDeploymentPipelinesInput{}, func(page *ilert.GetDeploymentPipelinesOutput) bool {
+				for _, item := range page.DeploymentPipelines {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			pipeline := &ilert.DeploymentPipeline{}
+			if err := json.Unmarshal(body, pipeline); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateDeploymentPipelineWithContext(ctx, &ilert.CreateDeploymentPipelineInput{DeploymentPipeline: pipeline})
+			if err != nil {
+				return nil, err
+			}
+			return output.DeploymentPipeline, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			pipeline := &ilert.DeploymentPipeline{}
+			if err := json.Unmarshal(body, pipeline); err != nil {
+				return err
+			}
+			deploymentPipelineID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateDeploymentPipelineWithContext(ctx, &ilert.UpdateDeploymentPipelineInput{DeploymentPipelineID: ilert.Int64(deploymentPipelineID), DeploymentPipeline: pipeline})
+			return err
+		},
+	},
+	{
+		name:     Kinds.IncidentTemplate,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetIncidentTemplatesPagesWithContext(ctx, &ilert.GetIncidentTemplatesInput{}, func(page *ilert.GetIncidentTemplatesOutput) bool {
+				for _, item := range page.IncidentTemplates {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			template := &ilert.IncidentTemplate{}
+			if err := json.Unmarshal(body, template); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateIncidentTemplateWithContext(ctx, &ilert.CreateIncidentTemplateInput{IncidentTemplate: template})
+			if err != nil {
+				return nil, err
+			}
+			return output.IncidentTemplate, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			template := &ilert.IncidentTemplate{}
+			if err := json.Unmarshal(body, template); err != nil {
+				return err
+			}
+			incidentTemplateID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateIncidentTemplateWithContext(ctx, &ilert.UpdateIncidentTemplateInput{IncidentTemplateID: ilert.Int64(incidentTemplateID), IncidentTemplate: template})
+			return err
+		},
+	},
+	{
+		name:     Kinds.EventFlow,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refs(obj, "teams", Kinds.Team)
+			m.tree(obj, "root", func(metadata map[string]interface{}) {
+				m.id(metadata, "alertSourceId", Kinds.AlertSource)
+				m.id(metadata, "escalationPolicyId", Kinds.EscalationPolicy)
+				m.id(metadata, "supportHoursId", Kinds.SupportHour)
+				m.id(metadata, "waitStartSupportHoursId", Kinds.SupportHour)
+				m.id(metadata, "waitEndSupportHoursId", Kinds.SupportHour)
+			})
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetEventFlowsPagesWithContext(ctx, &ilert.GetEventFlowsInput{}, func(page *ilert.GetEventFlowsOutput) bool {
+				for _, item := range page.EventFlows {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		get: func(ctx context.Context, client *ilert.Client, id string) (interface{}, error) {
+			flowID, err := objects.ParseID(id)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetEventFlowWithContext(ctx, &ilert.GetEventFlowInput{EventFlowID: ilert.Int64(flowID)})
+			if err != nil {
+				return nil, err
+			}
+			return output.EventFlow, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			flow := &ilert.EventFlow{}
+			if err := json.Unmarshal(body, flow); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateEventFlowWithContext(ctx, &ilert.CreateEventFlowInput{EventFlow: flow})
+			if err != nil {
+				return nil, err
+			}
+			return output.EventFlow, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			flow := &ilert.EventFlow{}
+			if err := json.Unmarshal(body, flow); err != nil {
+				return err
+			}
+			eventFlowID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateEventFlowWithContext(ctx, &ilert.UpdateEventFlowInput{EventFlowID: ilert.Int64(eventFlowID), EventFlow: flow})
+			return err
+		},
+	},
+	{
+		name:     Kinds.CallFlow,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			m.refs(obj, "teams", Kinds.Team)
+			m.tree(obj, "root", func(metadata map[string]interface{}) {
+				m.id(metadata, "alertSourceId", Kinds.AlertSource)
+				m.id(metadata, "supportHoursId", Kinds.SupportHour)
+				m.refList(metadata, "targets", func(target map[string]interface{}) bool {
+					switch target["type"] {
+					case ilert.CallFlowNodeMetadataCallTargetType.User:
+						return m.id(target, "target", Kinds.User)
+					case ilert.CallFlowNodeMetadataCallTargetType.OnCallSchedule:
+						return m.id(target, "target", Kinds.Schedule)
+					}
+					return true
+				})
+			})
+		},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetCallFlowsPagesWithContext(ctx, &ilert.GetCallFlowsInput{}, func(page *ilert.GetCallFlowsOutput) bool {
+				for _, item := range page.CallFlows {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		get: func(ctx context.Context, client *ilert.Client, id string) (interface{}, error) {
+			flowID, err := objects.ParseID(id)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetCallFlowWithContext(ctx, &ilert.GetCallFlowInput{CallFlowID: ilert.Int64(flowID)})
+			if err != nil {
+				return nil, err
+			}
+			return output.CallFlow, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			flow := &ilert.CallFlow{}
+			if err := json.Unmarshal(body, flow); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateCallFlowWithContext(ctx, &ilert.CreateCallFlowInput{CallFlow: flow})
+			if err != nil {
+				return nil, err
+			}
+			return output.CallFlow, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			flow := &ilert.CallFlow{}
+			if err := json.Unmarshal(body, flow); err != nil {
+				return err
+			}
+			callFlowID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateCallFlowWithContext(ctx, &ilert.UpdateCallFlowInput{CallFlowID: ilert.Int64(callFlowID), CallFlow: flow})
+			return err
+		},
+	},
+	{
+		name:     Kinds.StatusPage,
+		identity: objects.NameIdentity,
+		remap: func(m *remapper, obj map[string]interface{}) {
+			// groups are restored as status page groups
+			delete(obj, "groups")
+			m.refs(obj, "services", Kinds.Service)
+			m.refs(obj, "metrics", Kinds.Metric)
+			m.refs(obj, "teams", Kinds.Team)
+			if structure, ok := obj["structure"].(map[string]interface{}); ok {
+				remapStatusPageElements(m, structure, "elements")
+			}
+		},
+		deferred: []string{"structure"},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetStatusPagesPagesWithContext(ctx, &ilert.GetStatusPagesInput{}, func(page *ilert.GetStatusPagesOutput) bool {
+				for _, item := range page.StatusPages {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		get: func(ctx context.Context, client *ilert.Client, id string) (interface{}, error) {
+			pageID, err := objects.ParseID(id)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.GetStatusPageWithContext(ctx, &ilert.GetStatusPageInput{StatusPageID: ilert.Int64(pageID)})
+			if err != nil {
+				return nil, err
+			}
+			return output.StatusPage, nil
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			page := &ilert.StatusPage{}
+			if err := json.Unmarshal(body, page); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateStatusPageWithContext(ctx, &ilert.CreateStatusPageInput{StatusPage: page})
+			if err != nil {
+				return nil, err
+			}
+			return output.StatusPage, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			page := &ilert.StatusPage{}
+			if err := json.Unmarshal(body, page); err != nil {
+				return err
+			}
+			pageID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateStatusPageWithContext(ctx, &ilert.UpdateStatusPageInput{StatusPageID: ilert.Int64(pageID), StatusPage: page})
+			return err
+		},
+	},
+	{
+		name:   Kinds.StatusPageGroup,
+		parent: Kinds.StatusPage,
+		remap:  func(m *remapper, obj map[string]interface{}) {},
+		list: func(ctx context.Context, client *ilert.Client, parentID string) ([]interface{}, error) {
+			pageID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0)
+			err = client.GetStatusPageGroupsPagesWithContext(ctx, &ilert.GetStatusPageGroupsInput{StatusPageID: ilert.Int64(pageID)}, func(page *ilert.GetStatusPageGroupsOutput) bool {
+				for _, item := range page.StatusPageGroups {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, parentID string, body []byte) (interface{}, error) {
+			group := &ilert.StatusPageGroup{}
+			if err := json.Unmarshal(body, group); err != nil {
+				return nil, err
+			}
+			pageID, err := objects.ParseID(parentID)
+			if err != nil {
+				return nil, err
+			}
+			output, err := client.CreateStatusPageGroupWithContext(ctx, &ilert.CreateStatusPageGroupInput{StatusPageID: ilert.Int64(pageID), StatusPageGroup: group})
+			if err != nil {
+				return nil, err
+			}
+			return output.StatusPageGroup, nil
+		},
+	},
+}
+
+// kindByName returns the kind with the given name
+func kindByName(name string) *kind {
+	for _, k := range kinds {
+		if k.name == name {
+			return k
+		}
+	}
+	return nil
+}
+
+// remapPreferenceContact rewrites the contact of a user preference, email preferences use email contacts
+func remapPreferenceContact(m *remapper, obj map[string]interface{}) {
+	if obj["method"] == ilert.UserPreferenceMethod.Email {
+		m.ref(obj, "contact", Kinds.UserEmailContact)
+	} else {
+		m.ref(obj, "contact", Kinds.UserPhoneNumberContact)
+	}
+}
+
+// remapStatusPageElements rewrites the services and groups of the status page structure
+func remapStatusPageElements(m *remapper, obj map[string]interface{}, field string) {
+	m.refList(obj, field, func(element map[string]interface{}) bool {
+		if element["type"] == "GROUP" {
+			if !m.id(element, "id", Kinds.StatusPageGroup) {
+				return false
+			}
+			remapStatusPageElements(m, element, "children")
+			return true
+		}
+		return m.id(element, "id", Kinds.Service)
+	})
+}
@@ -0,0 +1,85 @@
+package backup
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// Migrator copies the resources of one iLert account into another, e.g. from a staging into a production account
+type Migrator struct {
+	source    *ilert.Client
+	target    *ilert.Client
+	kinds     []string
+	overwrite bool
+}
+
+// MigratorOptions allows for options to be passed into the Migrator for customization
+type MigratorOptions func(*Migrator)
+
+// WithMigrateKinds only copies resources of the given kinds, one of KindsAll.
+// References to top level resources of other kinds are resolved by matching their names in the target account.
+// Default: KindsAll
+func WithMigrateKinds(kinds ...string) MigratorOptions {
+	return func(m *Migrator) {
+		m.kinds = kinds
+	}
+}
+
+// WithOverwrite updates resources that exist in the target account with the configuration of the source account,
+// otherwise existing resources are left untouched
+// Default: false
+func WithOverwrite(overwrite bool) MigratorOptions {
+	return func(m *Migrator) {
+		m.overwrite = overwrite
+	}
+}
+
+// NewMigrator creates a migrator copying from the source into the target account
+func NewMigrator(source *ilert.Client, target *ilert.Client, options ...MigratorOptions) *Migrator {
+	m := &Migrator{source: source, target: target, kinds: KindsAll}
+	for _, opt := range options {
+		opt(m)
+	}
+	return m
+}
+
+// Migrate copies the resources of the source account in dependency order. Resources are matched by their identity
+// (the name, the email for users, the target for contacts), missing resources are created and references
+// are rewritten to the ids of the matching resources in the target account. References without a match are left out
+// and reported in Report.Unresolved. A failing resource does not stop the migration, Migrate returns the report
+// with ErrIncomplete in that case.
+func (m *Migrator) Migrate(ctx context.Context) (*Report, error) {
+	if m.source == nil {
+		return nil, errors.New("source client is required")
+	}
+	if m.target == nil {
+		return nil, errors.New("target client is required")
+	}
+	selected, err := selectKinds(m.kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCopier(m.target)
+	c.overwrite = m.overwrite
+	for _, k := range kinds {
+		c.mapped[k.name] = true
+	}
+	ids := make(map[string][]string)
+	for _, k := range kinds {
+		// nested kinds that are not copied are not matched either, references to them are unresolved
+		if !selected[k.name] && k.parent != "" {
+			continue
+		}
+		resources, err := fetchResources(ctx, m.source, k, ids, selected[k.name])
+		if err != nil {
+			return nil, err
+		}
+		if err := c.copy(ctx, k, resources, selected[k.name]); err != nil {
+			return nil, err
+		}
+	}
+	return c.finish(ctx)
+}
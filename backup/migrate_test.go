@@ -0,0 +1,127 @@
+package backup_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/backup"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestMigrateMatchesNamesAndReportsUnresolvedReferences(t *testing.T) {
+	ctx := context.Background()
+	source := ilerttest.NewServer()
+	defer source.Close()
+	sourceClient := source.Client()
+	user, err := sourceClient.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Email: "jane@example.com", Username: "jane"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := sourceClient.CreateSchedule(&ilert.CreateScheduleInput{Schedule: &ilert.Schedule{Name: "Primary", Timezone: "Europe/Berlin", Type: "static"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy, err := sourceClient.CreateEscalationPolicy(&ilert.CreateEscalationPolicyInput{EscalationPolicy: &ilert.EscalationPolicy{
+		Name: "Default",
+		EscalationRules: []ilert.EscalationRule{
+			{User: &ilert.User{ID: user.User.ID}, EscalationTimeout: 15},
+			{Schedule: &ilert.Schedule{ID: schedule.Schedule.ID}, EscalationTimeout: 30},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the target has the user under another id, but not the schedule
+	target := ilerttest.NewServer()
+	defer target.Close()
+	targetClient := target.Client()
+	shiftIDs(t, targetClient)
+	targetUser, err := targetClient.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Email: "Jane@Example.com", Username: "jane"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrator := backup.NewMigrator(sourceClient, targetClient, backup.WithMigrateKinds(backup.Kinds.EscalationPolicy))
+
+	report, err := migrator.Migrate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Created[backup.Kinds.EscalationPolicy] != 1 || report.Created[backup.Kinds.User] != 0 || report.Created[backup.Kinds.Schedule] != 0 {
+		t.Errorf("expected only the escalation policy to be created, got %v", report.Created)
+	}
+	if got := report.IDs[backup.Kinds.User][strconv.FormatInt(user.User.ID, 10)]; got != strconv.FormatInt(targetUser.User.ID, 10) {
+		t.Errorf("expected the user to be matched by email to %d, got %q", targetUser.User.ID, got)
+	}
+	if len(report.Unresolved) != 1 {
+		t.Fatalf("expected 1 unresolved reference, got %v", report.Unresolved)
+	}
+	expected := backup.UnresolvedReference{
+		Kind:    backup.Kinds.EscalationPolicy,
+		ID:      strconv.FormatInt(policy.EscalationPolicy.ID, 10),
+		Field:   "schedule",
+		RefKind: backup.Kinds.Schedule,
+		RefID:   strconv.FormatInt(schedule.Schedule.ID, 10),
+	}
+	if *report.Unresolved[0] != expected {
+		t.Errorf("expected unresolved reference %s, got %s", &expected, report.Unresolved[0])
+	}
+
+	policies, err := targetClient.GetEscalationPolicies(&ilert.GetEscalationPoliciesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies.EscalationPolicies) != 1 {
+		t.Fatalf("expected 1 escalation policy, got %d", len(policies.EscalationPolicies))
+	}
+	rules := policies.EscalationPolicies[0].EscalationRules
+	if len(rules) != 2 || rules[0].User == nil || rules[0].User.ID != targetUser.User.ID || rules[1].Schedule != nil {
+		t.Errorf("expected the user to be remapped and the schedule left out, got %+v", rules)
+	}
+
+	report, err = migrator.Migrate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Created[backup.Kinds.EscalationPolicy] != 0 || report.Reused[backup.Kinds.EscalationPolicy] != 1 {
+		t.Errorf("expected the rerun to reuse the escalation policy, got created %v reused %v", report.Created, report.Reused)
+	}
+	policies, err = targetClient.GetEscalationPolicies(&ilert.GetEscalationPoliciesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := targetClient.GetUsers(&ilert.GetUsersInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies.EscalationPolicies) != 1 || len(users.Users) != 1 {
+		t.Errorf("expected the rerun not to create resources, got %d policies and %d users", len(policies.EscalationPolicies), len(users.Users))
+	}
+}
+
+func TestMigrateCopiesAllKinds(t *testing.T) {
+	ctx := context.Background()
+	source := ilerttest.NewServer()
+	defer source.Close()
+	seeded := seedAccount(t, source.Client())
+	target := ilerttest.NewServer()
+	defer target.Close()
+	shiftIDs(t, target.Client())
+
+	report, err := backup.NewMigrator(source.Client(), target.Client()).Migrate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrated := restoredAccount(t, target.Client())
+	if got := report.IDs[backup.Kinds.AlertSource][strconv.FormatInt(seeded.alertSourceID, 10)]; got != strconv.FormatInt(migrated.alertSourceID, 10) {
+		t.Errorf("expected alert source %d to be mapped to %d, got %q", seeded.alertSourceID, migrated.alertSourceID, got)
+	}
+	if len(report.Unresolved) != 0 || len(report.Failures) != 0 {
+		t.Errorf("unexpected unresolved references %v or failures %v", report.Unresolved, report.Failures)
+	}
+}
@@ -0,0 +1,503 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/internal/objects"
+)
+
+// ErrIncomplete is returned when some resources could not be restored or migrated, see Report.Failures
+var ErrIncomplete = errors.New("not all resources were copied")
+
+// Restorer recreates the resources of a backup directory in an iLert account
+type Restorer struct {
+	client *ilert.Client
+	kinds  []string
+}
+
+// RestorerOptions allows for options to be passed into the Restorer for customization
+type RestorerOptions func(*Restorer)
+
+// WithRestoreKinds only restores resources of the given kinds, one of KindsAll.
+// References to resources of other kinds keep their ids, e.g. when restoring into the same account.
+// Default: KindsAll
+func WithRestoreKinds(kinds ...string) RestorerOptions {
+	return func(r *Restorer) {
+		r.kinds = kinds
+	}
+}
+
+// NewRestorer creates a restorer using the given client
+func NewRestorer(client *ilert.Client, options ...RestorerOptions) *Restorer {
+	r := &Restorer{client: client, kinds: KindsAll}
+	for _, opt := range options {
+		opt(r)
+	}
+	return r
+}
+
+// Report describes the outcome of a restore or migration
+type Report struct {
+	Created map[string]int // number of created resources per kind
+	Reused  map[string]int // number of existing resources with the same identity per kind
+	Updated map[string]int // number of reused resources per kind that were overwritten
+	Skipped map[string]int // number of resources per kind that belong to a reused resource e.g. contacts of an existing user
+
+	// maps the ids of the backup or source account to the ids in the account per kind
+	IDs map[string]map[string]string
+
+	Unresolved []*UnresolvedReference
+	Failures   []*Failure
+}
+
+// UnresolvedReference is a reference to a resource that neither exists in the account nor could be created,
+// the reference is left out of the created resource
+type UnresolvedReference struct {
+	Kind  string // kind of the referencing resource
+	ID    string // original id of the referencing resource
+	Field string
+
+	RefKind string // kind of the referenced resource
+	RefID   string // original id of the referenced resource
+}
+
+func (u *UnresolvedReference) String() string {
+	return fmt.Sprintf("%s %s: %s references unknown %s %s", u.Kind, u.ID, u.Field, u.RefKind, u.RefID)
+}
+
+// Failure is a resource that could not be created or updated
+type Failure struct {
+	Kind string
+	ID   string // original id of the resource
+	Err  error
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("%s %s: %s", f.Kind, f.ID, f.Err)
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// Restore creates the resources of the backup in the given directory in dependency order and rewrites references
+// to the ids of the restored resources. Resources whose identity (the name, the email for users, the target
+// for contacts) already exists in the account are reused instead of created, which allows to restore only what is missing.
+// A failing resource does not stop the restore, Restore returns the report with ErrIncomplete in that case.
+func (r *Restorer) Restore(ctx context.Context, dir string) (*Report, error) {
+	if r.client == nil {
+		return nil, errors.New("client is required")
+	}
+	if dir == "" {
+		return nil, errors.New("directory is required")
+	}
+	selected, err := selectKinds(r.kinds)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCopier(r.client)
+	for name := range selected {
+		if _, ok := manifest.Kinds[name]; ok {
+			c.mapped[name] = true
+		}
+	}
+	for _, k := range kinds {
+		if !c.mapped[k.name] {
+			continue
+		}
+		resources, err := readResources(dir, k)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.copy(ctx, k, resources, true); err != nil {
+			return nil, err
+		}
+	}
+	return c.finish(ctx)
+}
+
+// copier creates resources in an account and maps their original ids to the ids in the account
+type copier struct {
+	client *ilert.Client
+	report *Report
+	m      *remapper
+
+	// kinds whose references are remapped, references to other kinds keep their ids
+	mapped map[string]bool
+
+	// update reused resources with the copied resource
+	overwrite bool
+
+	// reused resources by kind and id in the account, their nested resources are not copied
+	reused   map[string]bool
+	deferred []func()
+}
+
+func newCopier(client *ilert.Client) *copier {
+	report := &Report{
+		Created: make(map[string]int),
+		Reused:  make(map[string]int),
+		Updated: make(map[string]int),
+		Skipped: make(map[string]int),
+		IDs:     make(map[string]map[string]string),
+	}
+	c := &copier{client: client, report: report, mapped: make(map[string]bool), reused: make(map[string]bool)}
+	c.m = &remapper{mapping: report.IDs, mapped: c.mapped, reported: make(map[string]bool)}
+	c.m.unresolved = func(ref *UnresolvedReference) {
+		report.Unresolved = append(report.Unresolved, ref)
+	}
+	return c
+}
+
+// copy maps the resources of a kind to existing resources with the same identity and creates the others.
+// Without create, resources are only mapped to existing resources, e.g. to resolve references to them.
+func (c *copier) copy(ctx context.Context, k *kind, resources []*resource, create bool) error {
+	if c.report.IDs[k.name] == nil {
+		c.report.IDs[k.name] = make(map[string]string)
+	}
+
+	// identities of existing resources per parent id, top level kinds use the empty parent id
+	existing := make(map[string]map[string]string)
+	for _, res := range resources {
+		parentID := ""
+		if k.parent != "" {
+			parentID = res.parentID
+			if c.mapped[k.parent] {
+				var ok bool
+				if parentID, ok = c.report.IDs[k.parent][res.parentID]; !ok {
+					if create {
+						c.fail(k, res, fmt.Errorf("%s %s does not exist in the account", k.parent, res.parentID))
+					}
+					continue
+				}
+			}
+			if create && c.reused[k.parent+"/"+parentID] {
+				c.report.Skipped[k.name]++
+				continue
+			}
+		}
+		if k.identity != nil {
+			if existing[parentID] == nil {
+				ids, err := c.identities(ctx, k, parentID)
+				if err != nil {
+					return err
+				}
+				existing[parentID] = ids
+			}
+			if id := existing[parentID][k.identity(res.obj)]; id != "" {
+				c.report.IDs[k.name][res.id] = id
+				if create {
+					c.reused[k.name+"/"+id] = true
+					c.report.Reused[k.name]++
+					if c.overwrite && k.update != nil {
+						c.update(ctx, k, res, id, true)
+					}
+				}
+				continue
+			}
+		}
+		if !create {
+			continue
+		}
+
+		body := objects.DeepCopy(res.obj).(map[string]interface{})
+		delete(body, "id")
+		for _, field := range k.deferred {
+			delete(body, field)
+		}
+		c.m.kind, c.m.resourceID = k.name, res.id
+		k.remap(c.m, body)
+		id, err := c.create(ctx, k, parentID, body)
+		if err != nil {
+			c.fail(k, res, err)
+			continue
+		}
+		c.report.IDs[k.name][res.id] = id
+		c.report.Created[k.name]++
+		if hasAny(res.obj, k.deferred) {
+			c.update(ctx, k, res, id, false)
+		}
+	}
+	return nil
+}
+
+// update updates a resource with the remapped resource. Updates of kinds with deferred fields are run by finish
+// once all resources exist, the others run right away.
+func (c *copier) update(ctx context.Context, k *kind, res *resource, id string, overwrite bool) {
+	update := func() {
+		body := objects.DeepCopy(res.obj).(map[string]interface{})
+		delete(body, "id")
+		c.m.kind, c.m.resourceID = k.name, res.id
+		k.remap(c.m, body)
+		content, err := json.Marshal(body)
+		if err == nil {
+			err = k.update(ctx, c.client, id, content)
+		}
+		if err != nil {
+			c.fail(k, res, err)
+		} else if overwrite {
+			c.report.Updated[k.name]++
+		}
+	}
+	if len(k.deferred) > 0 {
+		c.deferred = append(c.deferred, update)
+	} else {
+		update()
+	}
+}
+
+// finish runs the deferred updates and returns the report
+func (c *copier) finish(ctx context.Context) (*Report, error) {
+	for _, update := range c.deferred {
+		if ctx.Err() != nil {
+			return c.report, ctx.Err()
+		}
+		update()
+	}
+	if len(c.report.Failures) > 0 {
+		return c.report, fmt.Errorf("%w: %d resources failed", ErrIncomplete, len(c.report.Failures))
+	}
+	return c.report, nil
+}
+
+func (c *copier) fail(k *kind, res *resource, err error) {
+	c.report.Failures = append(c.report.Failures, &Failure{Kind: k.name, ID: res.id, Err: err})
+}
+
+// identities returns the ids of the existing resources of a kind by identity
+func (c *copier) identities(ctx context.Context, k *kind, parentID string) (map[string]string, error) {
+	items, err := k.list(ctx, c.client, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch %s: %w", k.name, err)
+	}
+	result := make(map[string]string, len(items))
+	for _, item := range items {
+		obj, err := toJSONObject(item)
+		if err != nil {
+			return nil, err
+		}
+		if key := k.identity(obj); key != "" {
+			result[key] = objects.ObjectID(obj)
+		}
+	}
+	return result, nil
+}
+
+// create creates a resource and returns its id
+func (c *copier) create(ctx context.Context, k *kind, parentID string, body map[string]interface{}) (string, error) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	created, err := k.create(ctx, c.client, parentID, content)
+	if err != nil {
+		return "", err
+	}
+	obj, err := toJSONObject(created)
+	if err != nil {
+		return "", err
+	}
+	id := objects.ObjectID(obj)
+	if id == "" {
+		return "", errors.New("created resource has no id")
+	}
+	return id, nil
+}
+
+// readResources reads the resources of a kind from the backup directory ordered by parent id and id
+func readResources(dir string, k *kind) ([]*resource, error) {
+	pattern := filepath.Join(dir, k.name, "*.json")
+	if k.parent != "" {
+		pattern = filepath.Join(dir, k.name, "*", "*.json")
+	}
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*resource, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		obj := make(map[string]interface{})
+		if err := decodeJSON(data, &obj); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", file, err)
+		}
+		res := &resource{id: objects.ObjectID(obj), obj: obj}
+		if res.id == "" {
+			return nil, fmt.Errorf("invalid %s: resource has no id", file)
+		}
+		if k.parent != "" {
+			res.parentID, err = url.PathUnescape(filepath.Base(filepath.Dir(file)))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", file, err)
+			}
+		}
+		resources = append(resources, res)
+	}
+	sort.SliceStable(resources, func(a, b int) bool {
+		if resources[a].parentID != resources[b].parentID {
+			return lessID(resources[a].parentID, resources[b].parentID)
+		}
+		return lessID(resources[a].id, resources[b].id)
+	})
+	return resources, nil
+}
+
+// lessID orders numeric ids by value and other ids lexically
+func lessID(a string, b string) bool {
+	if len(a) != len(b) && strings.Trim(a+b, "0123456789") == "" {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func hasAny(obj map[string]interface{}, fields []string) bool {
+	for _, field := range fields {
+		if obj[field] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// remapper rewrites references from the ids of the backup to the ids of the restored resources
+type remapper struct {
+	mapping map[string]map[string]string
+	mapped  map[string]bool // kinds that are remapped, references to other kinds are kept
+
+	// the resource being remapped
+	kind       string
+	resourceID string
+
+	unresolved func(ref *UnresolvedReference)
+	reported   map[string]bool
+}
+
+// lookup returns the new id of a referenced resource
+func (m *remapper) lookup(field string, kindName string, id string) (string, bool) {
+	if !m.mapped[kindName] {
+		return id, true
+	}
+	if newID, ok := m.mapping[kindName][id]; ok {
+		return newID, true
+	}
+	ref := &UnresolvedReference{Kind: m.kind, ID: m.resourceID, Field: field, RefKind: kindName, RefID: id}
+	if key := ref.String(); !m.reported[key] {
+		m.reported[key] = true
+		m.unresolved(ref)
+	}
+	return "", false
+}
+
+// ref rewrites the reference object in the given field of obj, the field is removed if the reference is unresolved
+func (m *remapper) ref(obj map[string]interface{}, field string, kindName string) bool {
+	target, ok := obj[field].(map[string]interface{})
+	if !ok {
+		return true
+	}
+	return m.rewrite(target, "id", field, kindName) || m.drop(obj, field)
+}
+
+// refs rewrites the reference objects in the given array field of obj, unresolved references are removed
+func (m *remapper) refs(obj map[string]interface{}, field string, kindName string) {
+	m.refList(obj, field, func(item map[string]interface{}) bool {
+		return m.rewrite(item, "id", field, kindName)
+	})
+}
+
+// id rewrites the plain id in the given field of obj, the field is removed if the reference is unresolved
+func (m *remapper) id(obj map[string]interface{}, field string, kindName string) bool {
+	return m.rewrite(obj, field, field, kindName)
+}
+
+// rewrite rewrites the id in the given field of obj, unresolved references are reported with the name of the referencing field
+func (m *remapper) rewrite(obj map[string]interface{}, field string, referencingField string, kindName string) bool {
+	id := objects.IDString(obj[field])
+	if id == "" {
+		return true
+	}
+	newID, ok := m.lookup(referencingField, kindName, id)
+	if !ok {
+		return m.drop(obj, field)
+	}
+	if _, isNumber := obj[field].(json.Number); isNumber {
+		obj[field] = json.Number(newID)
+	} else {
+		obj[field] = newID
+	}
+	return true
+}
+
+// ids rewrites the plain ids in the given array field of obj, unresolved ids are removed
+func (m *remapper) ids(obj map[string]interface{}, field string, kindName string) {
+	items, ok := obj[field].([]interface{})
+	if !ok {
+		return
+	}
+	remapped := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		holder := map[string]interface{}{field: item}
+		if m.id(holder, field, kindName) {
+			remapped = append(remapped, holder[field])
+		}
+	}
+	obj[field] = remapped
+}
+
+// refList calls remap for every object in the given array field of obj and removes the objects remap returns false for
+func (m *remapper) refList(obj map[string]interface{}, field string, remap func(item map[string]interface{}) bool) {
+	items, ok := obj[field].([]interface{})
+	if !ok {
+		return
+	}
+	remapped := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		itemObj, ok := item.(map[string]interface{})
+		if ok && !remap(itemObj) {
+			continue
+		}
+		remapped = append(remapped, item)
+	}
+	obj[field] = remapped
+}
+
+// tree calls remap for the metadata of every node of the flow in the given field of obj, node and branch ids are removed
+func (m *remapper) tree(obj map[string]interface{}, field string, remap func(metadata map[string]interface{})) {
+	node, ok := obj[field].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(node, "id")
+	if metadata, ok := node["metadata"].(map[string]interface{}); ok {
+		remap(metadata)
+	}
+	branches, _ := node["branches"].([]interface{})
+	for _, branch := range branches {
+		if branchObj, ok := branch.(map[string]interface{}); ok {
+			delete(branchObj, "id")
+			m.tree(branchObj, "target", remap)
+		}
+	}
+}
+
+// drop removes an unresolved reference and returns false
+func (m *remapper) drop(obj map[string]interface{}, field string) bool {
+	delete(obj, field)
+	return false
+}
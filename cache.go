@@ -0,0 +1,394 @@
+package ilert
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// CacheStats describes the cache usage of a route group
+type CacheStats struct {
+	// route group, i.e. the first path segment below /api e.g. users, teams or schedules
+	Group string
+
+	// responses served from the cache without a request
+	Hits int64
+
+	// responses fetched from the API because they were not cached or expired
+	Misses int64
+
+	// expired responses the API confirmed as unchanged via If-None-Match
+	Revalidations int64
+
+	// cached responses dropped due to create, update or delete requests of the client
+	Invalidations int64
+
+	// responses currently cached
+	Entries int
+}
+
+// uncachedRouteGroups are route groups of frequently changing data, they are only cached with WithRouteCacheTTL
+var uncachedRouteGroups = []string{"alerts", "incidents", "events", "heartbeats", "series"}
+
+// embeddingRouteGroups lists the route groups whose responses embed resources of another route group,
+// e.g. teams embed their member users, changes of a resource also invalidate the groups embedding it
+var embeddingRouteGroups = map[string][]string{
+	"users":               {"teams", "schedules", "escalation-policies", "alert-sources", "uptime-monitors"},
+	"teams":               {"alert-actions", "alert-sources", "escalation-policies", "heartbeat-monitors", "incident-templates", "metrics", "schedules", "services", "status-pages", "support-hours"},
+	"schedules":           {"escalation-policies", "alert-sources", "uptime-monitors"},
+	"escalation-policies": {"alert-sources", "uptime-monitors"},
+	"alert-sources":       {"heartbeat-monitors"},
+	"services":            {"status-pages"},
+	"support-hours":       {"alert-sources"},
+	"metric-data-sources": {"metrics"},
+}
+
+// responseHeadersNotCached are response headers describing the request rather than the resource
+var responseHeadersNotCached = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"}
+
+// responseCache caches successful responses of read requests per route group
+type responseCache struct {
+	mu         sync.Mutex
+	now        func() time.Time
+	ttl        time.Duration
+	ttls       map[string]time.Duration
+	maxEntries int
+	entries    map[string]*cacheEntry
+
+	// incremented on every invalidation of a group, responses of requests started before are not stored
+	generations map[string]uint64
+	stats       map[string]*CacheStats
+}
+
+// cacheEntry is a cached response, entries are not modified once cached but replaced on revalidation
+type cacheEntry struct {
+	group   string
+	status  int
+	header  http.Header
+	body    []byte
+	etag    string
+	stored  time.Time
+	expires time.Time
+}
+
+func newResponseCache() *responseCache {
+	ttls := make(map[string]time.Duration)
+	for _, group := range uncachedRouteGroups {
+		ttls[group] = 0
+	}
+	return &responseCache{
+		now:         time.Now,
+		ttls:        ttls,
+		entries:     make(map[string]*cacheEntry),
+		generations: make(map[string]uint64),
+		stats:       make(map[string]*CacheStats),
+	}
+}
+
+// WithCache enables a read-through cache for Get* and Search* calls. Successful responses are served from the cache
+// for ttl, afterwards responses with an ETag are revalidated using If-None-Match. Create, update and delete calls
+// of the client drop the cached responses of their route group e.g. UpdateTeam drops all cached teams responses,
+// as well as the responses of route groups embedding those resources e.g. UpdateUser also drops cached teams,
+// schedules and escalation policies. Changes made by others become visible once the ttl expired.
+//
+// Alerts, incidents, events, heartbeats and series are not cached unless enabled with WithRouteCacheTTL.
+// At most maxEntries responses are cached, the oldest are dropped first, maxEntries <= 0 does not limit the cache.
+func WithCache(ttl time.Duration, maxEntries int) ClientOptions {
+	return func(c *Client) {
+		c.enableCache()
+		c.cache.mu.Lock()
+		defer c.cache.mu.Unlock()
+		c.cache.ttl = ttl
+		c.cache.maxEntries = maxEntries
+	}
+}
+
+// WithRouteCacheTTL overrides the cache ttl of a single route group e.g. WithRouteCacheTTL("users", time.Hour),
+// a ttl <= 0 disables caching of the group
+func WithRouteCacheTTL(group string, ttl time.Duration) ClientOptions {
+	return func(c *Client) {
+		c.enableCache()
+		c.cache.mu.Lock()
+		defer c.cache.mu.Unlock()
+		c.cache.ttls[group] = ttl
+	}
+}
+
+// CacheStats returns the cache usage per route group, it is empty unless WithCache or WithRouteCacheTTL is used
+func (c *Client) CacheStats() map[string]CacheStats {
+	stats := make(map[string]CacheStats)
+	if c.cache == nil {
+		return stats
+	}
+	cache := c.cache
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for group, s := range cache.stats {
+		stats[group] = *s
+	}
+	for _, entry := range cache.entries {
+		s := stats[entry.group]
+		s.Group = entry.group
+		s.Entries++
+		stats[entry.group] = s
+	}
+	return stats
+}
+
+func (c *Client) enableCache() {
+	if c.cache == nil {
+		c.cache = newResponseCache()
+	}
+}
+
+// installCache routes the requests of the client through the cache, it is called once all client options are applied
+func (c *Client) installCache() {
+	next := c.httpClient.GetClient().Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c.httpClient.SetTransport(&cacheTransport{next: next, cache: c.cache})
+}
+
+// cacheTransport serves read requests from the cache and invalidates it on other requests
+type cacheTransport struct {
+	next  http.RoundTripper
+	cache *responseCache
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	group := routeGroup(req.URL.Path)
+	if !isReadRequest(req) {
+		resp, err := t.next.RoundTrip(req)
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			t.cache.invalidate(group)
+		}
+		return resp, err
+	}
+	ttl := t.cache.ttlOf(group)
+	if ttl <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	req, key, err := cacheKey(req)
+	if err != nil {
+		return nil, err
+	}
+	entry, generation := t.cache.lookup(key, group)
+	if entry != nil && t.cache.now().Before(entry.expires) {
+		t.cache.count(group, func(s *CacheStats) { s.Hits++ })
+		return entry.response(req), nil
+	}
+	if entry != nil && entry.etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return t.cache.revalidate(key, entry, ttl, resp.Header).response(req), nil
+	}
+	t.cache.count(group, func(s *CacheStats) { s.Misses++ })
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if !strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		t.cache.store(key, group, generation, resp, body, ttl)
+	}
+	return resp, nil
+}
+
+// isReadRequest reports whether a request reads resources, i.e. a GET request or a search by POST e.g. search-email
+func isReadRequest(req *http.Request) bool {
+	if req.Method == http.MethodGet {
+		return true
+	}
+	segments := strings.Split(strings.TrimRight(req.URL.Path, "/"), "/")
+	return req.Method == http.MethodPost && strings.HasPrefix(segments[len(segments)-1], "search-")
+}
+
+// cacheKey returns the cache key of a read request, searches by POST include a hash of the body.
+// The returned request must be sent instead of the given one as the body of the given request is consumed.
+func cacheKey(req *http.Request) (*http.Request, string, error) {
+	key := req.Method + " " + req.URL.RequestURI()
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, key, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, "", err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	sum := sha256.Sum256(body)
+	return clone, key + " " + hex.EncodeToString(sum[:]), nil
+}
+
+// fresh reports whether a request can be served from the cache without a request, used to skip rate limiting
+func (rc *responseCache) fresh(r *resty.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[http.MethodGet+" "+u.RequestURI()]
+	return ok && rc.now().Before(entry.expires)
+}
+
+func (rc *responseCache) ttlOf(group string) time.Duration {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if ttl, ok := rc.ttls[group]; ok {
+		return ttl
+	}
+	return rc.ttl
+}
+
+// lookup returns the cached response of a key, if any, and the current generation of the group
+func (rc *responseCache) lookup(key string, group string) (*cacheEntry, uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[key]
+	if ok && entry.etag == "" && !rc.now().Before(entry.expires) {
+		delete(rc.entries, key)
+		entry = nil
+	}
+	return entry, rc.generations[group]
+}
+
+// store caches a response unless the group was invalidated since the request started
+func (rc *responseCache) store(key string, group string, generation uint64, resp *http.Response, body []byte, ttl time.Duration) {
+	header := resp.Header.Clone()
+	for _, name := range responseHeadersNotCached {
+		header.Del(name)
+	}
+	now := rc.now()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.generations[group] != generation {
+		return
+	}
+	rc.entries[key] = &cacheEntry{
+		group:   group,
+		status:  resp.StatusCode,
+		header:  header,
+		body:    body,
+		etag:    resp.Header.Get("ETag"),
+		stored:  now,
+		expires: now.Add(ttl),
+	}
+	rc.evict(now)
+}
+
+// revalidate replaces a response the API confirmed as unchanged by a copy with extended ttl and returns the copy
+func (rc *responseCache) revalidate(key string, entry *cacheEntry, ttl time.Duration, header http.Header) *cacheEntry {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	revalidated := *entry
+	if etag := header.Get("ETag"); etag != "" {
+		revalidated.etag = etag
+	}
+	revalidated.expires = rc.now().Add(ttl)
+	if current, ok := rc.entries[key]; ok && current == entry {
+		rc.entries[key] = &revalidated
+		rc.stat(entry.group).Revalidations++
+	}
+	return &revalidated
+}
+
+// invalidate drops the cached responses of a group and of the groups embedding its resources
+func (rc *responseCache) invalidate(group string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	groups := append([]string{group}, embeddingRouteGroups[group]...)
+	for _, group := range groups {
+		rc.generations[group]++
+		for key, entry := range rc.entries {
+			if entry.group == group {
+				delete(rc.entries, key)
+				rc.stat(group).Invalidations++
+			}
+		}
+	}
+}
+
+// evict drops expired responses without ETag and then the oldest responses beyond maxEntries,
+// the cache must be locked by the caller
+func (rc *responseCache) evict(now time.Time) {
+	if rc.maxEntries <= 0 || len(rc.entries) <= rc.maxEntries {
+		return
+	}
+	for key, entry := range rc.entries {
+		if entry.etag == "" && !now.Before(entry.expires) {
+			delete(rc.entries, key)
+		}
+	}
+	for len(rc.entries) > rc.maxEntries {
+		oldestKey := ""
+		var oldest *cacheEntry
+		for key, entry := range rc.entries {
+			if oldest == nil || entry.stored.Before(oldest.stored) {
+				oldestKey, oldest = key, entry
+			}
+		}
+		delete(rc.entries, oldestKey)
+	}
+}
+
+func (rc *responseCache) count(group string, fn func(s *CacheStats)) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	fn(rc.stat(group))
+}
+
+// stat returns the stats of a group, the cache must be locked by the caller
+func (rc *responseCache) stat(group string) *CacheStats {
+	s, ok := rc.stats[group]
+	if !ok {
+		s = &CacheStats{Group: group}
+		rc.stats[group] = s
+	}
+	return s
+}
+
+// response returns a new response with the cached status, headers and body
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
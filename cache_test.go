@@ -0,0 +1,277 @@
+package ilert_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func countRequests(srv *ilerttest.Server, method string, path string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCacheHitAndMiss(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client(ilert.WithCache(time.Minute, 0))
+	if _, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: "ops"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := client.GetTeams(&ilert.GetTeamsInput{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Teams) != 1 || result.Teams[0].Name != "ops" {
+			t.Fatalf("unexpected teams %+v", result.Teams)
+		}
+	}
+
+	if n := countRequests(srv, http.MethodGet, "/api/teams"); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	stats := client.CacheStats()["teams"]
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCacheExpires(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client(ilert.WithCache(20*time.Millisecond, 0))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTeams(&ilert.GetTeamsInput{}); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	if n := countRequests(srv, http.MethodGet, "/api/teams"); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestCacheUncachedRouteGroups(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client(ilert.WithCache(time.Minute, 0), ilert.WithRouteCacheTTL("teams", 0))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTeams(&ilert.GetTeamsInput{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetAlerts(&ilert.GetAlertsInput{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := countRequests(srv, http.MethodGet, "/api/teams"); n != 2 {
+		t.Errorf("expected 2 teams requests, got %d", n)
+	}
+	if n := countRequests(srv, http.MethodGet, "/api/alerts"); n != 2 {
+		t.Errorf("expected 2 alerts requests, got %d", n)
+	}
+}
+
+func TestCacheInvalidatesOnWrite(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client(ilert.WithCache(time.Minute, 0))
+	created, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: "ops"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTeam(&ilert.GetTeamInput{TeamID: &created.Team.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	team := created.Team
+	team.Name = "sre"
+	if _, err := client.UpdateTeam(&ilert.UpdateTeamInput{TeamID: &team.ID, Team: team}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.GetTeam(&ilert.GetTeamInput{TeamID: &created.Team.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Team.Name != "sre" {
+		t.Errorf("expected updated team, got %q", result.Team.Name)
+	}
+	if stats := client.CacheStats()["teams"]; stats.Invalidations != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCacheInvalidatesEmbeddingRouteGroups(t *testing.T) {
+	tests := []struct {
+		name        string
+		delete      func(client *ilert.Client) error
+		invalidated []string
+		cached      []string
+	}{
+		{
+			name: "users",
+			delete: func(client *ilert.Client) error {
+				user, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Username: "jane", Email: "jane@example.com"}})
+				if err != nil {
+					return err
+				}
+				return cacheAndDelete(client, func() error {
+					_, err := client.DeleteUser(&ilert.DeleteUserInput{UserID: &user.User.ID})
+					return err
+				})
+			},
+			invalidated: []string{"teams", "escalation-policies"},
+			cached:      []string{"services", "metrics"},
+		},
+		{
+			name: "support hours",
+			delete: func(client *ilert.Client) error {
+				supportHour, err := client.CreateSupportHour(&ilert.CreateSupportHourInput{SupportHour: &ilert.SupportHour{Name: "Office", Timezone: "Europe/Berlin"}})
+				if err != nil {
+					return err
+				}
+				return cacheAndDelete(client, func() error {
+					_, err := client.DeleteSupportHour(&ilert.DeleteSupportHourInput{SupportHourID: &supportHour.SupportHour.ID})
+					return err
+				})
+			},
+			invalidated: []string{"alert-sources"},
+			cached:      []string{"teams", "metrics"},
+		},
+		{
+			name: "metric data sources",
+			delete: func(client *ilert.Client) error {
+				source, err := client.CreateMetricDataSource(&ilert.CreateMetricDataSourceInput{MetricDataSource: &ilert.MetricDataSource{Name: "Prometheus", Type: "prometheus"}})
+				if err != nil {
+					return err
+				}
+				return cacheAndDelete(client, func() error {
+					_, err := client.DeleteMetricDataSource(&ilert.DeleteMetricDataSourceInput{MetricDataSourceID: &source.MetricDataSource.ID})
+					return err
+				})
+			},
+			invalidated: []string{"metrics"},
+			cached:      []string{"teams", "alert-sources"},
+		},
+	}
+	for _, test := range tests {
+		srv := ilerttest.NewServer()
+		client := srv.Client(ilert.WithCache(time.Minute, 0))
+
+		err := test.delete(client)
+		srv.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		stats := client.CacheStats()
+		for _, group := range test.invalidated {
+			if stats[group].Entries != 0 || stats[group].Invalidations != 1 {
+				t.Errorf("%s: expected %s to be invalidated, got %+v", test.name, group, stats[group])
+			}
+		}
+		for _, group := range test.cached {
+			if stats[group].Entries != 1 {
+				t.Errorf("%s: expected %s to stay cached, got %+v", test.name, group, stats[group])
+			}
+		}
+	}
+}
+
+// cacheAndDelete caches lists of the route groups checked by TestCacheInvalidatesEmbeddingRouteGroups and then deletes
+func cacheAndDelete(client *ilert.Client, deleteResource func() error) error {
+	reads := []func() error{
+		func() error { _, err := client.GetTeams(&ilert.GetTeamsInput{}); return err },
+		func() error { _, err := client.GetEscalationPolicies(&ilert.GetEscalationPoliciesInput{}); return err },
+		func() error { _, err := client.GetServices(&ilert.GetServicesInput{}); return err },
+		func() error { _, err := client.GetAlertSources(&ilert.GetAlertSourcesInput{}); return err },
+		func() error { _, err := client.GetMetrics(&ilert.GetMetricsInput{}); return err },
+	}
+	for _, read := range reads {
+		if err := read(); err != nil {
+			return err
+		}
+	}
+	return deleteResource()
+}
+
+// etagServer answers every request with the same body and ETag, and with 304 Not Modified if the ETag matches
+func etagServer(calls *int32, notModified *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1,"username":"jane"}`))
+	}))
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	var calls, notModified int32
+	srv := etagServer(&calls, &notModified)
+	defer srv.Close()
+	client := ilert.NewClient(ilert.WithAPIEndpoint(srv.URL), ilert.WithCache(20*time.Millisecond, 0))
+	id := int64(1)
+
+	for i := 0; i < 3; i++ {
+		result, err := client.GetUser(&ilert.GetUserInput{UserID: &id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.User.Username != "jane" {
+			t.Fatalf("unexpected user %+v", result.User)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	if calls != 3 || notModified != 2 {
+		t.Errorf("expected 3 requests of which 2 revalidations, got %d and %d", calls, notModified)
+	}
+	if stats := client.CacheStats()["users"]; stats.Revalidations != 2 || stats.Misses != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCacheConcurrentRevalidation(t *testing.T) {
+	var calls, notModified int32
+	srv := etagServer(&calls, &notModified)
+	defer srv.Close()
+	client := ilert.NewClient(ilert.WithAPIEndpoint(srv.URL), ilert.WithCache(time.Millisecond, 0))
+	id := int64(1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := client.GetUser(&ilert.GetUserInput{UserID: &id}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
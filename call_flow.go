@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -400,6 +401,11 @@ type CreateCallFlowOutput struct {
 
 // CreateCallFlow creates a new call flow resource. https://api.ilert.com/api-docs/#tag/call-flows/post/call-flows
 func (c *Client) CreateCallFlow(input *CreateCallFlowInput) (*CreateCallFlowOutput, error) {
+	return c.CreateCallFlowWithContext(context.Background(), input)
+}
+
+// CreateCallFlowWithContext is the same as CreateCallFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateCallFlowWithContext(ctx context.Context, input *CreateCallFlowInput) (*CreateCallFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -407,7 +413,7 @@ func (c *Client) CreateCallFlow(input *CreateCallFlowInput) (*CreateCallFlowOutp
 		return nil, errors.New("call flow input is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.CallFlow).Post(fmt.Sprintf("%s", apiRoutes.callFlows))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.CallFlow).Post(fmt.Sprintf("%s", apiRoutes.callFlows))
 	if err != nil {
 		return nil, err
 	}
@@ -438,6 +444,11 @@ type GetCallFlowOutput struct {
 
 // GetCallFlow gets the call flows resource with specified id. https://api.ilert.com/api-docs/#tag/call-flows/get/call-flows/{id}
 func (c *Client) GetCallFlow(input *GetCallFlowInput) (*GetCallFlowOutput, error) {
+	return c.GetCallFlowWithContext(context.Background(), input)
+}
+
+// GetCallFlowWithContext is the same as GetCallFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetCallFlowWithContext(ctx context.Context, input *GetCallFlowInput) (*GetCallFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -445,7 +456,7 @@ func (c *Client) GetCallFlow(input *GetCallFlowInput) (*GetCallFlowOutput, error
 		return nil, errors.New("call flow id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.callFlows, *input.CallFlowID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d", apiRoutes.callFlows, *input.CallFlowID))
 	if err != nil {
 		return nil, err
 	}
@@ -482,6 +493,11 @@ type GetCallFlowsOutput struct {
 
 // GetCallFlows lists existing call flow resources. https://api.ilert.com/api-docs/#tag/call-flows/get/call-flows
 func (c *Client) GetCallFlows(input *GetCallFlowsInput) (*GetCallFlowsOutput, error) {
+	return c.GetCallFlowsWithContext(context.Background(), input)
+}
+
+// GetCallFlowsWithContext is the same as GetCallFlows with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetCallFlowsWithContext(ctx context.Context, input *GetCallFlowsInput) (*GetCallFlowsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -490,7 +506,7 @@ func (c *Client) GetCallFlows(input *GetCallFlowsInput) (*GetCallFlowsOutput, er
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.callFlows, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.callFlows, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -507,6 +523,32 @@ func (c *Client) GetCallFlows(input *GetCallFlowsInput) (*GetCallFlowsOutput, er
 	return &GetCallFlowsOutput{CallFlows: callFlows}, nil
 }
 
+// GetCallFlowsPages iterates over all pages of a GetCallFlows operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetCallFlowsPages(input *GetCallFlowsInput, fn func(page *GetCallFlowsOutput) bool, options ...PaginateOption) error {
+	return c.GetCallFlowsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetCallFlowsPagesWithContext is the same as GetCallFlowsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetCallFlowsPagesWithContext(ctx context.Context, input *GetCallFlowsInput, fn func(page *GetCallFlowsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetCallFlowsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*CallFlowOutput, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetCallFlowsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.CallFlows, nil
+	}
+	return Paginate(ctx, fetch, func(page []*CallFlowOutput) bool {
+		return fn(&GetCallFlowsOutput{CallFlows: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchCallFlowInput represents the input of a SearchCallFlow operation.
 type SearchCallFlowInput struct {
 	_            struct{}
@@ -521,6 +563,11 @@ type SearchCallFlowOutput struct {
 
 // SearchCallFlow gets the call flow resource with specified name.
 func (c *Client) SearchCallFlow(input *SearchCallFlowInput) (*SearchCallFlowOutput, error) {
+	return c.SearchCallFlowWithContext(context.Background(), input)
+}
+
+// SearchCallFlowWithContext is the same as SearchCallFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchCallFlowWithContext(ctx context.Context, input *SearchCallFlowInput) (*SearchCallFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -528,7 +575,7 @@ func (c *Client) SearchCallFlow(input *SearchCallFlowInput) (*SearchCallFlowOutp
 		return nil, errors.New("call flow name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.callFlows, *input.CallFlowName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.callFlows, *input.CallFlowName))
 	if err != nil {
 		return nil, err
 	}
@@ -560,6 +607,11 @@ type UpdateCallFlowOutput struct {
 
 // UpdateCallFlow updates an existing call flow resource. https://api.ilert.com/api-docs/#tag/call-flows/put/call-flows/{id}
 func (c *Client) UpdateCallFlow(input *UpdateCallFlowInput) (*UpdateCallFlowOutput, error) {
+	return c.UpdateCallFlowWithContext(context.Background(), input)
+}
+
+// UpdateCallFlowWithContext is the same as UpdateCallFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateCallFlowWithContext(ctx context.Context, input *UpdateCallFlowInput) (*UpdateCallFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -570,7 +622,7 @@ func (c *Client) UpdateCallFlow(input *UpdateCallFlowInput) (*UpdateCallFlowOutp
 		return nil, errors.New("call flow id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.CallFlow).Put(fmt.Sprintf("%s/%d", apiRoutes.callFlows, *input.CallFlowID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.CallFlow).Put(fmt.Sprintf("%s/%d", apiRoutes.callFlows, *input.CallFlowID))
 	if err != nil {
 		return nil, err
 	}
@@ -600,6 +652,11 @@ type DeleteCallFlowOutput struct {
 
 // DeleteCallFlow deletes the specified call flow resource. https://api.ilert.com/api-docs/#tag/call-flows/delete/call-flows/{id}
 func (c *Client) DeleteCallFlow(input *DeleteCallFlowInput) (*DeleteCallFlowOutput, error) {
+	return c.DeleteCallFlowWithContext(context.Background(), input)
+}
+
+// DeleteCallFlowWithContext is the same as DeleteCallFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteCallFlowWithContext(ctx context.Context, input *DeleteCallFlowInput) (*DeleteCallFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -607,7 +664,7 @@ func (c *Client) DeleteCallFlow(input *DeleteCallFlowInput) (*DeleteCallFlowOutp
 		return nil, errors.New("call flow id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.callFlows, *input.CallFlowID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.callFlows, *input.CallFlowID))
 	if err != nil {
 		return nil, err
 	}
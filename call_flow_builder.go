@@ -0,0 +1,547 @@
+package ilert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// CallFlowIVRMenuOptionsAll defines the keys that can be enabled in an IVR_MENU node
+var CallFlowIVRMenuOptionsAll = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "*", "#"}
+
+// CallFlowBuilder builds a call flow starting with the node an answered call enters
+//
+//	flow, err := ilert.NewCallFlowBuilder("Hotline", ilert.CallFlowLanguage.English).
+//		Start(ilert.CallFlowIVRMenu("Menu", "Press 1 for outages, 2 for everything else").
+//			Option("1", ilert.CallFlowRouteCall("On call", ilert.CallFlowNodeMetadataCallStyle.Ordered, ilert.CallTargetSchedule(scheduleID))).
+//			Option("2", ilert.CallFlowVoicemail("Voicemail", "Please leave a message"))).
+//		Build()
+type CallFlowBuilder struct {
+	flow  *CallFlow
+	start *CallFlowNodeBuilder
+}
+
+// NewCallFlowBuilder creates a new call flow builder, language is one of CallFlowLanguage
+func NewCallFlowBuilder(name string, language string) *CallFlowBuilder {
+	return &CallFlowBuilder{flow: &CallFlow{Name: name, Language: language}}
+}
+
+// Teams sets the teams of the call flow
+func (b *CallFlowBuilder) Teams(teams ...TeamShort) *CallFlowBuilder {
+	b.flow.Teams = teams
+	return b
+}
+
+// AssignedNumber sets the number of the call flow
+func (b *CallFlowBuilder) AssignedNumber(number *CallFlowNumber) *CallFlowBuilder {
+	b.flow.AssignedNumber = number
+	return b
+}
+
+// Start sets the node that answered calls enter
+func (b *CallFlowBuilder) Start(node *CallFlowNodeBuilder) *CallFlowBuilder {
+	b.start = node
+	return b
+}
+
+// Build returns the call flow or the errors of Validate
+func (b *CallFlowBuilder) Build() (*CallFlow, error) {
+	flow := *b.flow
+	flow.RootNode = &CallFlowNode{NodeType: CallFlowNodeType.Root, Branches: []CallFlowBranch{}}
+	if b.start != nil {
+		flow.RootNode.Branches = append(flow.RootNode.Branches, CallFlowBranch{
+			BranchType: CallFlowBranchType.Answered,
+			Target:     b.start.Node(),
+		})
+	}
+	if err := flow.Validate(); err != nil {
+		return nil, err
+	}
+	return &flow, nil
+}
+
+// CallFlowNodeBuilder builds a call flow node with typed metadata, create it with one of the CallFlow... node functions
+type CallFlowNodeBuilder struct {
+	node     *CallFlowNode
+	metadata *CallFlowNodeMetadata
+}
+
+func newCallFlowNodeBuilder(nodeType string, name string, metadata *CallFlowNodeMetadata) *CallFlowNodeBuilder {
+	return &CallFlowNodeBuilder{
+		node:     &CallFlowNode{Name: name, NodeType: nodeType, Branches: []CallFlowBranch{}},
+		metadata: metadata,
+	}
+}
+
+// CallFlowIVRMenu creates an IVR_MENU node, add options with Option
+func CallFlowIVRMenu(name string, textMessage string) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.IVRMenu, name, &CallFlowNodeMetadata{TextMessage: textMessage, EnabledOptions: []string{}})
+}
+
+// CallFlowAudioMessage creates an AUDIO_MESSAGE node playing the text message
+func CallFlowAudioMessage(name string, textMessage string) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.AudioMessage, name, &CallFlowNodeMetadata{TextMessage: textMessage})
+}
+
+// CallFlowPlain creates a PLAIN node branching on conditions, the variable is optional
+func CallFlowPlain(name string, varKey string, varValue string) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.Plain, name, &CallFlowNodeMetadata{VarKey: varKey, VarValue: varValue})
+}
+
+// CallFlowSupportHours creates a SUPPORT_HOURS node, continue with During and Outside
+func CallFlowSupportHours(name string, supportHoursID int64) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.SupportHours, name, &CallFlowNodeMetadata{SupportHoursId: supportHoursID})
+}
+
+// CallFlowRouteCall creates a ROUTE_CALL node, callStyle is one of CallFlowNodeMetadataCallStyle
+func CallFlowRouteCall(name string, callStyle string, targets ...CallFlowNodeMetadataCallTarget) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.RouteCall, name, &CallFlowNodeMetadata{CallStyle: callStyle, Targets: targets})
+}
+
+// CallFlowParallelRouteCall creates a PARALLEL_ROUTE_CALL node calling all targets at once
+func CallFlowParallelRouteCall(name string, targets ...CallFlowNodeMetadataCallTarget) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.ParallelRouteCall, name, &CallFlowNodeMetadata{Targets: targets})
+}
+
+// CallFlowVoicemail creates a VOICEMAIL node playing the text message before recording
+func CallFlowVoicemail(name string, textMessage string) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.Voicemail, name, &CallFlowNodeMetadata{TextMessage: textMessage})
+}
+
+// CallFlowPinCode creates a PIN_CODE node, add codes with Code
+func CallFlowPinCode(name string, textMessage string) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.PinCode, name, &CallFlowNodeMetadata{TextMessage: textMessage, Codes: []CallFlowNodeMetadataCode{}})
+}
+
+// CallFlowCreateAlert creates a CREATE_ALERT node creating an alert in the alert source
+func CallFlowCreateAlert(name string, alertSourceID int64) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.CreateAlert, name, &CallFlowNodeMetadata{AlertSourceId: alertSourceID})
+}
+
+// CallFlowBlockNumbers creates a BLOCK_NUMBERS node rejecting calls from the numbers
+func CallFlowBlockNumbers(name string, numbers ...string) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.BlockNumbers, name, &CallFlowNodeMetadata{Blacklist: numbers})
+}
+
+// CallFlowAgentic creates an AGENTIC node, add intents with Intent
+func CallFlowAgentic(name string) *CallFlowNodeBuilder {
+	return newCallFlowNodeBuilder(CallFlowNodeType.Agentic, name, &CallFlowNodeMetadata{Intents: []CallFlowNodeMetadataIntent{}})
+}
+
+// CallTargetUser returns a call target for the user
+func CallTargetUser(userID int64) CallFlowNodeMetadataCallTarget {
+	return CallFlowNodeMetadataCallTarget{Target: strconv.FormatInt(userID, 10), Type: CallFlowNodeMetadataCallTargetType.User}
+}
+
+// CallTargetSchedule returns a call target for the user on call of the schedule
+func CallTargetSchedule(scheduleID int64) CallFlowNodeMetadataCallTarget {
+	return CallFlowNodeMetadataCallTarget{Target: strconv.FormatInt(scheduleID, 10), Type: CallFlowNodeMetadataCallTargetType.OnCallSchedule}
+}
+
+// CallTargetNumber returns a call target for a phone number e.g. +4922112345678
+func CallTargetNumber(number string) CallFlowNodeMetadataCallTarget {
+	return CallFlowNodeMetadataCallTarget{Target: number, Type: CallFlowNodeMetadataCallTargetType.Number}
+}
+
+// Branch adds a branch taken if the condition matches
+func (b *CallFlowNodeBuilder) Branch(condition string, target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	b.node.Branches = append(b.node.Branches, CallFlowBranch{
+		BranchType: CallFlowBranchType.Branch,
+		Condition:  condition,
+		Target:     target.Node(),
+	})
+	return b
+}
+
+// CatchAll adds the branch taken if no other branch matches, e.g. on invalid input or if nobody answered
+func (b *CallFlowNodeBuilder) CatchAll(target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	b.node.Branches = append(b.node.Branches, CallFlowBranch{
+		BranchType: CallFlowBranchType.CatchAll,
+		Target:     target.Node(),
+	})
+	return b
+}
+
+// Then continues with the target, same as CatchAll
+func (b *CallFlowNodeBuilder) Then(target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	return b.CatchAll(target)
+}
+
+// Option enables a key of an IVR_MENU node and adds its branch, one of CallFlowIVRMenuOptionsAll
+func (b *CallFlowNodeBuilder) Option(option string, target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	b.metadata.EnabledOptions = append(b.metadata.EnabledOptions, option)
+	return b.Branch(option, target)
+}
+
+// Code adds a code of a PIN_CODE node and its branch
+func (b *CallFlowNodeBuilder) Code(code int64, label string, target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	b.metadata.Codes = append(b.metadata.Codes, CallFlowNodeMetadataCode{Code: code, Label: label})
+	return b.Branch(strconv.FormatInt(code, 10), target)
+}
+
+// Intent adds an intent of an AGENTIC node and its branch, the branch condition is the intent's label or type
+func (b *CallFlowNodeBuilder) Intent(intent CallFlowNodeMetadataIntent, target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	b.metadata.Intents = append(b.metadata.Intents, intent)
+	return b.Branch(callFlowIntentCondition(intent), target)
+}
+
+// Gather adds information an AGENTIC node asks the caller for
+func (b *CallFlowNodeBuilder) Gather(gathers ...CallFlowNodeMetadataGather) *CallFlowNodeBuilder {
+	b.metadata.Gathers = append(b.metadata.Gathers, gathers...)
+	return b
+}
+
+// Enrichment sets the information an AGENTIC node shares with the caller
+func (b *CallFlowNodeBuilder) Enrichment(enrichment *CallFlowNodeMetadataEnrichment) *CallFlowNodeBuilder {
+	b.metadata.Enrichment = enrichment
+	return b
+}
+
+// During adds the branch of a SUPPORT_HOURS node taken during support hours
+func (b *CallFlowNodeBuilder) During(target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	return b.Branch(SupportStatus.During, target)
+}
+
+// Outside adds the branch of a SUPPORT_HOURS node taken outside of support hours
+func (b *CallFlowNodeBuilder) Outside(target *CallFlowNodeBuilder) *CallFlowNodeBuilder {
+	return b.Branch(SupportStatus.Outside, target)
+}
+
+// Voice sets the AI voice model and language of a node playing a message
+func (b *CallFlowNodeBuilder) Voice(aiVoiceModel string, language string) *CallFlowNodeBuilder {
+	b.metadata.AIVoiceModel = aiVoiceModel
+	b.metadata.Language = language
+	return b
+}
+
+// CustomAudio plays the audio file instead of the text message
+func (b *CallFlowNodeBuilder) CustomAudio(url string) *CallFlowNodeBuilder {
+	b.metadata.CustomAudioUrl = url
+	return b
+}
+
+// HoldAudio sets the audio file played while a ROUTE_CALL node calls its targets
+func (b *CallFlowNodeBuilder) HoldAudio(url string) *CallFlowNodeBuilder {
+	b.metadata.HoldAudioUrl = url
+	return b
+}
+
+// Retries sets the retries of an IVR_MENU, PIN_CODE or ROUTE_CALL node
+func (b *CallFlowNodeBuilder) Retries(retries int64) *CallFlowNodeBuilder {
+	b.metadata.Retries = retries
+	return b
+}
+
+// CallTimeout sets the seconds a ROUTE_CALL node rings a target
+func (b *CallFlowNodeBuilder) CallTimeout(seconds int64) *CallFlowNodeBuilder {
+	b.metadata.CallTimeoutSec = seconds
+	return b
+}
+
+// Node returns the node, nil for a nil builder so that a missing branch target is reported by Validate
+func (b *CallFlowNodeBuilder) Node() *CallFlowNode {
+	if b == nil {
+		return nil
+	}
+	b.node.Metadata = b.metadata
+	return b.node
+}
+
+// Validate reports structural errors of the call flow as FlowValidationErrors, e.g. IVR_MENU options without branches,
+// call targets without id or number, negative retries and timeouts or branches cycling back
+func (f *CallFlow) Validate() error {
+	v := &flowValidator{}
+	if f.Name == "" {
+		v.addf("name", "is required")
+	}
+	if !containsString(CallFlowLanguageAll, f.Language) {
+		v.addf("language", "must be one of %v", CallFlowLanguageAll)
+	}
+	root := f.RootNode
+	if root == nil {
+		v.addf("root", "is required")
+		return v.err()
+	}
+	if root.NodeType != CallFlowNodeType.Root {
+		v.addf("root.nodeType", "must be %s", CallFlowNodeType.Root)
+	}
+	if len(root.Branches) != 1 {
+		v.addf("root.branches", "must contain exactly one %s branch", CallFlowBranchType.Answered)
+	}
+	for i, branch := range root.Branches {
+		path := fmt.Sprintf("root.branches[%d]", i)
+		if branch.BranchType != CallFlowBranchType.Answered {
+			v.addf(path+".branchType", "must be %s", CallFlowBranchType.Answered)
+		}
+		if branch.Target == nil {
+			v.addf(path+".target", "is required")
+			continue
+		}
+		validateCallFlowNode(v, path+".target", branch.Target)
+	}
+	return v.err()
+}
+
+func validateCallFlowNode(v *flowValidator, path string, node *CallFlowNode) {
+	if !v.enter(path, node) {
+		return
+	}
+	defer v.leave(node)
+	if !containsString(CallFlowNodeTypeAll, node.NodeType) || node.NodeType == CallFlowNodeType.Root {
+		v.addf(path+".nodeType", "invalid node type %q", node.NodeType)
+		return
+	}
+	metadata, err := callFlowNodeMetadata(node.Metadata)
+	if err != nil {
+		v.addf(path+".metadata", "%s", err)
+		return
+	}
+
+	// branches
+	catchAll := 0
+	conditions := make(map[string]bool)
+	for i, branch := range node.Branches {
+		branchPath := fmt.Sprintf("%s.branches[%d]", path, i)
+		switch branch.BranchType {
+		case CallFlowBranchType.Branch:
+			if branch.Condition == "" {
+				v.addf(branchPath+".condition", "is required")
+			} else if conditions[branch.Condition] {
+				v.addf(branchPath+".condition", "duplicate condition %q", branch.Condition)
+			}
+			conditions[branch.Condition] = true
+		case CallFlowBranchType.CatchAll:
+			catchAll++
+			if catchAll > 1 {
+				v.addf(branchPath+".branchType", "only one %s branch is allowed", CallFlowBranchType.CatchAll)
+			}
+			if branch.Condition != "" {
+				v.addf(branchPath+".condition", "must be empty for %s", CallFlowBranchType.CatchAll)
+			}
+		case CallFlowBranchType.Answered:
+			v.addf(branchPath+".branchType", "%s is only allowed on the root node", CallFlowBranchType.Answered)
+		default:
+			v.addf(branchPath+".branchType", "invalid branch type %q", branch.BranchType)
+		}
+	}
+	// branchConditions checks that the BRANCH conditions are exactly the allowed values
+	branchConditions := func(what string, allowed []string) {
+		for i, branch := range node.Branches {
+			if branch.BranchType == CallFlowBranchType.Branch && branch.Condition != "" && !containsString(allowed, branch.Condition) {
+				v.addf(fmt.Sprintf("%s.branches[%d].condition", path, i), "no %s %q", what, branch.Condition)
+			}
+		}
+		for _, value := range allowed {
+			if !conditions[value] {
+				v.addf(path+".branches", "no branch for %s %q", what, value)
+			}
+		}
+	}
+
+	// metadata
+	metadataPath := path + ".metadata"
+	switch node.NodeType {
+	case CallFlowNodeType.IVRMenu:
+		validateCallFlowMessage(v, metadataPath, metadata)
+		validateCallFlowRetries(v, metadataPath, metadata)
+		if len(metadata.EnabledOptions) == 0 {
+			v.addf(metadataPath+".enabledOptions", "at least one option is required")
+		}
+		seen := make(map[string]bool)
+		for i, option := range metadata.EnabledOptions {
+			if !containsString(CallFlowIVRMenuOptionsAll, option) {
+				v.addf(fmt.Sprintf("%s.enabledOptions[%d]", metadataPath, i), "must be one of %v", CallFlowIVRMenuOptionsAll)
+			} else if seen[option] {
+				v.addf(fmt.Sprintf("%s.enabledOptions[%d]", metadataPath, i), "duplicate option %q", option)
+			}
+			seen[option] = true
+		}
+		branchConditions("enabled option", metadata.EnabledOptions)
+	case CallFlowNodeType.AudioMessage, CallFlowNodeType.Voicemail:
+		validateCallFlowMessage(v, metadataPath, metadata)
+		validateCallFlowContinuation(v, path, node)
+	case CallFlowNodeType.PinCode:
+		validateCallFlowMessage(v, metadataPath, metadata)
+		validateCallFlowRetries(v, metadataPath, metadata)
+		if len(metadata.Codes) == 0 {
+			v.addf(metadataPath+".codes", "at least one code is required")
+		}
+		codes := make([]string, 0, len(metadata.Codes))
+		for i, code := range metadata.Codes {
+			codePath := fmt.Sprintf("%s.codes[%d]", metadataPath, i)
+			value := strconv.FormatInt(code.Code, 10)
+			if code.Code <= 0 {
+				v.addf(codePath+".code", "must be a positive number")
+			} else if containsString(codes, value) {
+				v.addf(codePath+".code", "duplicate code %s", value)
+			}
+			if code.Label == "" {
+				v.addf(codePath+".label", "is required")
+			}
+			codes = append(codes, value)
+		}
+		branchConditions("code", codes)
+	case CallFlowNodeType.SupportHours:
+		if metadata.SupportHoursId == 0 {
+			v.addf(metadataPath+".supportHoursId", "is required")
+		}
+		for i, branch := range node.Branches {
+			if branch.BranchType == CallFlowBranchType.Branch && !containsString(SupportStatusAll, branch.Condition) {
+				v.addf(fmt.Sprintf("%s.branches[%d].condition", path, i), "must be one of %v", SupportStatusAll)
+			}
+		}
+	case CallFlowNodeType.RouteCall, CallFlowNodeType.ParallelRouteCall:
+		if len(metadata.Targets) == 0 {
+			v.addf(metadataPath+".targets", "at least one target is required")
+		}
+		for i, target := range metadata.Targets {
+			validateCallFlowTarget(v, fmt.Sprintf("%s.targets[%d]", metadataPath, i), target)
+		}
+		if node.NodeType == CallFlowNodeType.RouteCall && !containsString(CallFlowNodeMetadataCallStyleAll, metadata.CallStyle) {
+			v.addf(metadataPath+".callStyle", "must be one of %v", CallFlowNodeMetadataCallStyleAll)
+		}
+		validateCallFlowRetries(v, metadataPath, metadata)
+		if metadata.CallTimeoutSec < 0 {
+			v.addf(metadataPath+".callTimeoutSec", "must not be negative")
+		}
+		validateCallFlowContinuation(v, path, node)
+	case CallFlowNodeType.CreateAlert:
+		if metadata.AlertSourceId == 0 {
+			v.addf(metadataPath+".alertSourceId", "is required")
+		}
+	case CallFlowNodeType.BlockNumbers:
+		if len(metadata.Blacklist) == 0 {
+			v.addf(metadataPath+".blacklist", "at least one number is required")
+		}
+		for i, number := range metadata.Blacklist {
+			if number == "" {
+				v.addf(fmt.Sprintf("%s.blacklist[%d]", metadataPath, i), "is required")
+			}
+		}
+	case CallFlowNodeType.Agentic:
+		validateCallFlowAgentic(v, metadataPath, metadata)
+		intents := make([]string, 0, len(metadata.Intents))
+		for _, intent := range metadata.Intents {
+			intents = append(intents, callFlowIntentCondition(intent))
+		}
+		branchConditions("intent", intents)
+	}
+
+	for i, branch := range node.Branches {
+		targetPath := fmt.Sprintf("%s.branches[%d].target", path, i)
+		if branch.Target == nil {
+			v.addf(targetPath, "is required")
+			continue
+		}
+		validateCallFlowNode(v, targetPath, branch.Target)
+	}
+}
+
+// validateCallFlowMessage checks the message, language and voice model of a node playing a message
+func validateCallFlowMessage(v *flowValidator, path string, metadata *CallFlowNodeMetadata) {
+	if metadata.TextMessage == "" && metadata.CustomAudioUrl == "" {
+		v.addf(path, "textMessage or customAudioUrl is required")
+	}
+	if metadata.Language != "" && !containsString(CallFlowNodeMetadataLanguageAll, metadata.Language) {
+		v.addf(path+".language", "must be one of %v", CallFlowNodeMetadataLanguageAll)
+	}
+	if metadata.AIVoiceModel != "" && !containsString(CallFlowNodeMetadataAIVoiceModelAll, metadata.AIVoiceModel) {
+		v.addf(path+".aiVoiceModel", "must be one of %v", CallFlowNodeMetadataAIVoiceModelAll)
+	}
+}
+
+func validateCallFlowRetries(v *flowValidator, path string, metadata *CallFlowNodeMetadata) {
+	if metadata.Retries < 0 {
+		v.addf(path+".retries", "must not be negative")
+	}
+}
+
+func validateCallFlowTarget(v *flowValidator, path string, target CallFlowNodeMetadataCallTarget) {
+	switch target.Type {
+	case CallFlowNodeMetadataCallTargetType.User, CallFlowNodeMetadataCallTargetType.OnCallSchedule:
+		if id, err := strconv.ParseInt(target.Target, 10, 64); err != nil || id <= 0 {
+			v.addf(path+".target", "must be an id for %s", target.Type)
+		}
+	case CallFlowNodeMetadataCallTargetType.Number:
+		if target.Target == "" {
+			v.addf(path+".target", "is required")
+		}
+	default:
+		v.addf(path+".type", "must be one of %v", CallFlowNodeMetadataCallTargetTypeAll)
+	}
+}
+
+func validateCallFlowAgentic(v *flowValidator, path string, metadata *CallFlowNodeMetadata) {
+	if len(metadata.Intents) == 0 {
+		v.addf(path+".intents", "at least one intent is required")
+	}
+	for i, intent := range metadata.Intents {
+		if !containsString(CallFlowNodeMetadataIntentTypeAll, intent.Type) {
+			v.addf(fmt.Sprintf("%s.intents[%d].type", path, i), "must be one of %v", CallFlowNodeMetadataIntentTypeAll)
+		}
+	}
+	for i, gather := range metadata.Gathers {
+		gatherPath := fmt.Sprintf("%s.gathers[%d]", path, i)
+		if !containsString(CallFlowNodeMetadataGatherTypeAll, gather.Type) {
+			v.addf(gatherPath+".type", "must be one of %v", CallFlowNodeMetadataGatherTypeAll)
+		}
+		if gather.VarType != "" && !containsString(CallFlowNodeMetadataGatherVarTypeAll, gather.VarType) {
+			v.addf(gatherPath+".varType", "must be one of %v", CallFlowNodeMetadataGatherVarTypeAll)
+		}
+	}
+	if enrichment := metadata.Enrichment; enrichment != nil && enrichment.Enabled {
+		if len(enrichment.InformationTypes) == 0 {
+			v.addf(path+".enrichment.informationTypes", "at least one information type is required")
+		}
+		for i, informationType := range enrichment.InformationTypes {
+			if !containsString(CallFlowNodeMetadataEnrichmentInformationTypeAll, informationType) {
+				v.addf(fmt.Sprintf("%s.enrichment.informationTypes[%d]", path, i), "must be one of %v", CallFlowNodeMetadataEnrichmentInformationTypeAll)
+			}
+		}
+		for i, source := range enrichment.Sources {
+			if !containsString(CallFlowNodeMetadataEnrichmentSourceTypeAll, source.Type) {
+				v.addf(fmt.Sprintf("%s.enrichment.sources[%d].type", path, i), "must be one of %v", CallFlowNodeMetadataEnrichmentSourceTypeAll)
+			}
+		}
+	}
+}
+
+// validateCallFlowContinuation checks that a node continues with a CATCH_ALL branch only
+func validateCallFlowContinuation(v *flowValidator, path string, node *CallFlowNode) {
+	for i, branch := range node.Branches {
+		if branch.BranchType == CallFlowBranchType.Branch {
+			v.addf(fmt.Sprintf("%s.branches[%d].branchType", path, i), "%s continues with a %s branch only", node.NodeType, CallFlowBranchType.CatchAll)
+		}
+	}
+}
+
+func callFlowIntentCondition(intent CallFlowNodeMetadataIntent) string {
+	if intent.Label != "" {
+		return intent.Label
+	}
+	return intent.Type
+}
+
+// callFlowNodeMetadata returns the metadata of a node as CallFlowNodeMetadata
+func callFlowNodeMetadata(metadata interface{}) (*CallFlowNodeMetadata, error) {
+	switch m := metadata.(type) {
+	case nil:
+		return &CallFlowNodeMetadata{}, nil
+	case *CallFlowNodeMetadata:
+		if m == nil {
+			return &CallFlowNodeMetadata{}, nil
+		}
+		return m, nil
+	case CallFlowNodeMetadata:
+		return &m, nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	result := &CallFlowNodeMetadata{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+	return result, nil
+}
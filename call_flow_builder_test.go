@@ -0,0 +1,255 @@
+package ilert
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCallFlowBuilderMissingTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		node *CallFlowNodeBuilder
+		path string
+	}{
+		{"branch", CallFlowPlain("Plain", "", "").Branch("call.from == '+49'", nil), "root.branches[0].target.branches[0].target"},
+		{"catch all", CallFlowPlain("Plain", "", "").CatchAll(nil), "root.branches[0].target.branches[0].target"},
+		{"option", CallFlowIVRMenu("Menu", "Press 1").Option("1", nil), "root.branches[0].target.branches[0].target"},
+	}
+	for _, test := range tests {
+		flow, err := NewCallFlowBuilder("Hotline", CallFlowLanguage.English).Start(test.node).Build()
+		var errs FlowValidationErrors
+		if flow != nil || !errors.As(err, &errs) {
+			t.Errorf("%s: expected validation errors, got %v", test.name, err)
+			continue
+		}
+		found := false
+		for _, e := range errs {
+			found = found || (e.Path == test.path && e.Message == "is required")
+		}
+		if !found {
+			t.Errorf("%s: expected %s to be required, got %v", test.name, test.path, err)
+		}
+	}
+}
+
+func TestCallFlowBuilderBuildsValidFlow(t *testing.T) {
+	voicemail := func() *CallFlowNodeBuilder { return CallFlowVoicemail("Voicemail", "Please leave a message") }
+	incident := CallFlowNodeMetadataIntent{Type: CallFlowNodeMetadataIntentType.Incident}
+	outage := CallFlowNodeMetadataIntent{Type: CallFlowNodeMetadataIntentType.SystemOutage, Label: "outage"}
+
+	flow, err := NewCallFlowBuilder("Hotline", CallFlowLanguage.English).
+		Start(CallFlowIVRMenu("Menu", "Press 1 for outages, 2 for support").
+			Voice(CallFlowNodeMetadataAIVoiceModel.Emma, CallFlowNodeMetadataLanguage.English).
+			Option("1", CallFlowPinCode("Pin", "Enter your pin").
+				Code(1234, "Ops", CallFlowRouteCall("On call", CallFlowNodeMetadataCallStyle.Ordered, CallTargetSchedule(7), CallTargetNumber("+4922112345678")).
+					Then(voicemail())).
+				CatchAll(voicemail())).
+			Option("2", CallFlowAgentic("Agent").
+				Intent(incident, CallFlowCreateAlert("Alert", 42)).
+				Intent(outage, voicemail()))).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	menu := flow.RootNode.Branches[0].Target
+	if flow.RootNode.NodeType != CallFlowNodeType.Root || menu.NodeType != CallFlowNodeType.IVRMenu {
+		t.Fatalf("expected the menu to be answered by the root node, got %+v", flow.RootNode)
+	}
+	if conditions := []string{menu.Branches[0].Condition, menu.Branches[1].Condition}; conditions[0] != "1" || conditions[1] != "2" {
+		t.Errorf("expected branches for the options, got %v", conditions)
+	}
+	agentic := menu.Branches[1].Target
+	if conditions := []string{agentic.Branches[0].Condition, agentic.Branches[1].Condition}; conditions[0] != incident.Type || conditions[1] != outage.Label {
+		t.Errorf("expected branches for the intent type and label, got %v", conditions)
+	}
+}
+
+func TestCallFlowValidate(t *testing.T) {
+	voicemail := func() *CallFlowNodeBuilder { return CallFlowVoicemail("Voicemail", "Please leave a message") }
+	target := "root.branches[0].target"
+	tests := []struct {
+		name    string
+		node    func() *CallFlowNodeBuilder
+		path    string
+		message string
+	}{
+		{
+			name: "option without branch",
+			node: func() *CallFlowNodeBuilder {
+				menu := CallFlowIVRMenu("Menu", "Press 1").Option("1", voicemail())
+				menu.metadata.EnabledOptions = append(menu.metadata.EnabledOptions, "2")
+				return menu
+			},
+			path:    target + ".branches",
+			message: `no branch for enabled option "2"`,
+		},
+		{
+			name: "branch without option",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowIVRMenu("Menu", "Press 1").Option("1", voicemail()).Branch("3", voicemail())
+			},
+			path:    target + ".branches[1].condition",
+			message: `no enabled option "3"`,
+		},
+		{
+			name: "duplicate option",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowIVRMenu("Menu", "Press 1").Option("1", voicemail()).Option("1", voicemail())
+			},
+			path:    target + ".metadata.enabledOptions[1]",
+			message: `duplicate option "1"`,
+		},
+		{
+			name:    "menu without options",
+			node:    func() *CallFlowNodeBuilder { return CallFlowIVRMenu("Menu", "Press 1") },
+			path:    target + ".metadata.enabledOptions",
+			message: "at least one option is required",
+		},
+		{
+			name: "code without branch",
+			node: func() *CallFlowNodeBuilder {
+				pin := CallFlowPinCode("Pin", "Enter your pin").Code(1234, "Ops", voicemail())
+				pin.metadata.Codes = append(pin.metadata.Codes, CallFlowNodeMetadataCode{Code: 5678, Label: "Support"})
+				return pin
+			},
+			path:    target + ".branches",
+			message: `no branch for code "5678"`,
+		},
+		{
+			name: "branch without code",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowPinCode("Pin", "Enter your pin").Code(1234, "Ops", voicemail()).Branch("999", voicemail())
+			},
+			path:    target + ".branches[1].condition",
+			message: `no code "999"`,
+		},
+		{
+			name: "duplicate code",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowPinCode("Pin", "Enter your pin").Code(1234, "Ops", voicemail()).Code(1234, "Support", voicemail())
+			},
+			path:    target + ".metadata.codes[1].code",
+			message: "duplicate code 1234",
+		},
+		{
+			name: "code without label",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowPinCode("Pin", "Enter your pin").Code(1234, "", voicemail())
+			},
+			path:    target + ".metadata.codes[0].label",
+			message: "is required",
+		},
+		{
+			name: "answered below root",
+			node: func() *CallFlowNodeBuilder {
+				plain := CallFlowPlain("Plain", "", "")
+				plain.node.Branches = append(plain.node.Branches, CallFlowBranch{BranchType: CallFlowBranchType.Answered, Target: voicemail().Node()})
+				return plain
+			},
+			path:    target + ".branches[0].branchType",
+			message: "ANSWERED is only allowed on the root node",
+		},
+		{
+			name:    "agentic without intents",
+			node:    func() *CallFlowNodeBuilder { return CallFlowAgentic("Agent") },
+			path:    target + ".metadata.intents",
+			message: "at least one intent is required",
+		},
+		{
+			name: "intent without branch",
+			node: func() *CallFlowNodeBuilder {
+				agent := CallFlowAgentic("Agent").Intent(CallFlowNodeMetadataIntent{Type: CallFlowNodeMetadataIntentType.Incident}, voicemail())
+				agent.metadata.Intents = append(agent.metadata.Intents, CallFlowNodeMetadataIntent{Type: CallFlowNodeMetadataIntentType.Inquiry, Label: "question"})
+				return agent
+			},
+			path:    target + ".branches",
+			message: `no branch for intent "question"`,
+		},
+		{
+			name: "branch without intent",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowAgentic("Agent").Intent(CallFlowNodeMetadataIntent{Type: CallFlowNodeMetadataIntentType.Incident}, voicemail()).Branch("outage", voicemail())
+			},
+			path:    target + ".branches[1].condition",
+			message: `no intent "outage"`,
+		},
+		{
+			name: "invalid intent type",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowAgentic("Agent").Intent(CallFlowNodeMetadataIntent{Type: "SMALL_TALK", Label: "chat"}, voicemail())
+			},
+			path:    target + ".metadata.intents[0].type",
+			message: fmt.Sprintf("must be one of %v", CallFlowNodeMetadataIntentTypeAll),
+		},
+		{
+			name: "negative retries",
+			node: func() *CallFlowNodeBuilder {
+				return CallFlowIVRMenu("Menu", "Press 1").Option("1", voicemail()).Retries(-1)
+			},
+			path:    target + ".metadata.retries",
+			message: "must not be negative",
+		},
+		{
+			name:    "target without number",
+			node:    func() *CallFlowNodeBuilder { return CallFlowParallelRouteCall("On call", CallTargetNumber("")) },
+			path:    target + ".metadata.targets[0].target",
+			message: "is required",
+		},
+		{
+			name: "cycle",
+			node: func() *CallFlowNodeBuilder {
+				menu := CallFlowIVRMenu("Menu", "Press 1 to repeat")
+				return menu.Option("1", CallFlowAudioMessage("Repeat", "Once more").Then(menu))
+			},
+			path:    target + ".branches[0].target.branches[0].target",
+			message: "cycles back to a node it is reached from",
+		},
+	}
+	for _, test := range tests {
+		_, err := NewCallFlowBuilder("Hotline", CallFlowLanguage.English).Start(test.node()).Build()
+		var errs FlowValidationErrors
+		if !errors.As(err, &errs) {
+			t.Errorf("%s: expected validation errors, got %v", test.name, err)
+			continue
+		}
+		found := false
+		for _, e := range errs {
+			found = found || (e.Path == test.path && e.Message == test.message)
+		}
+		if !found {
+			t.Errorf("%s: expected %s %s, got %v", test.name, test.path, test.message, err)
+		}
+	}
+}
+
+func TestCallFlowValidateRoot(t *testing.T) {
+	voicemail := CallFlowVoicemail("Voicemail", "Please leave a message").Node()
+	tests := []struct {
+		name     string
+		branches []CallFlowBranch
+		path     string
+		message  string
+	}{
+		{"no branch", []CallFlowBranch{}, "root.branches", "must contain exactly one ANSWERED branch"},
+		{"two branches", []CallFlowBranch{{BranchType: CallFlowBranchType.Answered, Target: voicemail}, {BranchType: CallFlowBranchType.Answered, Target: voicemail}}, "root.branches", "must contain exactly one ANSWERED branch"},
+		{"catch all", []CallFlowBranch{{BranchType: CallFlowBranchType.CatchAll, Target: voicemail}}, "root.branches[0].branchType", "must be ANSWERED"},
+	}
+	for _, test := range tests {
+		flow := &CallFlow{Name: "Hotline", Language: CallFlowLanguage.English, RootNode: &CallFlowNode{NodeType: CallFlowNodeType.Root, Branches: test.branches}}
+		err := flow.Validate()
+		var errs FlowValidationErrors
+		if !errors.As(err, &errs) {
+			t.Errorf("%s: expected validation errors, got %v", test.name, err)
+			continue
+		}
+		found := false
+		for _, e := range errs {
+			found = found || (e.Path == test.path && e.Message == test.message)
+		}
+		if !found {
+			t.Errorf("%s: expected %s %s, got %v", test.name, test.path, test.message, err)
+		}
+	}
+}
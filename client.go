@@ -1,10 +1,15 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -19,14 +24,76 @@ const (
 type Client struct {
 	apiEndpoint string
 	httpClient  *resty.Client
+	rateLimiter *rateLimiter
+	cache       *responseCache
+}
+
+// Sentinel errors that can be matched against API errors using errors.Is
+var (
+	ErrBadRequest          = errors.New("ilert: bad request")
+	ErrUnauthorized        = errors.New("ilert: unauthorized")
+	ErrForbidden           = errors.New("ilert: forbidden")
+	ErrNotFound            = errors.New("ilert: not found")
+	ErrConflict            = errors.New("ilert: conflict")
+	ErrUnprocessableEntity = errors.New("ilert: unprocessable entity")
+	ErrRateLimited         = errors.New("ilert: rate limited")
+)
+
+// statusSentinel returns the sentinel error matching an API response status code
+func statusSentinel(status int) error {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnprocessableEntity:
+		return ErrUnprocessableEntity
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// sentinelMatcher matches an API error with errors.Is against the sentinel error of its status code
+type sentinelMatcher struct {
+	sentinel error
+}
+
+// Is reports whether the target is the sentinel error of the status code
+func (m sentinelMatcher) Is(target error) bool {
+	return target != nil && target == m.sentinel
+}
+
+// APIErrorMetadata describes the request that caused an API response error
+type APIErrorMetadata struct {
+	// http method of the failed request
+	Method string
+
+	// url path of the failed request
+	Path string
+
+	// request id returned by the API, useful when contacting support
+	RequestID string
+
+	// wait duration requested by the API via the Retry-After header, zero if not set
+	RetryAfter time.Duration
 }
 
 // GenericAPIError describes generic API response error e.g. bad request
 type GenericAPIError struct {
 	error
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
 }
 
 func (aerr *GenericAPIError) Error() string {
@@ -36,9 +103,12 @@ func (aerr *GenericAPIError) Error() string {
 // RetryableAPIError describes retryable API response error e.g. too many requests
 type RetryableAPIError struct {
 	error
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
 }
 
 func (aerr *RetryableAPIError) Error() string {
@@ -48,36 +118,136 @@ func (aerr *RetryableAPIError) Error() string {
 // NotFoundAPIError describes not-found API response error e.g. resource deleted or never exists
 type NotFoundAPIError struct {
 	error
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
 }
 
 func (aerr *NotFoundAPIError) Error() string {
 	return fmt.Sprintf("Not found: api respond with status code: %d, error code: %s, message: %s", aerr.Status, aerr.Code, aerr.Message)
 }
 
-// BadRequestAPIError describes not-found API response error e.g. resource deleted or never exists
+// BadRequestAPIError describes bad request API response error e.g. invalid input
 type BadRequestAPIError struct {
 	error
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
 }
 
 func (aerr *BadRequestAPIError) Error() string {
 	return fmt.Sprintf("Bad request: api respond with status code: %d, error code: %s, message: %s", aerr.Status, aerr.Code, aerr.Message)
 }
 
+// UnauthorizedAPIError describes unauthorized API response error e.g. missing or invalid api token
+type UnauthorizedAPIError struct {
+	error
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
+}
+
+func (aerr *UnauthorizedAPIError) Error() string {
+	return fmt.Sprintf("Unauthorized: api respond with status code: %d, error code: %s, message: %s", aerr.Status, aerr.Code, aerr.Message)
+}
+
+// ForbiddenAPIError describes forbidden API response error e.g. missing permissions for the resource
+type ForbiddenAPIError struct {
+	error
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
+}
+
+func (aerr *ForbiddenAPIError) Error() string {
+	return fmt.Sprintf("Forbidden: api respond with status code: %d, error code: %s, message: %s", aerr.Status, aerr.Code, aerr.Message)
+}
+
+// ConflictAPIError describes conflict API response error e.g. resource with the same name already exists
+type ConflictAPIError struct {
+	error
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
+}
+
+func (aerr *ConflictAPIError) Error() string {
+	return fmt.Sprintf("Conflict: api respond with status code: %d, error code: %s, message: %s", aerr.Status, aerr.Code, aerr.Message)
+}
+
+// UnprocessableEntityAPIError describes unprocessable entity API response error e.g. semantically invalid resource
+type UnprocessableEntityAPIError struct {
+	error
+	APIErrorMetadata `json:"-"`
+	Status           int    `json:"status"`
+	Message          string `json:"message"`
+	Code             string `json:"code"`
+
+	sentinelMatcher
+}
+
+func (aerr *UnprocessableEntityAPIError) Error() string {
+	return fmt.Sprintf("Unprocessable entity: api respond with status code: %d, error code: %s, message: %s", aerr.Status, aerr.Code, aerr.Message)
+}
+
 // GenericCountResponse describes generic resources count response
 type GenericCountResponse struct {
 	Count int `json:"count"`
 }
 
+// noRetryContextKey marks request contexts whose requests must not be retried by the client
+type noRetryContextKey struct{}
+
+// withoutRetries disables the retries of the client for requests made with the returned context,
+// used by callers retrying on their own
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryContextKey{}, true)
+}
+
+// isRetryableStatus reports whether a request answered with the given status may succeed on a later attempt
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryCondition decides whether resty retries a request, requests made with withoutRetries are never retried
 func retryCondition(r *resty.Response, err error) bool {
-	return err != nil ||
-		r.StatusCode() == http.StatusTooManyRequests ||
-		r.StatusCode() >= http.StatusInternalServerError
+	if r != nil && r.Request != nil && r.Request.Context().Value(noRetryContextKey{}) != nil {
+		return false
+	}
+	return err != nil || isRetryableStatus(r.StatusCode())
+}
+
+// isRetryableError reports whether a failed request may succeed on a later attempt,
+// e.g. on network errors, rate limits or server errors
+func isRetryableError(err error) bool {
+	var retryable *RetryableAPIError
+	if errors.As(err, &retryable) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var apiErr *GenericAPIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.Status)
+	}
+	return false
 }
 
 // NewClient creates an API client using an API token
@@ -117,6 +287,9 @@ func NewClient(options ...ClientOptions) *Client {
 	for _, opt := range options {
 		opt(&c)
 	}
+	if c.cache != nil {
+		c.installCache()
+	}
 
 	return &c
 }
@@ -179,45 +352,77 @@ func WithRetry(retryCount int, retryWaitTime time.Duration, retryMaxWaitTime tim
 
 // getGenericAPIError extract API response error
 func getGenericAPIError(response *resty.Response, expectedStatusCode ...int) error {
-	if !intSliceContains(expectedStatusCode, response.StatusCode()) {
-		out := &GenericAPIError{}
-		err := json.Unmarshal(response.Body(), out)
-		if err != nil {
-			return &GenericAPIError{
-				Status:  response.StatusCode(),
-				Code:    "ERROR",
-				Message: "An error occurred",
-			}
-		}
-		if out.Status == 0 {
-			out.Status = response.StatusCode()
-		}
-		if out.Status == http.StatusNotFound {
-			return &NotFoundAPIError{
-				Status:  out.Status,
-				Code:    out.Code,
-				Message: out.Message,
-			}
-		}
+	if intSliceContains(expectedStatusCode, response.StatusCode()) {
+		return nil
+	}
 
-		if out.Status == http.StatusBadRequest {
-			return &BadRequestAPIError{
-				Status:  out.Status,
-				Code:    out.Code,
-				Message: out.Message,
-			}
+	out := &GenericAPIError{}
+	err := json.Unmarshal(response.Body(), out)
+	if err != nil {
+		out = &GenericAPIError{
+			Code:    "ERROR",
+			Message: "An error occurred",
 		}
-		if retryCondition(response, out) {
-			return &RetryableAPIError{
-				Status:  out.Status,
-				Code:    out.Code,
-				Message: out.Message,
-			}
+	}
+	if out.Status == 0 || err != nil {
+		out.Status = response.StatusCode()
+	}
+	meta := getAPIErrorMetadata(response)
+	matcher := sentinelMatcher{sentinel: statusSentinel(out.Status)}
+
+	switch out.Status {
+	case http.StatusNotFound:
+		return &NotFoundAPIError{APIErrorMetadata: meta, sentinelMatcher: matcher, Status: out.Status, Code: out.Code, Message: out.Message}
+	case http.StatusBadRequest:
+		return &BadRequestAPIError{APIErrorMetadata: meta, sentinelMatcher: matcher, Status: out.Status, Code: out.Code, Message: out.Message}
+	case http.StatusUnauthorized:
+		return &UnauthorizedAPIError{APIErrorMetadata: meta, sentinelMatcher: matcher, Status: out.Status, Code: out.Code, Message: out.Message}
+	case http.StatusForbidden:
+		return &ForbiddenAPIError{APIErrorMetadata: meta, sentinelMatcher: matcher, Status: out.Status, Code: out.Code, Message: out.Message}
+	case http.StatusConflict:
+		return &ConflictAPIError{APIErrorMetadata: meta, sentinelMatcher: matcher, Status: out.Status, Code: out.Code, Message: out.Message}
+	case http.StatusUnprocessableEntity:
+		return &UnprocessableEntityAPIError{APIErrorMetadata: meta, sentinelMatcher: matcher, Status: out.Status, Code: out.Code, Message: out.Message}
+	}
+	if isRetryableStatus(response.StatusCode()) {
+		return &RetryableAPIError{APIErrorMetadata: meta, sentinelMatcher: matcher, Status: out.Status, Code: out.Code, Message: out.Message}
+	}
+	out.APIErrorMetadata = meta
+	out.sentinelMatcher = matcher
+	return out
+}
+
+// getAPIErrorMetadata extracts the request details of an API response error
+func getAPIErrorMetadata(response *resty.Response) APIErrorMetadata {
+	meta := APIErrorMetadata{
+		RequestID:  response.Header().Get("X-Request-Id"),
+		RetryAfter: parseRetryAfter(response.Header().Get("Retry-After")),
+	}
+	if response.Request != nil {
+		meta.Method = response.Request.Method
+		if response.Request.RawRequest != nil && response.Request.RawRequest.URL != nil {
+			meta.Path = response.Request.RawRequest.URL.Path
+		} else if u, err := url.Parse(response.Request.URL); err == nil {
+			meta.Path = u.Path
 		}
-		return out
 	}
+	return meta
+}
 
-	return nil
+// parseRetryAfter parses a Retry-After header value given in seconds or as http date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 // apiRoutes defines api routes
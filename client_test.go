@@ -0,0 +1,78 @@
+package ilert_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestAPIErrors(t *testing.T) {
+	sentinels := []error{
+		ilert.ErrBadRequest,
+		ilert.ErrUnauthorized,
+		ilert.ErrForbidden,
+		ilert.ErrNotFound,
+		ilert.ErrConflict,
+		ilert.ErrUnprocessableEntity,
+		ilert.ErrRateLimited,
+	}
+	tests := []struct {
+		status   int
+		sentinel error
+		as       func(err error) bool
+	}{
+		{http.StatusBadRequest, ilert.ErrBadRequest, func(err error) bool { var e *ilert.BadRequestAPIError; return errors.As(err, &e) }},
+		{http.StatusUnauthorized, ilert.ErrUnauthorized, func(err error) bool { var e *ilert.UnauthorizedAPIError; return errors.As(err, &e) }},
+		{http.StatusForbidden, ilert.ErrForbidden, func(err error) bool { var e *ilert.ForbiddenAPIError; return errors.As(err, &e) }},
+		{http.StatusNotFound, ilert.ErrNotFound, func(err error) bool { var e *ilert.NotFoundAPIError; return errors.As(err, &e) }},
+		{http.StatusConflict, ilert.ErrConflict, func(err error) bool { var e *ilert.ConflictAPIError; return errors.As(err, &e) }},
+		{http.StatusUnprocessableEntity, ilert.ErrUnprocessableEntity, func(err error) bool { var e *ilert.UnprocessableEntityAPIError; return errors.As(err, &e) }},
+		{http.StatusTooManyRequests, ilert.ErrRateLimited, func(err error) bool { var e *ilert.RetryableAPIError; return errors.As(err, &e) }},
+		{http.StatusInternalServerError, nil, func(err error) bool { var e *ilert.RetryableAPIError; return errors.As(err, &e) }},
+		{http.StatusServiceUnavailable, nil, func(err error) bool { var e *ilert.RetryableAPIError; return errors.As(err, &e) }},
+		{http.StatusMethodNotAllowed, nil, func(err error) bool { var e *ilert.GenericAPIError; return errors.As(err, &e) }},
+	}
+	for _, test := range tests {
+		srv := ilerttest.NewServer()
+		srv.InjectError(http.MethodGet, "/api/teams", test.status, "ERROR", 1)
+
+		_, err := srv.Client().GetTeams(&ilert.GetTeamsInput{})
+		srv.Close()
+
+		if !test.as(err) {
+			t.Errorf("%d: unexpected error type %T", test.status, err)
+		}
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == test.sentinel) {
+				t.Errorf("%d: expected errors.Is %v to be %v", test.status, sentinel, !got)
+			}
+		}
+		wrapped := errors.Join(errors.New("could not sync teams"), err)
+		if test.sentinel != nil && !errors.Is(wrapped, test.sentinel) {
+			t.Errorf("%d: expected the wrapped error to match %v", test.status, test.sentinel)
+		}
+		var generic *ilert.GenericAPIError
+		if test.status != http.StatusMethodNotAllowed && errors.As(err, &generic) {
+			t.Errorf("%d: expected no generic error", test.status)
+		}
+	}
+}
+
+func TestAPIErrorMetadata(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	srv.InjectError(http.MethodGet, "/api/alerts/", http.StatusNotFound, "NOT_FOUND", 1)
+
+	_, err := srv.Client().GetAlert(&ilert.GetAlertInput{AlertID: ilert.Int64(123)})
+
+	var notFound *ilert.NotFoundAPIError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if notFound.Method != http.MethodGet || notFound.Path != "/api/alerts/123" || notFound.Code != "NOT_FOUND" || notFound.Status != http.StatusNotFound {
+		t.Errorf("unexpected error metadata %+v", notFound)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// alertColumns are the table columns of alerts
+var alertColumns = []string{"ID", "Status", "Priority", "Summary", "ReportTime", "AssignedTo.Username", "AlertSource.Name"}
+
+func runAlerts(ctx context.Context, a *app, args []string) error {
+	name, args, err := a.action(args, alertsUsage, "list", "get", "accept", "resolve", "assign")
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "list":
+		return listAlerts(ctx, a, args)
+	case "assign":
+		return assignAlert(ctx, a, args)
+	}
+
+	flags := a.subcommand("alerts "+name, "<alert id>")
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	id, err := alertID(positional)
+	if err != nil {
+		return err
+	}
+	var alert *ilert.Alert
+	switch name {
+	case "get":
+		result, err := a.client.GetAlertWithContext(ctx, &ilert.GetAlertInput{AlertID: id})
+		if err != nil {
+			return err
+		}
+		alert = result.Alert
+	case "accept":
+		result, err := a.client.AcceptAlertWithContext(ctx, &ilert.AcceptAlertInput{AlertID: id})
+		if err != nil {
+			return err
+		}
+		alert = result.Alert
+	case "resolve":
+		result, err := a.client.ResolveAlertWithContext(ctx, &ilert.ResolveAlertInput{AlertID: id})
+		if err != nil {
+			return err
+		}
+		alert = result.Alert
+	}
+	return a.print(alert, alertColumns...)
+}
+
+func listAlerts(ctx context.Context, a *app, args []string) error {
+	flags := a.subcommand("alerts list", "[flags]")
+	states := &stringsFlag{}
+	flags.Var(states, "state", "only alerts in state NEW, PENDING, ACCEPTED or RESOLVED, can be repeated")
+	sources := &stringsFlag{}
+	flags.Var(sources, "source", "only alerts of the alert source id, can be repeated")
+	assignedTo := &stringsFlag{}
+	flags.Var(assignedTo, "assigned-to", "only alerts assigned to the username, can be repeated")
+	since := flags.Duration("since", 0, "only alerts reported within the duration, e.g. 24h")
+	limit := flags.Int("limit", 100, "max number of alerts, 0 lists all")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	input := &ilert.GetAlertsInput{}
+	for _, state := range *states {
+		input.States = append(input.States, ilert.String(state))
+	}
+	for _, source := range *sources {
+		id, err := strconv.ParseInt(source, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid alert source id %q", source)
+		}
+		input.AlertSources = append(input.AlertSources, ilert.Int64(id))
+	}
+	for _, username := range *assignedTo {
+		input.AssignedToUserNames = append(input.AssignedToUserNames, ilert.String(username))
+	}
+	if *since > 0 {
+		input.From = ilert.String(time.Now().Add(-*since).UTC().Format(time.RFC3339))
+	}
+
+	alerts := make([]*ilert.Alert, 0)
+	err := a.client.GetAlertsPagesWithContext(ctx, input, func(page *ilert.GetAlertsOutput) bool {
+		alerts = append(alerts, page.Alerts...)
+		return *limit <= 0 || len(alerts) < *limit
+	})
+	if err != nil {
+		return err
+	}
+	if *limit > 0 && len(alerts) > *limit {
+		alerts = alerts[:*limit]
+	}
+	return a.print(alerts, alertColumns...)
+}
+
+func assignAlert(ctx context.Context, a *app, args []string) error {
+	flags := a.subcommand("alerts assign", "<alert id> -user <username or id> | -escalation-policy <id> | -schedule <id>")
+	user := flags.String("user", "", "username or id of the user")
+	escalationPolicyID := flags.Int64("escalation-policy", 0, "id of the escalation policy")
+	scheduleID := flags.Int64("schedule", 0, "id of the schedule")
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	id, err := alertID(positional)
+	if err != nil {
+		return err
+	}
+
+	input := &ilert.AssignAlertInput{AlertID: id}
+	switch {
+	case *user != "":
+		if userID, err := strconv.ParseInt(*user, 10, 64); err == nil {
+			input.UserID = ilert.Int64(userID)
+		} else {
+			input.Username = user
+		}
+	case *escalationPolicyID != 0:
+		input.EscalationPolicyID = escalationPolicyID
+	case *scheduleID != 0:
+		input.ScheduleID = scheduleID
+	default:
+		return errors.New("user, escalation policy or schedule is required")
+	}
+	result, err := a.client.AssignAlertWithContext(ctx, input)
+	if err != nil {
+		return err
+	}
+	return a.print(result.Alert, alertColumns...)
+}
+
+func alertID(positional []string) (*int64, error) {
+	if len(positional) != 1 {
+		return nil, errors.New("alert id is required")
+	}
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert id %q", positional[0])
+	}
+	return &id, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+func runEvent(ctx context.Context, a *app, args []string) error {
+	name, args, err := a.action(args, eventUsage, "send", "accept", "resolve")
+	if err != nil {
+		return err
+	}
+	flags := a.subcommand("event "+name, "-key <integration key> [flags]")
+	key := flags.String("key", "", "integration key of the alert source (required)")
+	alertKey := flags.String("alert-key", "", "alert key to deduplicate events or reference the alert to accept or resolve")
+	summary := flags.String("summary", "", "event summary (required for send)")
+	details := flags.String("details", "", "event details")
+	priority := flags.String("priority", "", "alert priority: HIGH or LOW")
+	labels := &stringsFlag{}
+	flags.Var(labels, "label", "label as key=value, can be repeated")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *key == "" {
+		return errors.New("integration key is required")
+	}
+
+	event := &ilert.Event{APIKey: *key, AlertKey: *alertKey, Summary: *summary, Details: *details, Priority: *priority}
+	switch name {
+	case "send":
+		event.EventType = ilert.EventTypes.Alert
+		if *summary == "" {
+			return errors.New("summary is required")
+		}
+	case "accept":
+		event.EventType = ilert.EventTypes.Accept
+	case "resolve":
+		event.EventType = ilert.EventTypes.Resolve
+	}
+	if event.EventType != ilert.EventTypes.Alert && *alertKey == "" {
+		return errors.New("alert key is required")
+	}
+	if len(*labels) > 0 {
+		event.Labels = make(map[string]string, len(*labels))
+		for _, label := range *labels {
+			k, v, ok := strings.Cut(label, "=")
+			if !ok || k == "" {
+				return errors.New("labels must be given as key=value")
+			}
+			event.Labels[k] = v
+		}
+	}
+
+	result, err := a.client.CreateEventWithContext(ctx, &ilert.CreateEventInput{Event: event})
+	if err != nil {
+		return err
+	}
+	return a.print(result.EventResponse, "ResponseCode", "AlertKey", "AlertURL")
+}
+
+func runHeartbeat(ctx context.Context, a *app, args []string) error {
+	_, args, err := a.action(args, heartbeatUsage, "ping")
+	if err != nil {
+		return err
+	}
+	flags := a.subcommand("heartbeat ping", "-key <integration key> [flags]")
+	key := flags.String("key", "", "integration key of the heartbeat monitor (required)")
+	method := flags.String("method", ilert.HeartbeatMethods.HEAD, "http method of the ping: HEAD, GET or POST")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *key == "" {
+		return errors.New("integration key is required")
+	}
+	if _, err := a.client.PingHeartbeatWithContext(ctx, &ilert.PingHeartbeatInput{APIKey: ilert.String(*key), Method: ilert.String(*method)}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Command ilert sends events, pings heartbeats and manages alerts, schedules and other resources from the shell.
+//
+// Authentication uses the same environment variables as ilert.NewClient, e.g. ILERT_API_TOKEN and ILERT_ENDPOINT.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// command is a subcommand of the cli
+type command struct {
+	usage string
+	run   func(ctx context.Context, app *app, args []string) error
+}
+
+// app holds the state shared by all subcommands
+type app struct {
+	client *ilert.Client
+	out    io.Writer
+	err    io.Writer
+	output string
+}
+
+// errUsage is returned by subcommands for invalid arguments, their usage has already been printed
+var errUsage = errors.New("invalid usage")
+
+// usages of the subcommands
+const (
+	eventUsage     = "event send|accept|resolve -key <integration key> [flags]"
+	heartbeatUsage = "heartbeat ping -key <integration key> [flags]"
+	alertsUsage    = "alerts list|get|accept|resolve|assign [flags]"
+	onCallUsage    = "oncall -schedule <id> [flags]"
+	overridesUsage = "overrides list|add -schedule <id> [flags]"
+	getUsage       = "get <resource> <id>"
+	listUsage      = "list <resource>"
+)
+
+var commands = map[string]command{
+	"event":     {usage: eventUsage, run: runEvent},
+	"heartbeat": {usage: heartbeatUsage, run: runHeartbeat},
+	"alerts":    {usage: alertsUsage, run: runAlerts},
+	"oncall":    {usage: onCallUsage, run: runOnCall},
+	"overrides": {usage: overridesUsage, run: runOverrides},
+	"get":       {usage: getUsage, run: runGet},
+	"list":      {usage: listUsage, run: runList},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ilert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "table", "output format: table or json")
+	flags.Usage = func() { usage(stderr, flags) }
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "ilert: unknown output format %q\n", *output)
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "ilert: unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	a := &app{client: ilert.NewClient(ilert.WithUserAgent("ilert-cli")), out: stdout, err: stderr, output: *output}
+	if err := cmd.run(ctx, a, flags.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "ilert: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: ilert [-o table|json] <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Resources: %s\n", strings.Join(resourceNames(), ", "))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	flags.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Environment: ILERT_API_TOKEN, ILERT_ENDPOINT, ILERT_ORGANIZATION, ILERT_USERNAME, ILERT_PASSWORD")
+}
+
+// subcommand returns the flag set of a subcommand, failing parses print its usage
+func (a *app) subcommand(name string, args string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(a.err)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: ilert %s %s\n", name, args)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parse parses the flags of a subcommand, which may be given before or after its positional arguments
+func parse(flags *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseFlags parses the flags of a subcommand without positional arguments
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	positional, err := parse(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("unexpected argument %q", positional[0])
+	}
+	return nil
+}
+
+// action returns the action of a subcommand with actions, e.g. send of event send
+func (a *app) action(args []string, usage string, actions ...string) (string, []string, error) {
+	if len(args) == 0 {
+		fmt.Fprintf(a.err, "Usage: ilert %s\n", usage)
+		return "", nil, errUsage
+	}
+	for _, action := range actions {
+		if action == args[0] {
+			return args[0], args[1:], nil
+		}
+	}
+	fmt.Fprintf(a.err, "ilert: unknown action %q\nUsage: ilert %s\n", args[0], usage)
+	return "", nil, errUsage
+}
+
+// stringsFlag is a flag that can be repeated
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
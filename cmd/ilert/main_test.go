@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+// runCLI runs the cli against the fake server and returns its exit code and output
+func runCLI(t *testing.T, srv *ilerttest.Server, args ...string) (int, string, string) {
+	t.Setenv("ILERT_ENDPOINT", srv.URL)
+	t.Setenv("ILERT_API_TOKEN", "ilerttest")
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(args, stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunUsage(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	tests := []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{[]string{}, 2, "Usage: ilert [-o table|json] <command>"},
+		{[]string{"-o", "yaml", "list", "teams"}, 2, `unknown output format "yaml"`},
+		{[]string{"deploy"}, 2, `unknown command "deploy"`},
+		{[]string{"event"}, 2, "Usage: ilert event send|accept|resolve"},
+		{[]string{"event", "snooze"}, 2, `unknown action "snooze"`},
+		{[]string{"event", "send", "-summary", "db down"}, 1, "integration key is required"},
+		{[]string{"event", "send", "-key", "key"}, 1, "summary is required"},
+		{[]string{"event", "resolve", "-key", "key"}, 1, "alert key is required"},
+		{[]string{"event", "send", "-key", "key", "-summary", "db down", "-label", "env"}, 1, "labels must be given as key=value"},
+		{[]string{"event", "send", "-unknown"}, 2, "Usage: ilert event send"},
+		{[]string{"heartbeat", "ping"}, 1, "integration key is required"},
+		{[]string{"alerts", "get"}, 1, "alert id is required"},
+		{[]string{"alerts", "get", "abc"}, 1, `invalid alert id "abc"`},
+		{[]string{"alerts", "assign", "1"}, 1, "user, escalation policy or schedule is required"},
+		{[]string{"alerts", "list", "extra"}, 1, `unexpected argument "extra"`},
+		{[]string{"oncall"}, 1, "schedule id is required"},
+		{[]string{"oncall", "-schedule", "1", "-at", "tomorrow"}, 1, `invalid time "tomorrow"`},
+		{[]string{"overrides", "add", "-schedule", "1"}, 1, "user, start and end are required"},
+		{[]string{"get", "teams"}, 2, "Usage: ilert get <resource> <id>"},
+		{[]string{"list", "widgets"}, 1, `unknown resource "widgets"`},
+		{[]string{"get", "alert-sources", "abc"}, 1, `invalid id "abc"`},
+	}
+	for _, test := range tests {
+		code, stdout, stderr := runCLI(t, srv, test.args...)
+		if code != test.code || !strings.Contains(stderr, test.stderr) {
+			t.Errorf("%v: expected exit code %d and %q, got %d and %q", test.args, test.code, test.stderr, code, stderr)
+		}
+		if stdout != "" {
+			t.Errorf("%v: expected no output, got %q", test.args, stdout)
+		}
+	}
+	if requests := srv.Requests(); len(requests) != 0 {
+		t.Errorf("expected invalid usages not to send requests, got %+v", requests)
+	}
+}
+
+func TestRunEvent(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "-o", "json", "event", "send", "-key", "key", "-alert-key", "db", "-summary", "db down", "-priority", "LOW", "-label", "env=prod", "-label", "team=ops")
+	if code != 0 {
+		t.Fatalf("expected the event to be sent, got %d: %s", code, stderr)
+	}
+	response := &ilert.EventResponse{}
+	if err := json.Unmarshal([]byte(stdout), response); err != nil {
+		t.Fatalf("expected json output, got %q", stdout)
+	}
+	if response.ResponseCode != ilerttest.EventResponseCodes.NewAlertCreated || response.AlertKey != "db" {
+		t.Errorf("unexpected event response %+v", response)
+	}
+	events := srv.Events()
+	if len(events) != 1 || events[0].EventType != ilert.EventTypes.Alert || events[0].Priority != "LOW" ||
+		events[0].Labels["env"] != "prod" || events[0].Labels["team"] != "ops" {
+		t.Errorf("unexpected events %+v", events)
+	}
+
+	code, stdout, _ = runCLI(t, srv, "event", "resolve", "-key", "key", "-alert-key", "db")
+	if code != 0 || !strings.HasPrefix(stdout, "RESPONSECODE") || !strings.Contains(stdout, ilerttest.EventResponseCodes.AlertResolved) {
+		t.Errorf("expected a table of the resolved alert, got %d and %q", code, stdout)
+	}
+	if alerts := srv.Alerts(); len(alerts) != 1 || alerts[0].Status != ilert.AlertStatuses.Resolved {
+		t.Errorf("expected the alert to be resolved, got %+v", alerts)
+	}
+
+	code, _, stderr = runCLI(t, srv, "event", "accept", "-key", "key", "-alert-key", "db")
+	if code != 1 || !strings.Contains(stderr, "ilert: ") {
+		t.Errorf("expected accepting a resolved alert to fail, got %d and %q", code, stderr)
+	}
+}
+
+func TestRunHeartbeat(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "heartbeat", "ping", "-key", "hb", "-method", http.MethodPost)
+	if code != 0 || stdout != "" {
+		t.Fatalf("expected a silent ping, got %d, %q and %q", code, stdout, stderr)
+	}
+	requests := srv.Requests()
+	if srv.HeartbeatPings("hb") != 1 || len(requests) != 1 || requests[0].Method != http.MethodPost {
+		t.Errorf("expected one POST ping, got %+v", requests)
+	}
+}
+
+func TestRunAlerts(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	user, err := srv.Client().CreateUser(&ilert.CreateUserInput{User: &ilert.User{Username: "jane", Email: "jane@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := srv.SeedAlert(ilert.Alert{Summary: "db down", Priority: ilert.AlertPriorities.High})
+	srv.SeedAlert(ilert.Alert{Summary: "disk full", Priority: ilert.AlertPriorities.Low})
+	resolved := srv.SeedAlert(ilert.Alert{Summary: "cpu high", Status: ilert.AlertStatuses.Resolved})
+
+	code, stdout, _ := runCLI(t, srv, "alerts", "list", "-state", ilert.AlertStatuses.Pending)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 3 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "db down") || strings.Contains(stdout, "cpu high") {
+		t.Errorf("expected a table of the pending alerts, got %d and %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, srv, "-o", "json", "alerts", "list", "-limit", "1")
+	alerts := make([]*ilert.Alert, 0)
+	if err := json.Unmarshal([]byte(stdout), &alerts); err != nil || code != 0 || len(alerts) != 1 || alerts[0].ID != pending {
+		t.Errorf("expected the first alert, got %d and %q", code, stdout)
+	}
+
+	// flags may follow the alert id
+	code, stdout, stderr := runCLI(t, srv, "alerts", "assign", fmt.Sprint(pending), "-user", "jane")
+	if code != 0 || !strings.Contains(stdout, "jane") {
+		t.Errorf("expected the alert to be assigned, got %d, %q and %q", code, stdout, stderr)
+	}
+	code, _, _ = runCLI(t, srv, "alerts", "accept", fmt.Sprint(pending))
+	if alert := srv.Alerts()[0]; code != 0 || alert.Status != ilert.AlertStatuses.Accepted || alert.AssignedTo == nil || alert.AssignedTo.ID != user.User.ID {
+		t.Errorf("expected the alert to be accepted by jane, got %d and %+v", code, alert)
+	}
+
+	code, _, stderr = runCLI(t, srv, "alerts", "accept", fmt.Sprint(resolved))
+	if code != 1 || !strings.Contains(stderr, "already resolved") {
+		t.Errorf("expected accepting a resolved alert to fail, got %d and %q", code, stderr)
+	}
+	code, _, stderr = runCLI(t, srv, "alerts", "get", "999")
+	if code != 1 || !strings.Contains(stderr, "ilert: ") {
+		t.Errorf("expected a missing alert to fail, got %d and %q", code, stderr)
+	}
+}
+
+func TestRunSchedules(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	jane, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Username: "jane", Email: "jane@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	john, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Username: "john", Email: "john@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := client.CreateSchedule(&ilert.CreateScheduleInput{Schedule: &ilert.Schedule{
+		Name:     "Primary",
+		Timezone: "UTC",
+		Type:     ilert.ScheduleType.Recurring,
+		ScheduleLayers: []ilert.ScheduleLayer{{
+			Name:     "Weekly",
+			StartsOn: "2024-01-01T00:00:00Z",
+			Users:    []ilert.User{*jane.User},
+			Rotation: "P7D",
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := fmt.Sprint(schedule.Schedule.ID)
+
+	code, stdout, stderr := runCLI(t, srv, "oncall", "-schedule", id)
+	if code != 0 || !strings.Contains(stdout, "jane") {
+		t.Errorf("expected jane to be on call now, got %d, %q and %q", code, stdout, stderr)
+	}
+
+	code, _, stderr = runCLI(t, srv, "overrides", "add", "-schedule", id, "-user", fmt.Sprint(john.User.ID), "-start", "2024-03-05T08:00:00Z", "-end", "2024-03-05T20:00:00Z")
+	if code != 0 {
+		t.Fatalf("expected the override to be added, got %d and %q", code, stderr)
+	}
+	code, stdout, _ = runCLI(t, srv, "-o", "json", "overrides", "list", "-schedule", id)
+	overrides := make([]ilert.Shift, 0)
+	if err := json.Unmarshal([]byte(stdout), &overrides); err != nil || code != 0 || len(overrides) != 1 ||
+		overrides[0].User.ID != john.User.ID || overrides[0].Start != "2024-03-05T08:00:00Z" {
+		t.Errorf("expected john's override, got %d and %q", code, stdout)
+	}
+
+	tests := []struct {
+		at   string
+		user int64
+	}{
+		{"2024-03-05T07:00:00Z", jane.User.ID},
+		{"2024-03-05T12:00:00Z", john.User.ID},
+		{"2024-03-05T21:00:00Z", jane.User.ID},
+	}
+	for _, test := range tests {
+		code, stdout, _ = runCLI(t, srv, "-o", "json", "oncall", "-schedule", id, "-at", test.at)
+		shift := &ilert.Shift{}
+		if err := json.Unmarshal([]byte(stdout), shift); err != nil || code != 0 || shift.User.ID != test.user {
+			t.Errorf("%s: expected user %d to be on call, got %d and %q", test.at, test.user, code, stdout)
+		}
+	}
+}
+
+func TestRunResources(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	team, err := srv.Client().CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: "Ops"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Client().CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: "Dev"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, _ := runCLI(t, srv, "list", "teams")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 3 || !strings.Contains(stdout, "Ops") || !strings.Contains(stdout, "Dev") {
+		t.Errorf("expected a table of both teams, got %d and %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, srv, "-o", "json", "get", "teams", fmt.Sprint(team.Team.ID))
+	got := &ilert.Team{}
+	if err := json.Unmarshal([]byte(stdout), got); err != nil || code != 0 || got.ID != team.Team.ID || got.Name != "Ops" {
+		t.Errorf("expected the team as json, got %d and %q", code, stdout)
+	}
+
+	srv.InjectError(http.MethodGet, "/api/teams", http.StatusForbidden, "FORBIDDEN", 1)
+	code, stdout, stderr := runCLI(t, srv, "list", "teams")
+	if code != 1 || stdout != "" || !strings.HasPrefix(stderr, "ilert: ") {
+		t.Errorf("expected api errors to be reported, got %d, %q and %q", code, stdout, stderr)
+	}
+}
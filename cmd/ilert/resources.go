@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// resource is a resource type that can be listed and fetched by id
+type resource struct {
+	columns []string
+	list    func(ctx context.Context, c *ilert.Client) (interface{}, error)
+	get     func(ctx context.Context, c *ilert.Client, id string) (interface{}, error)
+}
+
+var resources = map[string]resource{
+	"alert-actions": {
+		columns: []string{"ID", "Name", "ConnectorType", "TriggerMode"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.AlertActionOutput, 0)
+			err := c.GetAlertActionsPagesWithContext(ctx, &ilert.GetAlertActionsInput{}, func(page *ilert.GetAlertActionsOutput) bool {
+				items = append(items, page.AlertActions...)
+				return true
+			})
+			return items, err
+		},
+		get: func(ctx context.Context, c *ilert.Client, id string) (interface{}, error) {
+			result, err := c.GetAlertActionWithContext(ctx, &ilert.GetAlertActionInput{AlertActionID: ilert.String(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.AlertAction, nil
+		},
+	},
+	"alert-sources": {
+		columns: []string{"ID", "Name", "IntegrationType", "Status"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.AlertSource, 0)
+			err := c.GetAlertSourcesPagesWithContext(ctx, &ilert.GetAlertSourcesInput{}, func(page *ilert.GetAlertSourcesOutput) bool {
+				items = append(items, page.AlertSources...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetAlertSourceWithContext(ctx, &ilert.GetAlertSourceInput{AlertSourceID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.AlertSource, nil
+		}),
+	},
+	"connectors": {
+		columns: []string{"ID", "Name", "Type"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.ConnectorOutput, 0)
+			err := c.GetConnectorsPagesWithContext(ctx, &ilert.GetConnectorsInput{}, func(page *ilert.GetConnectorsOutput) bool {
+				items = append(items, page.Connectors...)
+				return true
+			})
+			return items, err
+		},
+		get: func(ctx context.Context, c *ilert.Client, id string) (interface{}, error) {
+			result, err := c.GetConnectorWithContext(ctx, &ilert.GetConnectorInput{ConnectorID: ilert.String(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.Connector, nil
+		},
+	},
+	"escalation-policies": {
+		columns: []string{"ID", "Name", "Repeating", "Frequency"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.EscalationPolicy, 0)
+			err := c.GetEscalationPoliciesPagesWithContext(ctx, &ilert.GetEscalationPoliciesInput{}, func(page *ilert.GetEscalationPoliciesOutput) bool {
+				items = append(items, page.EscalationPolicies...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetEscalationPolicyWithContext(ctx, &ilert.GetEscalationPolicyInput{EscalationPolicyID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.EscalationPolicy, nil
+		}),
+	},
+	"heartbeat-monitors": {
+		columns: []string{"ID", "Name", "State", "IntervalSec"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.HeartbeatMonitor, 0)
+			err := c.GetHeartbeatMonitorsPagesWithContext(ctx, &ilert.GetHeartbeatMonitorsInput{}, func(page *ilert.GetHeartbeatMonitorsOutput) bool {
+				items = append(items, page.HeartbeatMonitors...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetHeartbeatMonitorWithContext(ctx, &ilert.GetHeartbeatMonitorInput{HeartbeatMonitorID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.HeartbeatMonitor, nil
+		}),
+	},
+	"incidents": {
+		columns: []string{"ID", "Summary", "Status", "CreatedAt", "ResolvedOn"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.Incident, 0)
+			err := c.GetIncidentsPagesWithContext(ctx, &ilert.GetIncidentsInput{}, func(page *ilert.GetIncidentsOutput) bool {
+				items = append(items, page.Incidents...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetIncidentWithContext(ctx, &ilert.GetIncidentInput{IncidentID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.Incident, nil
+		}),
+	},
+	"schedules": {
+		columns: []string{"ID", "Name", "Type", "Timezone"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.Schedule, 0)
+			err := c.GetSchedulesPagesWithContext(ctx, &ilert.GetSchedulesInput{}, func(page *ilert.GetSchedulesOutput) bool {
+				items = append(items, page.Schedules...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetScheduleWithContext(ctx, &ilert.GetScheduleInput{ScheduleID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.Schedule, nil
+		}),
+	},
+	"services": {
+		columns: []string{"ID", "Name", "Status"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.Service, 0)
+			err := c.GetServicesPagesWithContext(ctx, &ilert.GetServicesInput{}, func(page *ilert.GetServicesOutput) bool {
+				items = append(items, page.Services...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetServiceWithContext(ctx, &ilert.GetServiceInput{ServiceID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.Service, nil
+		}),
+	},
+	"status-pages": {
+		columns: []string{"ID", "Name", "Subdomain", "Domain"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.StatusPage, 0)
+			err := c.GetStatusPagesPagesWithContext(ctx, &ilert.GetStatusPagesInput{}, func(page *ilert.GetStatusPagesOutput) bool {
+				items = append(items, page.StatusPages...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetStatusPageWithContext(ctx, &ilert.GetStatusPageInput{StatusPageID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.StatusPage, nil
+		}),
+	},
+	"support-hours": {
+		columns: []string{"ID", "Name", "Timezone"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.SupportHour, 0)
+			err := c.GetSupportHoursPagesWithContext(ctx, &ilert.GetSupportHoursInput{}, func(page *ilert.GetSupportHoursOutput) bool {
+				items = append(items, page.SupportHours...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetSupportHourWithContext(ctx, &ilert.GetSupportHourInput{SupportHourID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.SupportHour, nil
+		}),
+	},
+	"teams": {
+		columns: []string{"ID", "Name", "Visibility"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.Team, 0)
+			err := c.GetTeamsPagesWithContext(ctx, &ilert.GetTeamsInput{}, func(page *ilert.GetTeamsOutput) bool {
+				items = append(items, page.Teams...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetTeamWithContext(ctx, &ilert.GetTeamInput{TeamID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.Team, nil
+		}),
+	},
+	"uptime-monitors": {
+		columns: []string{"ID", "Name", "CheckType", "Status", "IntervalSec"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.UptimeMonitor, 0)
+			err := c.GetUptimeMonitorsPagesWithContext(ctx, &ilert.GetUptimeMonitorsInput{}, func(page *ilert.GetUptimeMonitorsOutput) bool {
+				items = append(items, page.UptimeMonitors...)
+				return true
+			})
+			return items, err
+		},
+		get: withInt64ID(func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error) {
+			result, err := c.GetUptimeMonitorWithContext(ctx, &ilert.GetUptimeMonitorInput{UptimeMonitorID: ilert.Int64(id)})
+			if err != nil {
+				return nil, err
+			}
+			return result.UptimeMonitor, nil
+		}),
+	},
+	"users": {
+		columns: []string{"ID", "Username", "FirstName", "LastName", "Email", "Role"},
+		list: func(ctx context.Context, c *ilert.Client) (interface{}, error) {
+			items := make([]*ilert.User, 0)
+			err := c.GetUsersPagesWithContext(ctx, &ilert.GetUsersInput{}, func(page *ilert.GetUsersOutput) bool {
+				items = append(items, page.Users...)
+				return true
+			})
+			return items, err
+		},
+		get: func(ctx context.Context, c *ilert.Client, id string) (interface{}, error) {
+			input := &ilert.GetUserInput{}
+			if userID, err := strconv.ParseInt(id, 10, 64); err == nil {
+				input.UserID = ilert.Int64(userID)
+			} else {
+				input.Username = ilert.String(id)
+			}
+			result, err := c.GetUserWithContext(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			return result.User, nil
+		},
+	},
+}
+
+// withInt64ID wraps the get function of a resource with numeric ids
+func withInt64ID(get func(ctx context.Context, c *ilert.Client, id int64) (interface{}, error)) func(ctx context.Context, c *ilert.Client, id string) (interface{}, error) {
+	return func(ctx context.Context, c *ilert.Client, id string) (interface{}, error) {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", id)
+		}
+		return get(ctx, c, n)
+	}
+}
+
+func resourceNames() []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runGet(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 {
+		fmt.Fprintf(a.err, "Usage: ilert %s\n", getUsage)
+		return errUsage
+	}
+	r, err := lookupResource(args[0])
+	if err != nil {
+		return err
+	}
+	item, err := r.get(ctx, a.client, args[1])
+	if err != nil {
+		return err
+	}
+	return a.print(item, r.columns...)
+}
+
+func runList(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintf(a.err, "Usage: ilert %s\n", listUsage)
+		return errUsage
+	}
+	r, err := lookupResource(args[0])
+	if err != nil {
+		return err
+	}
+	items, err := r.list(ctx, a.client)
+	if err != nil {
+		return err
+	}
+	return a.print(items, r.columns...)
+}
+
+func lookupResource(name string) (resource, error) {
+	r, ok := resources[name]
+	if !ok {
+		return resource{}, fmt.Errorf("unknown resource %q, one of: %v", name, resourceNames())
+	}
+	return r, nil
+}
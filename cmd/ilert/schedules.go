@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// shiftColumns are the table columns of shifts and overrides
+var shiftColumns = []string{"User.Username", "User.FirstName", "User.LastName", "Start", "End"}
+
+func runOnCall(ctx context.Context, a *app, args []string) error {
+	flags := a.subcommand("oncall", "-schedule <id> [flags]")
+	scheduleID := flags.Int64("schedule", 0, "id of the schedule (required)")
+	at := flags.String("at", "", "who is on call at the time instead of now, in RFC 3339 format")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *scheduleID == 0 {
+		return errors.New("schedule id is required")
+	}
+
+	if *at == "" {
+		result, err := a.client.GetScheduleUserOnCallWithContext(ctx, &ilert.GetScheduleUserOnCallInput{ScheduleID: scheduleID})
+		if err != nil {
+			return err
+		}
+		return a.print(result.Shift, shiftColumns...)
+	}
+
+	t, err := time.Parse(time.RFC3339, *at)
+	if err != nil {
+		return fmt.Errorf("invalid time %q", *at)
+	}
+	result, err := a.client.GetScheduleShiftsWithContext(ctx, &ilert.GetScheduleShiftsInput{
+		ScheduleID: scheduleID,
+		From:       ilert.String(t.Add(-24 * time.Hour).UTC().Format(time.RFC3339)),
+		Until:      ilert.String(t.Add(24 * time.Hour).UTC().Format(time.RFC3339)),
+	})
+	if err != nil {
+		return err
+	}
+	for _, shift := range result.Shifts {
+		start, startErr := time.Parse(time.RFC3339, shift.Start)
+		end, endErr := time.Parse(time.RFC3339, shift.End)
+		if startErr == nil && endErr == nil && !t.Before(start) && t.Before(end) {
+			return a.print(shift, shiftColumns...)
+		}
+	}
+	return fmt.Errorf("nobody is on call at %s", *at)
+}
+
+func runOverrides(ctx context.Context, a *app, args []string) error {
+	name, args, err := a.action(args, overridesUsage, "list", "add")
+	if err != nil {
+		return err
+	}
+	flags := a.subcommand("overrides "+name, "-schedule <id> [flags]")
+	scheduleID := flags.Int64("schedule", 0, "id of the schedule (required)")
+	user := flags.String("user", "", "username or id of the user taking over (required for add)")
+	start := flags.String("start", "", "start of the override in RFC 3339 format (required for add)")
+	end := flags.String("end", "", "end of the override in RFC 3339 format (required for add)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *scheduleID == 0 {
+		return errors.New("schedule id is required")
+	}
+
+	if name == "list" {
+		result, err := a.client.GetScheduleOverridesWithContext(ctx, &ilert.GetScheduleOverridesInput{ScheduleID: scheduleID})
+		if err != nil {
+			return err
+		}
+		return a.print(result.Overrides, shiftColumns...)
+	}
+
+	if *user == "" || *start == "" || *end == "" {
+		return errors.New("user, start and end are required")
+	}
+	for _, t := range []string{*start, *end} {
+		if _, err := time.Parse(time.RFC3339, t); err != nil {
+			return fmt.Errorf("invalid time %q", t)
+		}
+	}
+	override := &ilert.Shift{Start: *start, End: *end}
+	if userID, err := strconv.ParseInt(*user, 10, 64); err == nil {
+		override.User = ilert.User{ID: userID}
+	} else {
+		result, err := a.client.GetUserWithContext(ctx, &ilert.GetUserInput{Username: user})
+		if err != nil {
+			return err
+		}
+		override.User = *result.User
+	}
+	if _, err := a.client.AddScheduleShiftOverrideWithContext(ctx, &ilert.AddScheduleShiftOverrideInput{ScheduleID: scheduleID, Shift: override}); err != nil {
+		return err
+	}
+	return a.print(override, shiftColumns...)
+}
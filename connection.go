@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -245,13 +246,18 @@ type CreateConnectionOutput struct {
 
 // Legacy API - please use alert-actions - for more information see https://docs.ilert.com/rest-api/api-version-history#renaming-connections-to-alert-actions
 func (c *Client) CreateConnection(input *CreateConnectionInput) (*CreateConnectionOutput, error) {
+	return c.CreateConnectionWithContext(context.Background(), input)
+}
+
+// CreateConnectionWithContext is the same as CreateConnection with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateConnectionWithContext(ctx context.Context, input *CreateConnectionInput) (*CreateConnectionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.Connection == nil {
 		return nil, errors.New("Connection input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Connection).Post(apiRoutes.connections)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Connection).Post(apiRoutes.connections)
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +288,11 @@ type GetConnectionOutput struct {
 
 // Legacy API - please use alert-actions - for more information see https://docs.ilert.com/rest-api/api-version-history#renaming-connections-to-alert-actions
 func (c *Client) GetConnection(input *GetConnectionInput) (*GetConnectionOutput, error) {
+	return c.GetConnectionWithContext(context.Background(), input)
+}
+
+// GetConnectionWithContext is the same as GetConnection with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetConnectionWithContext(ctx context.Context, input *GetConnectionInput) (*GetConnectionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -289,7 +300,7 @@ func (c *Client) GetConnection(input *GetConnectionInput) (*GetConnectionOutput,
 		return nil, errors.New("Connection id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +330,12 @@ type GetConnectionsOutput struct {
 
 // Legacy API - please use alert-actions - for more information see https://docs.ilert.com/rest-api/api-version-history#renaming-connections-to-alert-actions
 func (c *Client) GetConnections(input *GetConnectionsInput) (*GetConnectionsOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.connections)
+	return c.GetConnectionsWithContext(context.Background(), input)
+}
+
+// GetConnectionsWithContext is the same as GetConnections with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetConnectionsWithContext(ctx context.Context, input *GetConnectionsInput) (*GetConnectionsOutput, error) {
+	resp, err := c.httpClient.R().SetContext(ctx).Get(apiRoutes.connections)
 	if err != nil {
 		return nil, err
 	}
@@ -351,6 +367,11 @@ type UpdateConnectionOutput struct {
 
 // Legacy API - please use alert-actions - for more information see https://docs.ilert.com/rest-api/api-version-history#renaming-connections-to-alert-actions
 func (c *Client) UpdateConnection(input *UpdateConnectionInput) (*UpdateConnectionOutput, error) {
+	return c.UpdateConnectionWithContext(context.Background(), input)
+}
+
+// UpdateConnectionWithContext is the same as UpdateConnection with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateConnectionWithContext(ctx context.Context, input *UpdateConnectionInput) (*UpdateConnectionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -361,7 +382,7 @@ func (c *Client) UpdateConnection(input *UpdateConnectionInput) (*UpdateConnecti
 		return nil, errors.New("Connection id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Connection).Put(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Connection).Put(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
 	if err != nil {
 		return nil, err
 	}
@@ -391,6 +412,11 @@ type DeleteConnectionOutput struct {
 
 // Legacy API - please use alert-actions - for more information see https://docs.ilert.com/rest-api/api-version-history#renaming-connections-to-alert-actions
 func (c *Client) DeleteConnection(input *DeleteConnectionInput) (*DeleteConnectionOutput, error) {
+	return c.DeleteConnectionWithContext(context.Background(), input)
+}
+
+// DeleteConnectionWithContext is the same as DeleteConnection with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteConnectionWithContext(ctx context.Context, input *DeleteConnectionInput) (*DeleteConnectionOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -398,7 +424,7 @@ func (c *Client) DeleteConnection(input *DeleteConnectionInput) (*DeleteConnecti
 		return nil, errors.New("Connection id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
 	if err != nil {
 		return nil, err
 	}
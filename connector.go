@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,7 +16,7 @@ type Connector struct {
 	Type      string      `json:"type"`
 	CreatedAt string      `json:"createdAt,omitempty"` // date time string in ISO 8601
 	UpdatedAt string      `json:"updatedAt,omitempty"` // date time string in ISO 8601
-	Params    interface{} `json:"params"`
+	Params    interface{} `json:"params"`              // @deprecated, use NewConnector or SetParams, raw params are only checked at runtime
 }
 
 // ConnectorOutput definition
@@ -26,6 +27,9 @@ type ConnectorOutput struct {
 	CreatedAt string                `json:"createdAt"` // date time string in ISO 8601
 	UpdatedAt string                `json:"updatedAt"` // date time string in ISO 8601
 	Params    ConnectorOutputParams `json:"params"`
+
+	// the params decoded into the params type of the connector type e.g. *ConnectorParamsJira, nil for unsupported connector types or params that do not decode into it
+	TypedParams ConnectorParams `json:"-"`
 }
 
 // ConnectorOutputParams definition
@@ -225,13 +229,21 @@ type CreateConnectorOutput struct {
 
 // CreateConnector creates a new connector. https://api.ilert.com/api-docs/#tag/Connectors/paths/~1connectors/post
 func (c *Client) CreateConnector(input *CreateConnectorInput) (*CreateConnectorOutput, error) {
+	return c.CreateConnectorWithContext(context.Background(), input)
+}
+
+// CreateConnectorWithContext is the same as CreateConnector with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateConnectorWithContext(ctx context.Context, input *CreateConnectorInput) (*CreateConnectorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.Connector == nil {
 		return nil, errors.New("connector input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Connector).Post(apiRoutes.connectors)
+	if err := input.Connector.checkParams(); err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Connector).Post(apiRoutes.connectors)
 	if err != nil {
 		return nil, err
 	}
@@ -262,6 +274,11 @@ type GetConnectorOutput struct {
 
 // GetConnector gets the connector with specified id. https://api.ilert.com/api-docs/#tag/Connectors/paths/~1connectors~1{id}/get
 func (c *Client) GetConnector(input *GetConnectorInput) (*GetConnectorOutput, error) {
+	return c.GetConnectorWithContext(context.Background(), input)
+}
+
+// GetConnectorWithContext is the same as GetConnector with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetConnectorWithContext(ctx context.Context, input *GetConnectorInput) (*GetConnectorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -269,7 +286,7 @@ func (c *Client) GetConnector(input *GetConnectorInput) (*GetConnectorOutput, er
 		return nil, errors.New("connector id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
 	if err != nil {
 		return nil, err
 	}
@@ -307,6 +324,11 @@ type GetConnectorsOutput struct {
 
 // GetConnectors lists existing connectors. https://api.ilert.com/api-docs/#tag/Connectors/paths/~1connectors/get
 func (c *Client) GetConnectors(input *GetConnectorsInput) (*GetConnectorsOutput, error) {
+	return c.GetConnectorsWithContext(context.Background(), input)
+}
+
+// GetConnectorsWithContext is the same as GetConnectors with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetConnectorsWithContext(ctx context.Context, input *GetConnectorsInput) (*GetConnectorsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -319,7 +341,7 @@ func (c *Client) GetConnectors(input *GetConnectorsInput) (*GetConnectorsOutput,
 		q.Add("max-results", "50")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.connectors, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.connectors, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +358,32 @@ func (c *Client) GetConnectors(input *GetConnectorsInput) (*GetConnectorsOutput,
 	return &GetConnectorsOutput{Connectors: connectors}, nil
 }
 
+// GetConnectorsPages iterates over all pages of a GetConnectors operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetConnectorsPages(input *GetConnectorsInput, fn func(page *GetConnectorsOutput) bool, options ...PaginateOption) error {
+	return c.GetConnectorsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetConnectorsPagesWithContext is the same as GetConnectorsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetConnectorsPagesWithContext(ctx context.Context, input *GetConnectorsInput, fn func(page *GetConnectorsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetConnectorsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*ConnectorOutput, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetConnectorsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Connectors, nil
+	}
+	return Paginate(ctx, fetch, func(page []*ConnectorOutput) bool {
+		return fn(&GetConnectorsOutput{Connectors: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchConnectorInput represents the input of a SearchConnector operation.
 type SearchConnectorInput struct {
 	_             struct{}
@@ -350,6 +398,11 @@ type SearchConnectorOutput struct {
 
 // SearchConnector gets the connector with specified name.
 func (c *Client) SearchConnector(input *SearchConnectorInput) (*SearchConnectorOutput, error) {
+	return c.SearchConnectorWithContext(context.Background(), input)
+}
+
+// SearchConnectorWithContext is the same as SearchConnector with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchConnectorWithContext(ctx context.Context, input *SearchConnectorInput) (*SearchConnectorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -357,7 +410,7 @@ func (c *Client) SearchConnector(input *SearchConnectorInput) (*SearchConnectorO
 		return nil, errors.New("connector name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.connectors, *input.ConnectorName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.connectors, *input.ConnectorName))
 	if err != nil {
 		return nil, err
 	}
@@ -389,17 +442,25 @@ type UpdateConnectorOutput struct {
 
 // UpdateConnector updates an existing connector. https://api.ilert.com/api-docs/#tag/Connectors/paths/~1connectors~1{id}/put
 func (c *Client) UpdateConnector(input *UpdateConnectorInput) (*UpdateConnectorOutput, error) {
+	return c.UpdateConnectorWithContext(context.Background(), input)
+}
+
+// UpdateConnectorWithContext is the same as UpdateConnector with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateConnectorWithContext(ctx context.Context, input *UpdateConnectorInput) (*UpdateConnectorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.Connector == nil {
 		return nil, errors.New("connector input is required")
 	}
+	if err := input.Connector.checkParams(); err != nil {
+		return nil, err
+	}
 	if input.ConnectorID == nil {
 		return nil, errors.New("connector id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Connector).Put(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Connector).Put(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
 	if err != nil {
 		return nil, err
 	}
@@ -429,6 +490,11 @@ type DeleteConnectorOutput struct {
 
 // DeleteConnector deletes the specified connector. https://api.ilert.com/api-docs/#tag/Connectors/paths/~1connectors~1{id}/delete
 func (c *Client) DeleteConnector(input *DeleteConnectorInput) (*DeleteConnectorOutput, error) {
+	return c.DeleteConnectorWithContext(context.Background(), input)
+}
+
+// DeleteConnectorWithContext is the same as DeleteConnector with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteConnectorWithContext(ctx context.Context, input *DeleteConnectorInput) (*DeleteConnectorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -436,7 +502,7 @@ func (c *Client) DeleteConnector(input *DeleteConnectorInput) (*DeleteConnectorO
 		return nil, errors.New("connector id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -124,6 +125,11 @@ type CreateDeploymentPipelineOutput struct {
 
 // CreateDeploymentPipeline creates a new deployment pipeline resource. https://api.ilert.com/api-docs/#tag/deployment-pipelines/post/deployment-pipelines
 func (c *Client) CreateDeploymentPipeline(input *CreateDeploymentPipelineInput) (*CreateDeploymentPipelineOutput, error) {
+	return c.CreateDeploymentPipelineWithContext(context.Background(), input)
+}
+
+// CreateDeploymentPipelineWithContext is the same as CreateDeploymentPipeline with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateDeploymentPipelineWithContext(ctx context.Context, input *CreateDeploymentPipelineInput) (*CreateDeploymentPipelineOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -136,7 +142,7 @@ func (c *Client) CreateDeploymentPipeline(input *CreateDeploymentPipelineInput)
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.DeploymentPipeline).Post(fmt.Sprintf("%s?%s", apiRoutes.deploymentPipelines, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.DeploymentPipeline).Post(fmt.Sprintf("%s?%s", apiRoutes.deploymentPipelines, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +177,11 @@ type GetDeploymentPipelineOutput struct {
 
 // GetDeploymentPipeline gets the deployment pipelines resource with specified id. https://api.ilert.com/api-docs/#tag/deployment-pipelines/get/deployment-pipelines/{id}
 func (c *Client) GetDeploymentPipeline(input *GetDeploymentPipelineInput) (*GetDeploymentPipelineOutput, error) {
+	return c.GetDeploymentPipelineWithContext(context.Background(), input)
+}
+
+// GetDeploymentPipelineWithContext is the same as GetDeploymentPipeline with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetDeploymentPipelineWithContext(ctx context.Context, input *GetDeploymentPipelineInput) (*GetDeploymentPipelineOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -183,7 +194,7 @@ func (c *Client) GetDeploymentPipeline(input *GetDeploymentPipelineInput) (*GetD
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d?%s", apiRoutes.deploymentPipelines, *input.DeploymentPipelineID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d?%s", apiRoutes.deploymentPipelines, *input.DeploymentPipelineID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +231,11 @@ type GetDeploymentPipelinesOutput struct {
 
 // GetDeploymentPipelines lists existing deployment pipeline resources. https://api.ilert.com/api-docs/#tag/deployment-pipelines/get/deployment-pipelines
 func (c *Client) GetDeploymentPipelines(input *GetDeploymentPipelinesInput) (*GetDeploymentPipelinesOutput, error) {
+	return c.GetDeploymentPipelinesWithContext(context.Background(), input)
+}
+
+// GetDeploymentPipelinesWithContext is the same as GetDeploymentPipelines with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetDeploymentPipelinesWithContext(ctx context.Context, input *GetDeploymentPipelinesInput) (*GetDeploymentPipelinesOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -228,7 +244,7 @@ func (c *Client) GetDeploymentPipelines(input *GetDeploymentPipelinesInput) (*Ge
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.deploymentPipelines, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.deploymentPipelines, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -245,6 +261,32 @@ func (c *Client) GetDeploymentPipelines(input *GetDeploymentPipelinesInput) (*Ge
 	return &GetDeploymentPipelinesOutput{DeploymentPipelines: deploymentPipelines}, nil
 }
 
+// GetDeploymentPipelinesPages iterates over all pages of a GetDeploymentPipelines operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetDeploymentPipelinesPages(input *GetDeploymentPipelinesInput, fn func(page *GetDeploymentPipelinesOutput) bool, options ...PaginateOption) error {
+	return c.GetDeploymentPipelinesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetDeploymentPipelinesPagesWithContext is the same as GetDeploymentPipelinesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetDeploymentPipelinesPagesWithContext(ctx context.Context, input *GetDeploymentPipelinesInput, fn func(page *GetDeploymentPipelinesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetDeploymentPipelinesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*DeploymentPipelineOutput, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetDeploymentPipelinesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.DeploymentPipelines, nil
+	}
+	return Paginate(ctx, fetch, func(page []*DeploymentPipelineOutput) bool {
+		return fn(&GetDeploymentPipelinesOutput{DeploymentPipelines: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchDeploymentPipelineInput represents the input of a SearchDeploymentPipeline operation.
 type SearchDeploymentPipelineInput struct {
 	_                      struct{}
@@ -259,6 +301,11 @@ type SearchDeploymentPipelineOutput struct {
 
 // SearchDeploymentPipeline gets the deployment pipeline resource with specified name.
 func (c *Client) SearchDeploymentPipeline(input *SearchDeploymentPipelineInput) (*SearchDeploymentPipelineOutput, error) {
+	return c.SearchDeploymentPipelineWithContext(context.Background(), input)
+}
+
+// SearchDeploymentPipelineWithContext is the same as SearchDeploymentPipeline with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchDeploymentPipelineWithContext(ctx context.Context, input *SearchDeploymentPipelineInput) (*SearchDeploymentPipelineOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -266,7 +313,7 @@ func (c *Client) SearchDeploymentPipeline(input *SearchDeploymentPipelineInput)
 		return nil, errors.New("deployment pipeline name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.deploymentPipelines, *input.DeploymentPipelineName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.deploymentPipelines, *input.DeploymentPipelineName))
 	if err != nil {
 		return nil, err
 	}
@@ -302,6 +349,11 @@ type UpdateDeploymentPipelineOutput struct {
 
 // UpdateDeploymentPipeline updates an existing deployment pipeline resource. https://api.ilert.com/api-docs/#tag/deployment-pipelines/put/deployment-pipelines/{id}
 func (c *Client) UpdateDeploymentPipeline(input *UpdateDeploymentPipelineInput) (*UpdateDeploymentPipelineOutput, error) {
+	return c.UpdateDeploymentPipelineWithContext(context.Background(), input)
+}
+
+// UpdateDeploymentPipelineWithContext is the same as UpdateDeploymentPipeline with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateDeploymentPipelineWithContext(ctx context.Context, input *UpdateDeploymentPipelineInput) (*UpdateDeploymentPipelineOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -317,7 +369,7 @@ func (c *Client) UpdateDeploymentPipeline(input *UpdateDeploymentPipelineInput)
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.DeploymentPipeline).Put(fmt.Sprintf("%s/%d?%s", apiRoutes.deploymentPipelines, *input.DeploymentPipelineID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.DeploymentPipeline).Put(fmt.Sprintf("%s/%d?%s", apiRoutes.deploymentPipelines, *input.DeploymentPipelineID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -347,6 +399,11 @@ type DeleteDeploymentPipelineOutput struct {
 
 // DeleteDeploymentPipeline deletes the specified deployment pipeline resource. https://api.ilert.com/api-docs/#tag/deployment-pipelines/delete/deployment-pipelines/{id}
 func (c *Client) DeleteDeploymentPipeline(input *DeleteDeploymentPipelineInput) (*DeleteDeploymentPipelineOutput, error) {
+	return c.DeleteDeploymentPipelineWithContext(context.Background(), input)
+}
+
+// DeleteDeploymentPipelineWithContext is the same as DeleteDeploymentPipeline with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteDeploymentPipelineWithContext(ctx context.Context, input *DeleteDeploymentPipelineInput) (*DeleteDeploymentPipelineOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -354,7 +411,7 @@ func (c *Client) DeleteDeploymentPipeline(input *DeleteDeploymentPipelineInput)
 		return nil, errors.New("deployment pipeline id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.deploymentPipelines, *input.DeploymentPipelineID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.deploymentPipelines, *input.DeploymentPipelineID))
 	if err != nil {
 		return nil, err
 	}
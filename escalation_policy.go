@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,13 +45,18 @@ type CreateEscalationPolicyOutput struct {
 
 // CreateEscalationPolicy creates a new escalation policy. https://api.ilert.com/api-docs/#tag/Escalation-Policies/paths/~1escalation-policies/post
 func (c *Client) CreateEscalationPolicy(input *CreateEscalationPolicyInput) (*CreateEscalationPolicyOutput, error) {
+	return c.CreateEscalationPolicyWithContext(context.Background(), input)
+}
+
+// CreateEscalationPolicyWithContext is the same as CreateEscalationPolicy with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateEscalationPolicyWithContext(ctx context.Context, input *CreateEscalationPolicyInput) (*CreateEscalationPolicyOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.EscalationPolicy == nil {
 		return nil, errors.New("escalation policy input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.EscalationPolicy).Post(apiRoutes.escalationPolicies)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.EscalationPolicy).Post(apiRoutes.escalationPolicies)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +87,11 @@ type GetEscalationPolicyOutput struct {
 
 // GetEscalationPolicy gets the escalation policy with specified id. https://api.ilert.com/api-docs/#tag/Escalation-Policies/paths/~1escalation-policies~1{id}/get
 func (c *Client) GetEscalationPolicy(input *GetEscalationPolicyInput) (*GetEscalationPolicyOutput, error) {
+	return c.GetEscalationPolicyWithContext(context.Background(), input)
+}
+
+// GetEscalationPolicyWithContext is the same as GetEscalationPolicy with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetEscalationPolicyWithContext(ctx context.Context, input *GetEscalationPolicyInput) (*GetEscalationPolicyOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -88,7 +99,7 @@ func (c *Client) GetEscalationPolicy(input *GetEscalationPolicyInput) (*GetEscal
 		return nil, errors.New("escalation policy id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +136,11 @@ type GetEscalationPoliciesOutput struct {
 
 // GetEscalationPolicies lists existing escalation policies. https://api.ilert.com/api-docs/#tag/Escalation-Policies/paths/~1escalation-policies/get
 func (c *Client) GetEscalationPolicies(input *GetEscalationPoliciesInput) (*GetEscalationPoliciesOutput, error) {
+	return c.GetEscalationPoliciesWithContext(context.Background(), input)
+}
+
+// GetEscalationPoliciesWithContext is the same as GetEscalationPolicies with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetEscalationPoliciesWithContext(ctx context.Context, input *GetEscalationPoliciesInput) (*GetEscalationPoliciesOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -133,7 +149,7 @@ func (c *Client) GetEscalationPolicies(input *GetEscalationPoliciesInput) (*GetE
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.escalationPolicies, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.escalationPolicies, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +166,32 @@ func (c *Client) GetEscalationPolicies(input *GetEscalationPoliciesInput) (*GetE
 	return &GetEscalationPoliciesOutput{EscalationPolicies: escalationPolicies}, nil
 }
 
+// GetEscalationPoliciesPages iterates over all pages of a GetEscalationPolicies operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetEscalationPoliciesPages(input *GetEscalationPoliciesInput, fn func(page *GetEscalationPoliciesOutput) bool, options ...PaginateOption) error {
+	return c.GetEscalationPoliciesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetEscalationPoliciesPagesWithContext is the same as GetEscalationPoliciesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetEscalationPoliciesPagesWithContext(ctx context.Context, input *GetEscalationPoliciesInput, fn func(page *GetEscalationPoliciesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetEscalationPoliciesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*EscalationPolicy, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetEscalationPoliciesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.EscalationPolicies, nil
+	}
+	return Paginate(ctx, fetch, func(page []*EscalationPolicy) bool {
+		return fn(&GetEscalationPoliciesOutput{EscalationPolicies: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 50, options)...)
+}
+
 // SearchEscalationPolicyInput represents the input of a SearchEscalationPolicy operation.
 type SearchEscalationPolicyInput struct {
 	_                    struct{}
@@ -164,6 +206,11 @@ type SearchEscalationPolicyOutput struct {
 
 // SearchEscalationPolicy gets the escalationPolicy with specified name.
 func (c *Client) SearchEscalationPolicy(input *SearchEscalationPolicyInput) (*SearchEscalationPolicyOutput, error) {
+	return c.SearchEscalationPolicyWithContext(context.Background(), input)
+}
+
+// SearchEscalationPolicyWithContext is the same as SearchEscalationPolicy with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchEscalationPolicyWithContext(ctx context.Context, input *SearchEscalationPolicyInput) (*SearchEscalationPolicyOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -171,7 +218,7 @@ func (c *Client) SearchEscalationPolicy(input *SearchEscalationPolicyInput) (*Se
 		return nil, errors.New("escalation policy name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.escalationPolicies, *input.EscalationPolicyName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.escalationPolicies, *input.EscalationPolicyName))
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +250,11 @@ type UpdateEscalationPolicyOutput struct {
 
 // UpdateEscalationPolicy updates an existing escalation policy. https://api.ilert.com/api-docs/#tag/Escalation-Policies/paths/~1escalation-policies~1{id}/put
 func (c *Client) UpdateEscalationPolicy(input *UpdateEscalationPolicyInput) (*UpdateEscalationPolicyOutput, error) {
+	return c.UpdateEscalationPolicyWithContext(context.Background(), input)
+}
+
+// UpdateEscalationPolicyWithContext is the same as UpdateEscalationPolicy with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateEscalationPolicyWithContext(ctx context.Context, input *UpdateEscalationPolicyInput) (*UpdateEscalationPolicyOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -213,7 +265,7 @@ func (c *Client) UpdateEscalationPolicy(input *UpdateEscalationPolicyInput) (*Up
 		return nil, errors.New("escalation policy id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.EscalationPolicy).Put(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.EscalationPolicy).Put(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +295,11 @@ type DeleteEscalationPolicyOutput struct {
 
 // DeleteEscalationPolicy deletes the specified escalation policy. https://api.ilert.com/api-docs/#tag/Escalation-Policies/paths/~1escalation-policies~1{id}/delete
 func (c *Client) DeleteEscalationPolicy(input *DeleteEscalationPolicyInput) (*DeleteEscalationPolicyOutput, error) {
+	return c.DeleteEscalationPolicyWithContext(context.Background(), input)
+}
+
+// DeleteEscalationPolicyWithContext is the same as DeleteEscalationPolicy with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteEscalationPolicyWithContext(ctx context.Context, input *DeleteEscalationPolicyInput) (*DeleteEscalationPolicyOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -250,7 +307,7 @@ func (c *Client) DeleteEscalationPolicy(input *DeleteEscalationPolicyInput) (*De
 		return nil, errors.New("escalation policy id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
 	if err != nil {
 		return nil, err
 	}
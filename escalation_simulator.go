@@ -0,0 +1,413 @@
+package ilert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EscalationTimeline describes who is notified when an alert escalates along a policy
+type EscalationTimeline struct {
+	Policy    *EscalationPolicy
+	AlertTime time.Time
+
+	// escalation steps ordered by time
+	Steps []EscalationStep
+
+	// time after the alert at which the escalation ends, if the alert is not accepted
+	End time.Duration
+}
+
+// EscalationStep is an escalation rule reached at a point in time
+type EscalationStep struct {
+	// time after the alert at which the rule is reached
+	Offset time.Duration
+	Time   time.Time
+
+	// index of the rule within the policy
+	RuleIndex int
+	Rule      EscalationRule
+
+	// 0 for the first pass, 1 for the first repetition and so on
+	Repetition int
+
+	// notified users, each user once
+	Notifications []EscalationNotification
+
+	// targets that did not resolve to any user e.g. a schedule without anybody on call
+	Unresolved []string
+}
+
+// EscalationNotification is a user notified by an escalation rule
+type EscalationNotification struct {
+	User User
+
+	// the rule target the user was resolved from e.g. "user", "schedule Primary" or "team Ops"
+	Via string
+}
+
+// Users returns the notified users of the step
+func (s *EscalationStep) Users() []User {
+	users := make([]User, 0, len(s.Notifications))
+	for _, notification := range s.Notifications {
+		users = append(users, notification.User)
+	}
+	return users
+}
+
+// At returns the step that is active at the given time after the alert, nil if the escalation has not started or ended
+func (t *EscalationTimeline) At(offset time.Duration) *EscalationStep {
+	if offset >= t.End {
+		return nil
+	}
+	var step *EscalationStep
+	for i := range t.Steps {
+		if t.Steps[i].Offset > offset {
+			break
+		}
+		step = &t.Steps[i]
+	}
+	return step
+}
+
+// NotifiedUntil returns every user notified up to and including the given time after the alert, each user once
+func (t *EscalationTimeline) NotifiedUntil(offset time.Duration) []User {
+	users := make([]User, 0)
+	for _, step := range t.Steps {
+		if step.Offset > offset {
+			break
+		}
+		for _, notification := range step.Notifications {
+			if !containsUser(users, notification.User) {
+				users = append(users, notification.User)
+			}
+		}
+	}
+	return users
+}
+
+// String returns one line per step e.g. "+5m rule 2: Alice Doe (schedule Primary)"
+func (t *EscalationTimeline) String() string {
+	b := &strings.Builder{}
+	for _, step := range t.Steps {
+		fmt.Fprintf(b, "+%s rule %d", formatOffset(step.Offset), step.RuleIndex+1)
+		if step.Repetition > 0 {
+			fmt.Fprintf(b, " (repetition %d)", step.Repetition)
+		}
+		b.WriteString(":")
+		parts := make([]string, 0, len(step.Notifications)+len(step.Unresolved))
+		for _, notification := range step.Notifications {
+			parts = append(parts, fmt.Sprintf("%s (%s)", UserDisplayName(notification.User), notification.Via))
+		}
+		for _, unresolved := range step.Unresolved {
+			parts = append(parts, fmt.Sprintf("nobody (%s)", unresolved))
+		}
+		if len(parts) == 0 {
+			parts = append(parts, "nobody")
+		}
+		fmt.Fprintf(b, " %s\n", strings.Join(parts, ", "))
+	}
+	fmt.Fprintf(b, "+%s escalation ends\n", formatOffset(t.End))
+	return b.String()
+}
+
+// EscalationSimulator computes escalation timelines of escalation policies
+type EscalationSimulator struct {
+	client  *Client
+	options *EscalationSimulatorOptions
+}
+
+// EscalationSimulatorOptions holds data used instead of calling the API
+type EscalationSimulatorOptions struct {
+	// shifts per schedule id
+	Shifts map[int64][]Shift
+
+	// teams per team id
+	Teams map[int64]*Team
+}
+
+// EscalationSimulatorOption allows for options to be passed into NewEscalationSimulator for customization
+type EscalationSimulatorOption func(*EscalationSimulatorOptions)
+
+// WithSimulatorShifts sets the shifts of a schedule, the schedule's shifts are not fetched from the API
+func WithSimulatorShifts(scheduleID int64, shifts []Shift) EscalationSimulatorOption {
+	return func(o *EscalationSimulatorOptions) {
+		o.Shifts[scheduleID] = shifts
+	}
+}
+
+// WithSimulatorTeam sets the members of a team, the team is not fetched from the API
+func WithSimulatorTeam(team *Team) EscalationSimulatorOption {
+	return func(o *EscalationSimulatorOptions) {
+		if team != nil {
+			o.Teams[team.ID] = team
+		}
+	}
+}
+
+// NewEscalationSimulator creates a new escalation simulator. The client may be nil if all schedules and teams
+// are given as options or are contained in the policy.
+func NewEscalationSimulator(client *Client, options ...EscalationSimulatorOption) *EscalationSimulator {
+	opts := &EscalationSimulatorOptions{
+		Shifts: make(map[int64][]Shift),
+		Teams:  make(map[int64]*Team),
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &EscalationSimulator{client: client, options: opts}
+}
+
+// Simulate returns who is notified when an alert created at the given time is never accepted.
+// Rules are reached after the escalation timeouts of the previous rules, delayed by the policy's DelayMin.
+// Repeating policies start over with the first rule after the last rule's timeout, Frequency times.
+//
+// Schedules are resolved to the user on call at the time the rule is reached: from shifts given with
+// WithSimulatorShifts, rendered from layers or shifts contained in the policy, or fetched with GetScheduleShifts.
+// If at is zero the alert is created now and rules reached immediately resolve schedules with GetScheduleUserOnCall.
+func (s *EscalationSimulator) Simulate(ctx context.Context, policy *EscalationPolicy, at time.Time) (*EscalationTimeline, error) {
+	if policy == nil {
+		return nil, errors.New("escalation policy is required")
+	}
+	now := at.IsZero()
+	if now {
+		at = time.Now().Truncate(time.Second)
+	}
+
+	repetitions := 0
+	if policy.Repeating {
+		repetitions = policy.Frequency
+		if repetitions < 1 {
+			repetitions = 1
+		}
+	}
+	timeline := &EscalationTimeline{
+		Policy:    policy,
+		AlertTime: at,
+		Steps:     make([]EscalationStep, 0),
+	}
+	offset := time.Duration(policy.DelayMin) * time.Minute
+	for repetition := 0; repetition <= repetitions; repetition++ {
+		for i, rule := range policy.EscalationRules {
+			timeline.Steps = append(timeline.Steps, EscalationStep{
+				Offset:     offset,
+				Time:       at.Add(offset),
+				RuleIndex:  i,
+				Rule:       rule,
+				Repetition: repetition,
+			})
+			offset += time.Duration(rule.EscalationTimeout) * time.Minute
+		}
+	}
+	timeline.End = offset
+
+	resolver := &escalationResolver{
+		simulator: s,
+		from:      at,
+		until:     at.Add(offset + time.Minute),
+		shifts:    make(map[int64][]Shift),
+		teams:     make(map[int64]*Team),
+	}
+	for i := range timeline.Steps {
+		step := &timeline.Steps[i]
+		step.Notifications = make([]EscalationNotification, 0)
+		step.Unresolved = make([]string, 0)
+		err := resolver.resolve(ctx, step, now && step.Offset == 0)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve rule %d: %w", step.RuleIndex+1, err)
+		}
+	}
+	return timeline, nil
+}
+
+// escalationResolver resolves rule targets to users, caching shifts and teams per simulation
+type escalationResolver struct {
+	simulator *EscalationSimulator
+	from      time.Time
+	until     time.Time
+	shifts    map[int64][]Shift
+	teams     map[int64]*Team
+}
+
+func (r *escalationResolver) resolve(ctx context.Context, step *EscalationStep, onCallNow bool) error {
+	notify := func(user User, via string) {
+		for _, notification := range step.Notifications {
+			if SameUser(notification.User, user) {
+				return
+			}
+		}
+		step.Notifications = append(step.Notifications, EscalationNotification{User: user, Via: via})
+	}
+
+	rule := step.Rule
+	if rule.User != nil {
+		notify(*rule.User, "user")
+	}
+	for _, user := range rule.Users {
+		notify(user, "user")
+	}
+
+	schedules := make([]Schedule, 0, len(rule.Schedules)+1)
+	if rule.Schedule != nil {
+		schedules = append(schedules, *rule.Schedule)
+	}
+	schedules = append(schedules, rule.Schedules...)
+	for _, schedule := range schedules {
+		via := "schedule " + scheduleName(schedule)
+		users, err := r.onCall(ctx, schedule, step.Time, onCallNow)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			step.Unresolved = append(step.Unresolved, via)
+		}
+		for _, user := range users {
+			notify(user, via)
+		}
+	}
+
+	for _, teamShort := range rule.Teams {
+		team, err := r.team(ctx, teamShort)
+		if err != nil {
+			return err
+		}
+		name := team.Name
+		if name == "" {
+			name = fmt.Sprint(team.ID)
+		}
+		via := "team " + name
+		resolved := false
+		for _, member := range team.Members {
+			if member.Role == TeamMemberRoles.Stakeholder {
+				continue
+			}
+			notify(member.User, via)
+			resolved = true
+		}
+		if !resolved {
+			step.Unresolved = append(step.Unresolved, via)
+		}
+	}
+	return nil
+}
+
+// onCall returns the users on call for the schedule at the given time
+func (r *escalationResolver) onCall(ctx context.Context, schedule Schedule, t time.Time, now bool) ([]User, error) {
+	if _, ok := r.simulator.options.Shifts[schedule.ID]; !ok && now && schedule.ID != 0 && r.simulator.client != nil {
+		result, err := r.simulator.client.GetScheduleUserOnCallWithContext(ctx, &GetScheduleUserOnCallInput{ScheduleID: Int64(schedule.ID)})
+		if err != nil {
+			return nil, fmt.Errorf("could not get user on call of schedule %s: %w", scheduleName(schedule), err)
+		}
+		if result.Shift == nil {
+			return []User{}, nil
+		}
+		return []User{result.Shift.User}, nil
+	}
+
+	shifts, err := r.scheduleShifts(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if schedule.Timezone != "" {
+		if tz, err := time.LoadLocation(schedule.Timezone); err == nil {
+			loc = tz
+		}
+	}
+	users := make([]User, 0)
+	for _, shift := range shifts {
+		segment, err := shiftSegment(shift, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shift of schedule %s: %w", scheduleName(schedule), err)
+		}
+		if !segment.start.After(t) && segment.end.After(t) && !containsUser(users, shift.User) {
+			users = append(users, shift.User)
+		}
+	}
+	return users, nil
+}
+
+func (r *escalationResolver) scheduleShifts(ctx context.Context, schedule Schedule) ([]Shift, error) {
+	if shifts, ok := r.simulator.options.Shifts[schedule.ID]; ok {
+		return shifts, nil
+	}
+	if shifts, ok := r.shifts[schedule.ID]; ok && schedule.ID != 0 {
+		return shifts, nil
+	}
+	var shifts []Shift
+	switch {
+	case len(schedule.ScheduleLayers) > 0 || len(schedule.Shifts) > 0:
+		rendered, err := RenderSchedule(&schedule, r.from, r.until)
+		if err != nil {
+			return nil, fmt.Errorf("could not render schedule %s: %w", scheduleName(schedule), err)
+		}
+		shifts = rendered
+	case schedule.ID != 0 && r.simulator.client != nil:
+		result, err := r.simulator.client.GetScheduleShiftsWithContext(ctx, &GetScheduleShiftsInput{
+			ScheduleID: Int64(schedule.ID),
+			From:       String(r.from.UTC().Format(time.RFC3339)),
+			Until:      String(r.until.UTC().Format(time.RFC3339)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get shifts of schedule %s: %w", scheduleName(schedule), err)
+		}
+		shifts = make([]Shift, 0, len(result.Shifts))
+		for _, shift := range result.Shifts {
+			if shift != nil {
+				shifts = append(shifts, *shift)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("no shifts for schedule %s", scheduleName(schedule))
+	}
+	r.shifts[schedule.ID] = shifts
+	return shifts, nil
+}
+
+func (r *escalationResolver) team(ctx context.Context, teamShort TeamShort) (*Team, error) {
+	if team, ok := r.simulator.options.Teams[teamShort.ID]; ok {
+		return team, nil
+	}
+	if team, ok := r.teams[teamShort.ID]; ok {
+		return team, nil
+	}
+	if r.simulator.client == nil {
+		return nil, fmt.Errorf("no members for team %d", teamShort.ID)
+	}
+	result, err := r.simulator.client.GetTeamWithContext(ctx, &GetTeamInput{TeamID: Int64(teamShort.ID)})
+	if err != nil {
+		return nil, fmt.Errorf("could not get team %d: %w", teamShort.ID, err)
+	}
+	r.teams[teamShort.ID] = result.Team
+	return result.Team, nil
+}
+
+func scheduleName(schedule Schedule) string {
+	if schedule.Name != "" {
+		return schedule.Name
+	}
+	return fmt.Sprint(schedule.ID)
+}
+
+func containsUser(users []User, user User) bool {
+	for _, u := range users {
+		if SameUser(u, user) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatOffset formats a duration in minutes or hours and minutes e.g. 5m or 1h30m
+func formatOffset(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,200 @@
+package ilert_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestEscalationSimulatorRepetitions(t *testing.T) {
+	jane := ilert.User{ID: 1, FirstName: "Jane", LastName: "Doe"}
+	john := ilert.User{ID: 2, Username: "john"}
+	rules := []ilert.EscalationRule{
+		{User: &jane, EscalationTimeout: 10},
+		{Users: []ilert.User{john, jane}, EscalationTimeout: 15},
+	}
+	tests := []struct {
+		name        string
+		policy      *ilert.EscalationPolicy
+		offsets     []int
+		repetitions []int
+		end         int
+	}{
+		{"delayed", &ilert.EscalationPolicy{EscalationRules: rules, DelayMin: 5}, []int{5, 15}, []int{0, 0}, 30},
+		{"repeating twice", &ilert.EscalationPolicy{EscalationRules: rules, DelayMin: 5, Repeating: true, Frequency: 2}, []int{5, 15, 30, 40, 55, 65}, []int{0, 0, 1, 1, 2, 2}, 80},
+		{"repeating without frequency", &ilert.EscalationPolicy{EscalationRules: rules, Repeating: true}, []int{0, 10, 25, 35}, []int{0, 0, 1, 1}, 50},
+		{"frequency without repeating", &ilert.EscalationPolicy{EscalationRules: rules, Frequency: 3}, []int{0, 10}, []int{0, 0}, 25},
+	}
+	at := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	for _, test := range tests {
+		timeline, err := ilert.NewEscalationSimulator(nil).Simulate(context.Background(), test.policy, at)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		offsets, repetitions := []int{}, []int{}
+		for _, step := range timeline.Steps {
+			offsets = append(offsets, int(step.Offset/time.Minute))
+			repetitions = append(repetitions, step.Repetition)
+			if !step.Time.Equal(at.Add(step.Offset)) {
+				t.Errorf("%s: expected rule %d to be reached at %s, got %s", test.name, step.RuleIndex+1, at.Add(step.Offset), step.Time)
+			}
+		}
+		if fmt.Sprint(offsets) != fmt.Sprint(test.offsets) || fmt.Sprint(repetitions) != fmt.Sprint(test.repetitions) {
+			t.Errorf("%s: expected offsets %v of repetitions %v, got %v of %v", test.name, test.offsets, test.repetitions, offsets, repetitions)
+		}
+		if timeline.End != time.Duration(test.end)*time.Minute {
+			t.Errorf("%s: expected the escalation to end after %dm, got %s", test.name, test.end, timeline.End)
+		}
+	}
+
+	timeline, err := ilert.NewEscalationSimulator(nil).Simulate(context.Background(), tests[0].policy, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeline.At(4*time.Minute) != nil || timeline.At(5*time.Minute).RuleIndex != 0 || timeline.At(29*time.Minute).RuleIndex != 1 || timeline.At(30*time.Minute) != nil {
+		t.Error("expected the rules to be active between their offsets until the escalation ends")
+	}
+	if users := timeline.NotifiedUntil(14 * time.Minute); len(users) != 1 || users[0] != jane {
+		t.Errorf("expected jane to be notified before the second rule, got %+v", users)
+	}
+	if users := timeline.NotifiedUntil(time.Hour); len(users) != 2 {
+		t.Errorf("expected each user to be notified once, got %+v", users)
+	}
+	want := "+5m rule 1: Jane Doe (user)\n+15m rule 2: john (user), Jane Doe (user)\n+30m escalation ends\n"
+	if got := timeline.String(); got != want {
+		t.Errorf("expected timeline %q, got %q", want, got)
+	}
+}
+
+func TestEscalationSimulatorResolvesSchedules(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	users := make(map[string]ilert.User)
+	for _, username := range []string{"jane", "john", "alex", "bob"} {
+		output, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Username: username, Email: username + "@example.com"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		users[username] = *output.User
+	}
+	primary, err := client.CreateSchedule(&ilert.CreateScheduleInput{Schedule: &ilert.Schedule{
+		Name:     "Primary",
+		Timezone: "UTC",
+		Type:     ilert.ScheduleType.Recurring,
+		ScheduleLayers: []ilert.ScheduleLayer{{
+			Name:     "Daily",
+			StartsOn: "2024-03-04T00:00:00Z",
+			Users:    []ilert.User{users["jane"], users["john"]},
+			Rotation: "P1D",
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{
+		Name: "Ops",
+		Members: []ilert.TeamMember{
+			{User: users["jane"], Role: ilert.TeamMemberRoles.Responder},
+			{User: users["bob"], Role: ilert.TeamMemberRoles.Stakeholder},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stakeholders := &ilert.Team{ID: 77, Name: "Stakeholders", Members: []ilert.TeamMember{{User: users["bob"], Role: ilert.TeamMemberRoles.Stakeholder}}}
+	secondary := []ilert.Shift{{User: users["alex"], Start: "2024-03-05T00:00:00Z", End: "2024-03-05T01:30:00Z"}}
+	inline := ilert.Schedule{
+		Name:     "Inline",
+		Timezone: "UTC",
+		ScheduleLayers: []ilert.ScheduleLayer{{
+			StartsOn: "2024-03-01T00:00:00Z",
+			Users:    []ilert.User{users["john"]},
+			Rotation: "P7D",
+		}},
+	}
+	policy := &ilert.EscalationPolicy{
+		Repeating: true,
+		Frequency: 1,
+		EscalationRules: []ilert.EscalationRule{
+			{Schedule: &ilert.Schedule{ID: primary.Schedule.ID}, EscalationTimeout: 30},
+			{Schedules: []ilert.Schedule{{ID: 99, Name: "Secondary"}}, EscalationTimeout: 30},
+			{Schedule: &inline, Teams: []ilert.TeamShort{{ID: ops.Team.ID}}, EscalationTimeout: 10},
+			{Schedule: &ilert.Schedule{ID: 98, Name: "Empty"}, Teams: []ilert.TeamShort{{ID: stakeholders.ID}}, EscalationTimeout: 10},
+		},
+	}
+	simulator := ilert.NewEscalationSimulator(client,
+		ilert.WithSimulatorShifts(99, secondary),
+		ilert.WithSimulatorShifts(98, []ilert.Shift{}),
+		ilert.WithSimulatorTeam(stakeholders))
+
+	timeline, err := simulator.Simulate(context.Background(), policy, time.Date(2024, 3, 4, 23, 45, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the repetition reaches the primary schedule after the rotation handed over to john
+	want := "+0m rule 1: jane (schedule " + fmt.Sprint(primary.Schedule.ID) + ")\n" +
+		"+30m rule 2: alex (schedule Secondary)\n" +
+		"+1h rule 3: john (schedule Inline), jane (team Ops)\n" +
+		"+1h10m rule 4: nobody (schedule Empty), nobody (team Stakeholders)\n" +
+		"+1h20m rule 1 (repetition 1): john (schedule " + fmt.Sprint(primary.Schedule.ID) + ")\n" +
+		"+1h50m rule 2 (repetition 1): nobody (schedule Secondary)\n" +
+		"+2h20m rule 3 (repetition 1): john (schedule Inline), jane (team Ops)\n" +
+		"+2h30m rule 4 (repetition 1): nobody (schedule Empty), nobody (team Stakeholders)\n" +
+		"+2h40m escalation ends\n"
+	if got := timeline.String(); got != want {
+		t.Errorf("expected timeline\n%s\ngot\n%s", want, got)
+	}
+	if n := countRequests(srv, http.MethodGet, fmt.Sprintf("/api/schedules/%d/shifts", primary.Schedule.ID)); n != 1 {
+		t.Errorf("expected the shifts of the primary schedule to be fetched once, got %d", n)
+	}
+	if n := countRequests(srv, http.MethodGet, fmt.Sprintf("/api/teams/%d", ops.Team.ID)); n != 1 {
+		t.Errorf("expected the ops team to be fetched once, got %d", n)
+	}
+}
+
+func TestEscalationSimulatorResolvesUserOnCallNow(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	jane, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Username: "jane", Email: "jane@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := client.CreateSchedule(&ilert.CreateScheduleInput{Schedule: &ilert.Schedule{
+		Name:     "Primary",
+		Timezone: "UTC",
+		Type:     ilert.ScheduleType.Recurring,
+		ScheduleLayers: []ilert.ScheduleLayer{{
+			Name:     "Daily",
+			StartsOn: "2024-01-01T00:00:00Z",
+			Users:    []ilert.User{{ID: jane.User.ID}},
+			Rotation: "P1D",
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := &ilert.EscalationPolicy{EscalationRules: []ilert.EscalationRule{
+		{Schedule: &ilert.Schedule{ID: schedule.Schedule.ID}, EscalationTimeout: 5},
+	}}
+
+	timeline, err := ilert.NewEscalationSimulator(client).Simulate(context.Background(), policy, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if users := timeline.Steps[0].Users(); len(users) != 1 || users[0].ID != jane.User.ID {
+		t.Errorf("expected jane to be on call now, got %+v", users)
+	}
+	if n := countRequests(srv, http.MethodGet, fmt.Sprintf("/api/schedules/%d/user-on-call", schedule.Schedule.ID)); n != 1 {
+		t.Errorf("expected the user on call to be fetched, got %d requests", n)
+	}
+}
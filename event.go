@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 )
@@ -99,6 +100,11 @@ type CreateEventOutput struct {
 
 // CreateEvent creates an alert event. https://api.ilert.com/api-docs/#tag/Events/paths/~1events/post
 func (c *Client) CreateEvent(input *CreateEventInput) (*CreateEventOutput, error) {
+	return c.CreateEventWithContext(context.Background(), input)
+}
+
+// CreateEventWithContext is the same as CreateEvent with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateEventWithContext(ctx context.Context, input *CreateEventInput) (*CreateEventOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -109,7 +115,7 @@ func (c *Client) CreateEvent(input *CreateEventInput) (*CreateEventOutput, error
 	if input.URL != nil && *input.URL != "" {
 		url = *input.URL
 	}
-	resp, err := c.httpClient.R().SetBody(input.Event).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Event).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,265 @@
+package ilert
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrEventDropped is returned for ACCEPT and RESOLVE events that were dropped because their alert key was never alerted locally
+var ErrEventDropped = errors.New("event dropped: alert key was never alerted")
+
+// EventDeduplicationStats describes the counters of an EventDeduplicator
+type EventDeduplicationStats struct {
+	// events passed on to CreateEvent
+	Sent int64
+
+	// identical ALERT events answered with the response of the first event within the window
+	Coalesced int64
+
+	// ACCEPT and RESOLVE events dropped for alert keys that were never alerted locally
+	Dropped int64
+
+	// alert keys currently tracked
+	Keys int
+}
+
+// eventDeduplicationEntry describes the last ALERT event sent for an alert key
+type eventDeduplicationEntry struct {
+	fingerprint [sha256.Size]byte
+	sentAt      time.Time
+	seenAt      time.Time
+	output      *CreateEventOutput
+}
+
+// eventDeduplicationCall is an ALERT event being sent, identical events wait for its response
+type eventDeduplicationCall struct {
+	fingerprint [sha256.Size]byte
+	done        chan struct{}
+	output      *CreateEventOutput
+	err         error
+}
+
+// EventDeduplicator is a client-side deduplication layer for CreateEvent keyed on the event's api key and alert key.
+// Identical ALERT events for the same alert key are coalesced within a window and answered with the response
+// of the first event, optionally ACCEPT and RESOLVE events for alert keys never alerted locally are dropped.
+// Events without alert key are always passed on.
+type EventDeduplicator struct {
+	client              *Client
+	window              time.Duration
+	keyTTL              time.Duration
+	dropUnknownResolves bool
+	now                 func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]*eventDeduplicationEntry
+	inflight  map[string]*eventDeduplicationCall
+	stats     EventDeduplicationStats
+	lastPrune time.Time
+}
+
+// EventDeduplicatorOptions allows for options to be passed into the EventDeduplicator for customization
+type EventDeduplicatorOptions func(*EventDeduplicator)
+
+// WithDeduplicationWindow sets the window in which identical ALERT events are coalesced
+// Default: 1 minute
+func WithDeduplicationWindow(window time.Duration) EventDeduplicatorOptions {
+	return func(d *EventDeduplicator) {
+		d.window = window
+	}
+}
+
+// WithDeduplicationKeyTTL sets how long alert keys are remembered after their last ALERT event
+// Default: 24 hours
+func WithDeduplicationKeyTTL(ttl time.Duration) EventDeduplicatorOptions {
+	return func(d *EventDeduplicator) {
+		d.keyTTL = ttl
+	}
+}
+
+// WithDropUnknownResolves drops ACCEPT and RESOLVE events for alert keys that were never alerted locally
+func WithDropUnknownResolves(drop bool) EventDeduplicatorOptions {
+	return func(d *EventDeduplicator) {
+		d.dropUnknownResolves = drop
+	}
+}
+
+// NewEventDeduplicator creates a deduplication layer on top of the CreateEvent operation of the client
+func NewEventDeduplicator(client *Client, options ...EventDeduplicatorOptions) *EventDeduplicator {
+	d := &EventDeduplicator{
+		client:   client,
+		window:   1 * time.Minute,
+		keyTTL:   24 * time.Hour,
+		now:      time.Now,
+		entries:  make(map[string]*eventDeduplicationEntry),
+		inflight: make(map[string]*eventDeduplicationCall),
+	}
+	for _, opt := range options {
+		opt(d)
+	}
+	return d
+}
+
+// CreateEvent creates an alert event unless it is suppressed. https://api.ilert.com/api-docs/#tag/Events/paths/~1events/post
+func (d *EventDeduplicator) CreateEvent(input *CreateEventInput) (*CreateEventOutput, error) {
+	return d.CreateEventWithContext(context.Background(), input)
+}
+
+// CreateEventWithContext is the same as CreateEvent with the addition of a context, which is used to cancel the request and its retries.
+func (d *EventDeduplicator) CreateEventWithContext(ctx context.Context, input *CreateEventInput) (*CreateEventOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.Event == nil {
+		return nil, errors.New("input event is required")
+	}
+	event := input.Event
+	if event.AlertKey == "" {
+		return d.send(ctx, input)
+	}
+	key := event.APIKey + "\x00" + event.AlertKey
+
+	if event.EventType == EventTypes.Alert {
+		return d.alert(ctx, input, key)
+	}
+
+	d.mu.Lock()
+	d.prune(d.now())
+	_, known := d.entries[key]
+	if _, sending := d.inflight[key]; sending {
+		known = true
+	}
+
+	switch event.EventType {
+	case EventTypes.Accept, EventTypes.Resolve:
+		if !known && d.dropUnknownResolves {
+			d.stats.Dropped++
+			d.mu.Unlock()
+			return nil, ErrEventDropped
+		}
+		d.mu.Unlock()
+
+		output, err := d.send(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		if event.EventType == EventTypes.Resolve {
+			d.mu.Lock()
+			delete(d.entries, key)
+			d.mu.Unlock()
+		}
+		return output, nil
+	}
+
+	d.mu.Unlock()
+	return d.send(ctx, input)
+}
+
+// alert sends an ALERT event unless an identical event was sent within the window or is being sent,
+// in which case the response of that event is returned. If the event being sent fails, waiting events are sent again.
+func (d *EventDeduplicator) alert(ctx context.Context, input *CreateEventInput, key string) (*CreateEventOutput, error) {
+	fingerprint, err := eventFingerprint(input.Event)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		d.mu.Lock()
+		now := d.now()
+		d.prune(now)
+		if entry, ok := d.entries[key]; ok {
+			entry.seenAt = now
+			if entry.fingerprint == fingerprint && now.Sub(entry.sentAt) < d.window && entry.output != nil {
+				d.stats.Coalesced++
+				d.mu.Unlock()
+				return entry.output, nil
+			}
+		}
+		call, ok := d.inflight[key]
+		if !ok || call.fingerprint != fingerprint {
+			break
+		}
+		d.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if call.err == nil {
+			d.mu.Lock()
+			d.stats.Coalesced++
+			d.mu.Unlock()
+			return call.output, nil
+		}
+	}
+
+	now := d.now()
+	call := &eventDeduplicationCall{fingerprint: fingerprint, done: make(chan struct{})}
+	d.inflight[key] = call
+	d.mu.Unlock()
+
+	call.output, call.err = d.send(ctx, input)
+
+	d.mu.Lock()
+	if d.inflight[key] == call {
+		delete(d.inflight, key)
+	}
+	if call.err == nil {
+		d.entries[key] = &eventDeduplicationEntry{fingerprint: fingerprint, sentAt: now, seenAt: now, output: call.output}
+	}
+	d.mu.Unlock()
+	close(call.done)
+	return call.output, call.err
+}
+
+// Stats returns the current suppression counters
+func (d *EventDeduplicator) Stats() EventDeduplicationStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := d.stats
+	stats.Keys = len(d.entries)
+	return stats
+}
+
+// Reset forgets all alert keys and resets the counters
+func (d *EventDeduplicator) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = make(map[string]*eventDeduplicationEntry)
+	d.stats = EventDeduplicationStats{}
+}
+
+func (d *EventDeduplicator) send(ctx context.Context, input *CreateEventInput) (*CreateEventOutput, error) {
+	output, err := d.client.CreateEventWithContext(ctx, input)
+	if err == nil {
+		d.mu.Lock()
+		d.stats.Sent++
+		d.mu.Unlock()
+	}
+	return output, err
+}
+
+// prune forgets alert keys that were not seen within the key ttl, the deduplicator must be locked by the caller
+func (d *EventDeduplicator) prune(now time.Time) {
+	if d.keyTTL <= 0 || now.Sub(d.lastPrune) < d.keyTTL/10 {
+		return
+	}
+	d.lastPrune = now
+	for key, entry := range d.entries {
+		if now.Sub(entry.seenAt) > d.keyTTL {
+			delete(d.entries, key)
+		}
+	}
+}
+
+// eventFingerprint hashes the event content that is relevant to decide whether two events are identical
+func eventFingerprint(event *Event) ([sha256.Size]byte, error) {
+	content, err := json.Marshal(event)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(content), nil
+}
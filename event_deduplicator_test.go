@@ -0,0 +1,189 @@
+package ilert_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestEventDeduplicatorCoalescesIdenticalAlerts(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	clock := newFakeClock()
+	dedup := ilert.NewEventDeduplicator(srv.Client(), ilert.WithDeduplicationWindow(time.Minute))
+	ilert.SetDeduplicatorClock(dedup, clock.Now)
+	send := func(event *ilert.Event) *ilert.CreateEventOutput {
+		t.Helper()
+		output, err := dedup.CreateEvent(&ilert.CreateEventInput{Event: event})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return output
+	}
+
+	first := send(alertEvent("db-1"))
+	if second := send(alertEvent("db-1")); second != first {
+		t.Error("expected the identical event to be answered with the first response")
+	}
+	changed := alertEvent("db-1")
+	changed.Summary = "db still down"
+	send(changed)
+	send(alertEvent("db-2"))
+	send(&ilert.Event{APIKey: "key", EventType: ilert.EventTypes.Alert, Summary: "no key"})
+	send(&ilert.Event{APIKey: "key", EventType: ilert.EventTypes.Alert, Summary: "no key"})
+	clock.Advance(time.Minute)
+	send(changed)
+
+	// changed content, other alert keys, events without alert key and the event after the window are sent
+	if stats := dedup.Stats(); stats.Sent != 6 || stats.Coalesced != 1 || stats.Keys != 2 {
+		t.Errorf("expected 6 sent and 1 coalesced event for 2 keys, got %+v", stats)
+	}
+	if events := srv.Events(); len(events) != 6 {
+		t.Errorf("expected 6 events to reach the server, got %d", len(events))
+	}
+
+	dedup.Reset()
+	send(changed)
+	if stats := dedup.Stats(); stats.Sent != 1 || stats.Coalesced != 0 || stats.Keys != 1 {
+		t.Errorf("expected reset counters and keys, got %+v", stats)
+	}
+}
+
+func TestEventDeduplicatorCoalescesConcurrentAlerts(t *testing.T) {
+	mu := sync.Mutex{}
+	requests := 0
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		received <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"incidentUrl":"https://example.com/alerts/1"}`))
+	}))
+	defer ts.Close()
+	dedup := ilert.NewEventDeduplicator(ilert.NewClient(ilert.WithAPIEndpoint(ts.URL), ilert.WithRetry(0, 0, 0)))
+
+	wg := sync.WaitGroup{}
+	outputs := make([]*ilert.CreateEventOutput, 5)
+	errs := make([]error, 5)
+	for i := range outputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i], errs[i] = dedup.CreateEvent(&ilert.CreateEventInput{Event: alertEvent("db-1")})
+		}(i)
+	}
+	<-received
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range outputs {
+		if errs[i] != nil || outputs[i] != outputs[0] {
+			t.Errorf("expected every event to get the response of the sent event, got %+v and %v", outputs[i], errs[i])
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+	if stats := dedup.Stats(); stats.Sent != 1 || stats.Coalesced != 4 {
+		t.Errorf("expected 1 sent and 4 coalesced events, got %+v", stats)
+	}
+}
+
+func TestEventDeduplicatorSendsAgainAfterFailure(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	dedup := ilert.NewEventDeduplicator(srv.Client())
+	srv.InjectError(http.MethodPost, "/api/events", http.StatusInternalServerError, "INTERNAL_ERROR", 1)
+
+	if _, err := dedup.CreateEvent(&ilert.CreateEventInput{Event: alertEvent("db-1")}); err == nil {
+		t.Fatal("expected the first event to fail")
+	}
+	if _, err := dedup.CreateEvent(&ilert.CreateEventInput{Event: alertEvent("db-1")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats := dedup.Stats(); stats.Sent != 1 || stats.Coalesced != 0 {
+		t.Errorf("expected the event to be sent again, got %+v", stats)
+	}
+}
+
+func TestEventDeduplicatorDropsUnknownResolves(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	clock := newFakeClock()
+	dedup := ilert.NewEventDeduplicator(srv.Client(), ilert.WithDropUnknownResolves(true), ilert.WithDeduplicationKeyTTL(time.Hour))
+	ilert.SetDeduplicatorClock(dedup, clock.Now)
+	send := func(eventType string, alertKey string) error {
+		_, err := dedup.CreateEvent(&ilert.CreateEventInput{Event: &ilert.Event{APIKey: "key", EventType: eventType, AlertKey: alertKey, Summary: "db down"}})
+		return err
+	}
+
+	if err := send(ilert.EventTypes.Resolve, "db-1"); !errors.Is(err, ilert.ErrEventDropped) {
+		t.Errorf("expected the resolve of an unknown alert key to be dropped, got %v", err)
+	}
+	if err := send(ilert.EventTypes.Accept, "db-1"); !errors.Is(err, ilert.ErrEventDropped) {
+		t.Errorf("expected the accept of an unknown alert key to be dropped, got %v", err)
+	}
+	if err := send(ilert.EventTypes.Alert, "db-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := send(ilert.EventTypes.Accept, "db-1"); err != nil {
+		t.Errorf("expected the accept of an alerted key to be sent, got %v", err)
+	}
+	if err := send(ilert.EventTypes.Resolve, "db-1"); err != nil {
+		t.Errorf("expected the resolve of an alerted key to be sent, got %v", err)
+	}
+	if err := send(ilert.EventTypes.Resolve, "db-1"); !errors.Is(err, ilert.ErrEventDropped) {
+		t.Errorf("expected the alert key to be forgotten after the resolve, got %v", err)
+	}
+
+	// alert keys not seen within the key ttl are pruned
+	if err := send(ilert.EventTypes.Alert, "db-2"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(30 * time.Minute)
+	if err := send(ilert.EventTypes.Alert, "db-2"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(59 * time.Minute)
+	if err := send(ilert.EventTypes.Accept, "db-2"); err != nil {
+		t.Errorf("expected an alert key seen within the key ttl to be kept, got %v", err)
+	}
+	clock.Advance(2 * time.Hour)
+	if err := send(ilert.EventTypes.Resolve, "db-2"); !errors.Is(err, ilert.ErrEventDropped) {
+		t.Errorf("expected the pruned alert key to be dropped, got %v", err)
+	}
+
+	if stats := dedup.Stats(); stats.Sent != 6 || stats.Dropped != 4 || stats.Keys != 0 {
+		t.Errorf("expected 6 sent and 4 dropped events without keys, got %+v", stats)
+	}
+	if events := srv.Events(); len(events) != 6 {
+		t.Errorf("expected dropped events not to reach the server, got %d events", len(events))
+	}
+}
+
+func TestEventDeduplicatorPassesUnknownResolvesByDefault(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	srv.SeedAlert(ilert.Alert{Summary: "db down", AlertKey: "db-1", AlertSource: &ilert.AlertSource{IntegrationKey: "key"}})
+	dedup := ilert.NewEventDeduplicator(srv.Client())
+
+	_, err := dedup.CreateEvent(&ilert.CreateEventInput{Event: &ilert.Event{APIKey: "key", EventType: ilert.EventTypes.Resolve, AlertKey: "db-1"}})
+
+	if err != nil || len(srv.Events()) != 1 {
+		t.Errorf("expected the resolve to be sent, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -175,6 +176,11 @@ type CreateEventFlowOutput struct {
 
 // CreateEventFlow creates a new event flow resource. https://api.ilert.com/api-docs/#tag/event-flows/post/event-flows
 func (c *Client) CreateEventFlow(input *CreateEventFlowInput) (*CreateEventFlowOutput, error) {
+	return c.CreateEventFlowWithContext(context.Background(), input)
+}
+
+// CreateEventFlowWithContext is the same as CreateEventFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateEventFlowWithContext(ctx context.Context, input *CreateEventFlowInput) (*CreateEventFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -182,7 +188,7 @@ func (c *Client) CreateEventFlow(input *CreateEventFlowInput) (*CreateEventFlowO
 		return nil, errors.New("event flow input is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.EventFlow).Post(fmt.Sprintf("%s", apiRoutes.eventFlows))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.EventFlow).Post(fmt.Sprintf("%s", apiRoutes.eventFlows))
 	if err != nil {
 		return nil, err
 	}
@@ -213,6 +219,11 @@ type GetEventFlowOutput struct {
 
 // GetEventFlow gets the event flow resource with specified id. https://api.ilert.com/api-docs/#tag/event-flows/get/event-flows/{id}
 func (c *Client) GetEventFlow(input *GetEventFlowInput) (*GetEventFlowOutput, error) {
+	return c.GetEventFlowWithContext(context.Background(), input)
+}
+
+// GetEventFlowWithContext is the same as GetEventFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetEventFlowWithContext(ctx context.Context, input *GetEventFlowInput) (*GetEventFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -220,7 +231,7 @@ func (c *Client) GetEventFlow(input *GetEventFlowInput) (*GetEventFlowOutput, er
 		return nil, errors.New("event flow id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.eventFlows, *input.EventFlowID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d", apiRoutes.eventFlows, *input.EventFlowID))
 	if err != nil {
 		return nil, err
 	}
@@ -257,6 +268,11 @@ type GetEventFlowsOutput struct {
 
 // GetEventFlows lists existing event flow resources. https://api.ilert.com/api-docs/#tag/event-flows/get/event-flows
 func (c *Client) GetEventFlows(input *GetEventFlowsInput) (*GetEventFlowsOutput, error) {
+	return c.GetEventFlowsWithContext(context.Background(), input)
+}
+
+// GetEventFlowsWithContext is the same as GetEventFlows with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetEventFlowsWithContext(ctx context.Context, input *GetEventFlowsInput) (*GetEventFlowsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -265,7 +281,7 @@ func (c *Client) GetEventFlows(input *GetEventFlowsInput) (*GetEventFlowsOutput,
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.eventFlows, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.eventFlows, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +298,32 @@ func (c *Client) GetEventFlows(input *GetEventFlowsInput) (*GetEventFlowsOutput,
 	return &GetEventFlowsOutput{EventFlows: eventFlows}, nil
 }
 
+// GetEventFlowsPages iterates over all pages of a GetEventFlows operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetEventFlowsPages(input *GetEventFlowsInput, fn func(page *GetEventFlowsOutput) bool, options ...PaginateOption) error {
+	return c.GetEventFlowsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetEventFlowsPagesWithContext is the same as GetEventFlowsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetEventFlowsPagesWithContext(ctx context.Context, input *GetEventFlowsInput, fn func(page *GetEventFlowsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetEventFlowsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*EventFlowOutput, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetEventFlowsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.EventFlows, nil
+	}
+	return Paginate(ctx, fetch, func(page []*EventFlowOutput) bool {
+		return fn(&GetEventFlowsOutput{EventFlows: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchEventFlowInput represents the input of a SearchEventFlow operation.
 type SearchEventFlowInput struct {
 	_             struct{}
@@ -296,6 +338,11 @@ type SearchEventFlowOutput struct {
 
 // SearchEventFlow gets the event flow resource with specified name.
 func (c *Client) SearchEventFlow(input *SearchEventFlowInput) (*SearchEventFlowOutput, error) {
+	return c.SearchEventFlowWithContext(context.Background(), input)
+}
+
+// SearchEventFlowWithContext is the same as SearchEventFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchEventFlowWithContext(ctx context.Context, input *SearchEventFlowInput) (*SearchEventFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -303,7 +350,7 @@ func (c *Client) SearchEventFlow(input *SearchEventFlowInput) (*SearchEventFlowO
 		return nil, errors.New("event flow name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.eventFlows, *input.EventFlowName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.eventFlows, *input.EventFlowName))
 	if err != nil {
 		return nil, err
 	}
@@ -335,6 +382,11 @@ type UpdateEventFlowOutput struct {
 
 // UpdateEventFlow updates an existing event flow resource. https://api.ilert.com/api-docs/#tag/event-flows/put/event-flows/{id}
 func (c *Client) UpdateEventFlow(input *UpdateEventFlowInput) (*UpdateEventFlowOutput, error) {
+	return c.UpdateEventFlowWithContext(context.Background(), input)
+}
+
+// UpdateEventFlowWithContext is the same as UpdateEventFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateEventFlowWithContext(ctx context.Context, input *UpdateEventFlowInput) (*UpdateEventFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -345,7 +397,7 @@ func (c *Client) UpdateEventFlow(input *UpdateEventFlowInput) (*UpdateEventFlowO
 		return nil, errors.New("event flow id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.EventFlow).Put(fmt.Sprintf("%s/%d", apiRoutes.eventFlows, *input.EventFlowID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.EventFlow).Put(fmt.Sprintf("%s/%d", apiRoutes.eventFlows, *input.EventFlowID))
 	if err != nil {
 		return nil, err
 	}
@@ -375,6 +427,11 @@ type DeleteEventFlowOutput struct {
 
 // DeleteEventFlow deletes the specified event flow resource. https://api.ilert.com/api-docs/#tag/event-flows/delete/event-flows/{id}
 func (c *Client) DeleteEventFlow(input *DeleteEventFlowInput) (*DeleteEventFlowOutput, error) {
+	return c.DeleteEventFlowWithContext(context.Background(), input)
+}
+
+// DeleteEventFlowWithContext is the same as DeleteEventFlow with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteEventFlowWithContext(ctx context.Context, input *DeleteEventFlowInput) (*DeleteEventFlowOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -382,7 +439,7 @@ func (c *Client) DeleteEventFlow(input *DeleteEventFlowInput) (*DeleteEventFlowO
 		return nil, errors.New("event flow id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.eventFlows, *input.EventFlowID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.eventFlows, *input.EventFlowID))
 	if err != nil {
 		return nil, err
 	}
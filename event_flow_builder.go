@@ -0,0 +1,402 @@
+package ilert
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EventFlowBuilder builds an event flow starting with the node an accepted event enters
+//
+//	flow, err := ilert.NewEventFlowBuilder("Routing").
+//		Start(ilert.EventFlowDefineBranches("Severity").
+//			Define("critical", "event.priority == 'HIGH'", ilert.EventFlowRouteEvent("Pager", pagerAlertSourceID)).
+//			CatchAll(ilert.EventFlowRouteEvent("Tickets", ticketAlertSourceID))).
+//		Build()
+type EventFlowBuilder struct {
+	flow  *EventFlow
+	start *EventFlowNodeBuilder
+}
+
+// NewEventFlowBuilder creates a new event flow builder
+func NewEventFlowBuilder(name string) *EventFlowBuilder {
+	return &EventFlowBuilder{flow: &EventFlow{Name: name}}
+}
+
+// Teams sets the teams of the event flow
+func (b *EventFlowBuilder) Teams(teams ...TeamShort) *EventFlowBuilder {
+	b.flow.Teams = teams
+	return b
+}
+
+// Start sets the node that accepted events enter
+func (b *EventFlowBuilder) Start(node *EventFlowNodeBuilder) *EventFlowBuilder {
+	b.start = node
+	return b
+}
+
+// Build returns the event flow or the errors of Validate
+func (b *EventFlowBuilder) Build() (*EventFlow, error) {
+	flow := *b.flow
+	flow.RootNode = &EventFlowNode{NodeType: EventFlowNodeType.Root, Branches: []EventFlowBranch{}}
+	if b.start != nil {
+		flow.RootNode.Branches = append(flow.RootNode.Branches, EventFlowBranch{
+			BranchType: EventFlowBranchType.Accepted,
+			Target:     b.start.Node(),
+		})
+	}
+	if err := flow.Validate(); err != nil {
+		return nil, err
+	}
+	return &flow, nil
+}
+
+// EventFlowNodeBuilder builds an event flow node with typed metadata, create it with one of the
+// EventFlowPlain, EventFlowSupportHours, EventFlowRouteEvent, EventFlowDefineBranches, EventFlowWait or EventFlowTransform functions
+type EventFlowNodeBuilder struct {
+	node     *EventFlowNode
+	metadata *EventFlowNodeMetadata
+}
+
+func newEventFlowNodeBuilder(nodeType string, name string, metadata *EventFlowNodeMetadata) *EventFlowNodeBuilder {
+	return &EventFlowNodeBuilder{
+		node:     &EventFlowNode{Name: name, NodeType: nodeType, Branches: []EventFlowBranch{}},
+		metadata: metadata,
+	}
+}
+
+// EventFlowPlain creates a PLAIN node branching on conditions, the variable is optional
+func EventFlowPlain(name string, varKey string, varValue string) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.Plain, name, &EventFlowNodeMetadata{VarKey: varKey, VarValue: varValue})
+}
+
+// EventFlowSupportHours creates a SUPPORT_HOURS node, continue with During and Outside
+func EventFlowSupportHours(name string, supportHoursID int64) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.SupportHours, name, &EventFlowNodeMetadata{SupportHoursID: Int64(supportHoursID)})
+}
+
+// EventFlowRouteEvent creates a ROUTE_EVENT node routing the event to an alert source
+func EventFlowRouteEvent(name string, alertSourceID int64) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.RouteEvent, name, &EventFlowNodeMetadata{AlertSourceID: Int64(alertSourceID)})
+}
+
+// EventFlowDefineBranches creates a DEFINE_BRANCHES node, add branches with Define
+func EventFlowDefineBranches(name string) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.DefineBranches, name, &EventFlowNodeMetadata{Definitions: []EventFlowNodeDefinition{}})
+}
+
+// EventFlowWait creates a WAIT node waiting for an ISO 8601 duration e.g. PT5M
+func EventFlowWait(name string, duration string) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.Wait, name, &EventFlowNodeMetadata{WaitForDuration: duration})
+}
+
+// EventFlowWaitForSupportHoursStart creates a WAIT node waiting until the support hours start
+func EventFlowWaitForSupportHoursStart(name string, supportHoursID int64) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.Wait, name, &EventFlowNodeMetadata{WaitStartSupportHoursID: Int64(supportHoursID)})
+}
+
+// EventFlowWaitForSupportHoursEnd creates a WAIT node waiting until the support hours end
+func EventFlowWaitForSupportHoursEnd(name string, supportHoursID int64) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.Wait, name, &EventFlowNodeMetadata{WaitEndSupportHoursID: Int64(supportHoursID)})
+}
+
+// EventFlowTransform creates a TRANSFORM node applying the rules to the event
+func EventFlowTransform(name string, rules ...EventFlowNodeRuleMetadata) *EventFlowNodeBuilder {
+	return newEventFlowNodeBuilder(EventFlowNodeType.Transform, name, &EventFlowNodeMetadata{Rules: rules})
+}
+
+// Branch adds a branch taken if the condition matches
+func (b *EventFlowNodeBuilder) Branch(condition string, target *EventFlowNodeBuilder) *EventFlowNodeBuilder {
+	b.node.Branches = append(b.node.Branches, EventFlowBranch{
+		BranchType: EventFlowBranchType.Branch,
+		Condition:  condition,
+		Target:     target.Node(),
+	})
+	return b
+}
+
+// CatchAll adds the branch taken if no other branch matches
+func (b *EventFlowNodeBuilder) CatchAll(target *EventFlowNodeBuilder) *EventFlowNodeBuilder {
+	b.node.Branches = append(b.node.Branches, EventFlowBranch{
+		BranchType: EventFlowBranchType.CatchAll,
+		Target:     target.Node(),
+	})
+	return b
+}
+
+// Then continues with the target after a WAIT or TRANSFORM node, same as CatchAll
+func (b *EventFlowNodeBuilder) Then(target *EventFlowNodeBuilder) *EventFlowNodeBuilder {
+	return b.CatchAll(target)
+}
+
+// Define adds a branch definition and its branch to a DEFINE_BRANCHES node
+func (b *EventFlowNodeBuilder) Define(branchName string, conditions string, target *EventFlowNodeBuilder) *EventFlowNodeBuilder {
+	b.metadata.Definitions = append(b.metadata.Definitions, EventFlowNodeDefinition{BranchName: branchName, Conditions: conditions})
+	return b.Branch(branchName, target)
+}
+
+// During adds the branch of a SUPPORT_HOURS node taken during support hours
+func (b *EventFlowNodeBuilder) During(target *EventFlowNodeBuilder) *EventFlowNodeBuilder {
+	return b.Branch(SupportStatus.During, target)
+}
+
+// Outside adds the branch of a SUPPORT_HOURS node taken outside of support hours
+func (b *EventFlowNodeBuilder) Outside(target *EventFlowNodeBuilder) *EventFlowNodeBuilder {
+	return b.Branch(SupportStatus.Outside, target)
+}
+
+// EscalationPolicy sets the escalation policy of a ROUTE_EVENT node
+func (b *EventFlowNodeBuilder) EscalationPolicy(escalationPolicyID int64) *EventFlowNodeBuilder {
+	b.metadata.EscalationPolicyID = Int64(escalationPolicyID)
+	return b
+}
+
+// OverwritePriority sets the priority of a ROUTE_EVENT node, one of EventFlowNodeMetadataOverwritePriority
+func (b *EventFlowNodeBuilder) OverwritePriority(priority string) *EventFlowNodeBuilder {
+	b.metadata.OverwritePriority = priority
+	return b
+}
+
+// When sets the condition of a TRANSFORM node, rules are only applied to matching events
+func (b *EventFlowNodeBuilder) When(condition string) *EventFlowNodeBuilder {
+	b.metadata.Condition = condition
+	return b
+}
+
+// Node returns the node, nil for a nil builder so that a missing branch target is reported by Validate
+func (b *EventFlowNodeBuilder) Node() *EventFlowNode {
+	if b == nil {
+		return nil
+	}
+	b.node.Metadata = b.metadata
+	return b.node
+}
+
+// Validate reports structural errors of the event flow as FlowValidationErrors, e.g. missing metadata,
+// branch types not allowed at a node, DEFINE_BRANCHES definitions not matching the branches or branches cycling back
+func (f *EventFlow) Validate() error {
+	v := &flowValidator{}
+	if f.Name == "" {
+		v.addf("name", "is required")
+	}
+	root := f.RootNode
+	if root == nil {
+		v.addf("root", "is required")
+		return v.err()
+	}
+	if root.NodeType != EventFlowNodeType.Root {
+		v.addf("root.nodeType", "must be %s", EventFlowNodeType.Root)
+	}
+	if len(root.Branches) != 1 {
+		v.addf("root.branches", "must contain exactly one %s branch", EventFlowBranchType.Accepted)
+	}
+	for i, branch := range root.Branches {
+		path := fmt.Sprintf("root.branches[%d]", i)
+		if branch.BranchType != EventFlowBranchType.Accepted {
+			v.addf(path+".branchType", "must be %s", EventFlowBranchType.Accepted)
+		}
+		if branch.Target == nil {
+			v.addf(path+".target", "is required")
+			continue
+		}
+		validateEventFlowNode(v, path+".target", branch.Target)
+	}
+	return v.err()
+}
+
+func validateEventFlowNode(v *flowValidator, path string, node *EventFlowNode) {
+	if !v.enter(path, node) {
+		return
+	}
+	defer v.leave(node)
+	if !containsString(EventFlowNodeTypeAll, node.NodeType) || node.NodeType == EventFlowNodeType.Root {
+		v.addf(path+".nodeType", "invalid node type %q", node.NodeType)
+		return
+	}
+	metadata, err := eventFlowNodeMetadata(node.Metadata)
+	if err != nil {
+		v.addf(path+".metadata", "%s", err)
+		return
+	}
+
+	// branches
+	catchAll := 0
+	conditions := make(map[string]bool)
+	for i, branch := range node.Branches {
+		branchPath := fmt.Sprintf("%s.branches[%d]", path, i)
+		switch branch.BranchType {
+		case EventFlowBranchType.Branch:
+			if branch.Condition == "" {
+				v.addf(branchPath+".condition", "is required")
+			} else if conditions[branch.Condition] {
+				v.addf(branchPath+".condition", "duplicate condition %q", branch.Condition)
+			}
+			conditions[branch.Condition] = true
+		case EventFlowBranchType.CatchAll:
+			catchAll++
+			if catchAll > 1 {
+				v.addf(branchPath+".branchType", "only one %s branch is allowed", EventFlowBranchType.CatchAll)
+			}
+			if branch.Condition != "" {
+				v.addf(branchPath+".condition", "must be empty for %s", EventFlowBranchType.CatchAll)
+			}
+		case EventFlowBranchType.Accepted:
+			v.addf(branchPath+".branchType", "%s is only allowed on the root node", EventFlowBranchType.Accepted)
+		default:
+			v.addf(branchPath+".branchType", "invalid branch type %q", branch.BranchType)
+		}
+	}
+
+	// metadata
+	metadataPath := path + ".metadata"
+	switch node.NodeType {
+	case EventFlowNodeType.Plain:
+		if (metadata.VarKey == "") != (metadata.VarValue == "") {
+			v.addf(metadataPath, "varKey and varValue must be set together")
+		}
+	case EventFlowNodeType.SupportHours:
+		if metadata.SupportHoursID == nil {
+			v.addf(metadataPath+".supportHoursId", "is required")
+		}
+		for i, branch := range node.Branches {
+			if branch.BranchType == EventFlowBranchType.Branch && !containsString(SupportStatusAll, branch.Condition) {
+				v.addf(fmt.Sprintf("%s.branches[%d].condition", path, i), "must be one of %v", SupportStatusAll)
+			}
+		}
+	case EventFlowNodeType.RouteEvent:
+		if metadata.AlertSourceID == nil {
+			v.addf(metadataPath+".alertSourceId", "is required")
+		}
+		if metadata.OverwritePriority != "" && !containsString(EventFlowNodeMetadataOverwritePriorityAll, metadata.OverwritePriority) {
+			v.addf(metadataPath+".overwritePriority", "must be one of %v", EventFlowNodeMetadataOverwritePriorityAll)
+		}
+		if len(node.Branches) > 0 {
+			v.addf(path+".branches", "must be empty for %s", EventFlowNodeType.RouteEvent)
+		}
+	case EventFlowNodeType.DefineBranches:
+		if len(metadata.Definitions) == 0 {
+			v.addf(metadataPath+".definitions", "at least one definition is required")
+		}
+		defined := make(map[string]bool)
+		for i, definition := range metadata.Definitions {
+			definitionPath := fmt.Sprintf("%s.definitions[%d]", metadataPath, i)
+			if definition.BranchName == "" {
+				v.addf(definitionPath+".branchName", "is required")
+			} else if defined[definition.BranchName] {
+				v.addf(definitionPath+".branchName", "duplicate branch name %q", definition.BranchName)
+			}
+			if definition.Conditions == "" {
+				v.addf(definitionPath+".conditions", "is required")
+			}
+			defined[definition.BranchName] = true
+			if definition.BranchName != "" && !conditions[definition.BranchName] {
+				v.addf(definitionPath, "no branch for definition %q", definition.BranchName)
+			}
+		}
+		for i, branch := range node.Branches {
+			if branch.BranchType == EventFlowBranchType.Branch && branch.Condition != "" && !defined[branch.Condition] {
+				v.addf(fmt.Sprintf("%s.branches[%d].condition", path, i), "no definition for branch %q", branch.Condition)
+			}
+		}
+	case EventFlowNodeType.Wait:
+		set := 0
+		if metadata.WaitForDuration != "" {
+			set++
+			if _, err := parseScheduleDuration(metadata.WaitForDuration); err != nil {
+				v.addf(metadataPath+".waitForDuration", "invalid ISO 8601 duration %q", metadata.WaitForDuration)
+			}
+		}
+		if metadata.WaitStartSupportHoursID != nil {
+			set++
+		}
+		if metadata.WaitEndSupportHoursID != nil {
+			set++
+		}
+		if set != 1 {
+			v.addf(metadataPath, "exactly one of waitForDuration, waitStartSupportHoursId or waitEndSupportHoursId is required")
+		}
+		validateEventFlowContinuation(v, path, node)
+	case EventFlowNodeType.Transform:
+		if len(metadata.Rules) == 0 {
+			v.addf(metadataPath+".rules", "at least one rule is required")
+		}
+		for i, rule := range metadata.Rules {
+			validateEventFlowRule(v, fmt.Sprintf("%s.rules[%d]", metadataPath, i), rule)
+		}
+		validateEventFlowContinuation(v, path, node)
+	}
+
+	for i, branch := range node.Branches {
+		targetPath := fmt.Sprintf("%s.branches[%d].target", path, i)
+		if branch.Target == nil {
+			v.addf(targetPath, "is required")
+			continue
+		}
+		validateEventFlowNode(v, targetPath, branch.Target)
+	}
+}
+
+// validateEventFlowContinuation checks that a node continues with a single CATCH_ALL branch at most
+func validateEventFlowContinuation(v *flowValidator, path string, node *EventFlowNode) {
+	for i, branch := range node.Branches {
+		if branch.BranchType == EventFlowBranchType.Branch {
+			v.addf(fmt.Sprintf("%s.branches[%d].branchType", path, i), "%s continues with a %s branch only", node.NodeType, EventFlowBranchType.CatchAll)
+		}
+	}
+}
+
+func validateEventFlowRule(v *flowValidator, path string, rule EventFlowNodeRuleMetadata) {
+	if rule.Target == "" {
+		v.addf(path+".target", "is required")
+	}
+	switch rule.Operator {
+	case EventFlowNodeRuleOperator.Set, EventFlowNodeRuleOperator.Template:
+		if rule.Value == nil {
+			v.addf(path+".value", "is required for %s", rule.Operator)
+		}
+	case EventFlowNodeRuleOperator.Copy:
+		if rule.Source == "" {
+			v.addf(path+".source", "is required for %s", rule.Operator)
+		}
+	case EventFlowNodeRuleOperator.Map:
+		if rule.Source == "" {
+			v.addf(path+".source", "is required for %s", rule.Operator)
+		}
+		if len(rule.Mapping) == 0 {
+			v.addf(path+".mapping", "is required for %s", rule.Operator)
+		}
+	case EventFlowNodeRuleOperator.Merge:
+		if len(rule.Properties) == 0 {
+			v.addf(path+".properties", "is required for %s", rule.Operator)
+		}
+	case EventFlowNodeRuleOperator.AppendArray:
+		if len(rule.Items) == 0 {
+			v.addf(path+".items", "is required for %s", rule.Operator)
+		}
+	default:
+		v.addf(path+".operator", "must be one of %v", EventFlowNodeRuleOperatorAll)
+	}
+}
+
+// eventFlowNodeMetadata returns the metadata of a node as EventFlowNodeMetadata
+func eventFlowNodeMetadata(metadata any) (*EventFlowNodeMetadata, error) {
+	switch m := metadata.(type) {
+	case nil:
+		return &EventFlowNodeMetadata{}, nil
+	case *EventFlowNodeMetadata:
+		if m == nil {
+			return &EventFlowNodeMetadata{}, nil
+		}
+		return m, nil
+	case EventFlowNodeMetadata:
+		return &m, nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	result := &EventFlowNodeMetadata{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,189 @@
+package ilert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEventFlowBuilderMissingTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		node *EventFlowNodeBuilder
+		path string
+	}{
+		{"branch", EventFlowPlain("Plain", "", "").Branch("event.priority == 'HIGH'", nil), "root.branches[0].target.branches[0].target"},
+		{"catch all", EventFlowPlain("Plain", "", "").CatchAll(nil), "root.branches[0].target.branches[0].target"},
+		{"then", EventFlowWait("Wait", "PT5M").Then(nil), "root.branches[0].target.branches[0].target"},
+	}
+	for _, test := range tests {
+		flow, err := NewEventFlowBuilder("Routing").Start(test.node).Build()
+		var errs FlowValidationErrors
+		if flow != nil || !errors.As(err, &errs) {
+			t.Errorf("%s: expected validation errors, got %v", test.name, err)
+			continue
+		}
+		found := false
+		for _, e := range errs {
+			found = found || (e.Path == test.path && e.Message == "is required")
+		}
+		if !found {
+			t.Errorf("%s: expected %s to be required, got %v", test.name, test.path, err)
+		}
+	}
+}
+
+func TestEventFlowValidate(t *testing.T) {
+	route := func() *EventFlowNodeBuilder { return EventFlowRouteEvent("Tickets", 42) }
+	target := "root.branches[0].target"
+	tests := []struct {
+		name    string
+		node    func() *EventFlowNodeBuilder
+		path    string
+		message string
+	}{
+		{
+			name: "two catch all",
+			node: func() *EventFlowNodeBuilder {
+				return EventFlowPlain("Plain", "", "").CatchAll(route()).CatchAll(route())
+			},
+			path:    target + ".branches[1].branchType",
+			message: "only one CATCH_ALL branch is allowed",
+		},
+		{
+			name: "catch all with condition",
+			node: func() *EventFlowNodeBuilder {
+				plain := EventFlowPlain("Plain", "", "")
+				plain.node.Branches = append(plain.node.Branches, EventFlowBranch{BranchType: EventFlowBranchType.CatchAll, Condition: "event.priority == 'HIGH'", Target: route().Node()})
+				return plain
+			},
+			path:    target + ".branches[0].condition",
+			message: "must be empty for CATCH_ALL",
+		},
+		{
+			name:    "wait with two catch all",
+			node:    func() *EventFlowNodeBuilder { return EventFlowWait("Wait", "PT5M").Then(route()).Then(route()) },
+			path:    target + ".branches[1].branchType",
+			message: "only one CATCH_ALL branch is allowed",
+		},
+		{
+			name: "accepted below root",
+			node: func() *EventFlowNodeBuilder {
+				plain := EventFlowPlain("Plain", "", "")
+				plain.node.Branches = append(plain.node.Branches, EventFlowBranch{BranchType: EventFlowBranchType.Accepted, Target: route().Node()})
+				return plain
+			},
+			path:    target + ".branches[0].branchType",
+			message: "ACCEPTED is only allowed on the root node",
+		},
+		{
+			name:    "no definitions",
+			node:    func() *EventFlowNodeBuilder { return EventFlowDefineBranches("Severity") },
+			path:    target + ".metadata.definitions",
+			message: "at least one definition is required",
+		},
+		{
+			name: "definition without branch",
+			node: func() *EventFlowNodeBuilder {
+				define := EventFlowDefineBranches("Severity").Define("critical", "event.priority == 'HIGH'", route())
+				define.metadata.Definitions = append(define.metadata.Definitions, EventFlowNodeDefinition{BranchName: "minor", Conditions: "event.priority == 'LOW'"})
+				return define
+			},
+			path:    target + ".metadata.definitions[1]",
+			message: `no branch for definition "minor"`,
+		},
+		{
+			name: "branch without definition",
+			node: func() *EventFlowNodeBuilder {
+				return EventFlowDefineBranches("Severity").Define("critical", "event.priority == 'HIGH'", route()).Branch("minor", route())
+			},
+			path:    target + ".branches[1].condition",
+			message: `no definition for branch "minor"`,
+		},
+		{
+			name: "duplicate definition",
+			node: func() *EventFlowNodeBuilder {
+				return EventFlowDefineBranches("Severity").Define("critical", "event.priority == 'HIGH'", route()).Define("critical", "event.summary == 'down'", route())
+			},
+			path:    target + ".metadata.definitions[1].branchName",
+			message: `duplicate branch name "critical"`,
+		},
+		{
+			name: "definition without conditions",
+			node: func() *EventFlowNodeBuilder {
+				return EventFlowDefineBranches("Severity").Define("critical", "", route())
+			},
+			path:    target + ".metadata.definitions[0].conditions",
+			message: "is required",
+		},
+		{
+			name: "cycle",
+			node: func() *EventFlowNodeBuilder {
+				wait := EventFlowWait("Wait", "PT5M")
+				plain := EventFlowPlain("Plain", "", "").Branch("event.priority == 'HIGH'", route()).CatchAll(wait)
+				wait.node.Branches = append(wait.node.Branches, EventFlowBranch{BranchType: EventFlowBranchType.CatchAll, Target: plain.Node()})
+				return plain
+			},
+			path:    target + ".branches[1].target.branches[0].target",
+			message: "cycles back to a node it is reached from",
+		},
+	}
+	for _, test := range tests {
+		_, err := NewEventFlowBuilder("Routing").Start(test.node()).Build()
+		var errs FlowValidationErrors
+		if !errors.As(err, &errs) {
+			t.Errorf("%s: expected validation errors, got %v", test.name, err)
+			continue
+		}
+		found := false
+		for _, e := range errs {
+			found = found || (e.Path == test.path && e.Message == test.message)
+		}
+		if !found {
+			t.Errorf("%s: expected %s %s, got %v", test.name, test.path, test.message, err)
+		}
+	}
+}
+
+func TestEventFlowValidateRoot(t *testing.T) {
+	route := EventFlowRouteEvent("Tickets", 42).Node()
+	tests := []struct {
+		name     string
+		branches []EventFlowBranch
+		path     string
+		message  string
+	}{
+		{"no branch", []EventFlowBranch{}, "root.branches", "must contain exactly one ACCEPTED branch"},
+		{"two branches", []EventFlowBranch{{BranchType: EventFlowBranchType.Accepted, Target: route}, {BranchType: EventFlowBranchType.Accepted, Target: route}}, "root.branches", "must contain exactly one ACCEPTED branch"},
+		{"catch all", []EventFlowBranch{{BranchType: EventFlowBranchType.CatchAll, Target: route}}, "root.branches[0].branchType", "must be ACCEPTED"},
+	}
+	for _, test := range tests {
+		flow := &EventFlow{Name: "Routing", RootNode: &EventFlowNode{NodeType: EventFlowNodeType.Root, Branches: test.branches}}
+		err := flow.Validate()
+		var errs FlowValidationErrors
+		if !errors.As(err, &errs) {
+			t.Errorf("%s: expected validation errors, got %v", test.name, err)
+			continue
+		}
+		found := false
+		for _, e := range errs {
+			found = found || (e.Path == test.path && e.Message == test.message)
+		}
+		if !found {
+			t.Errorf("%s: expected %s %s, got %v", test.name, test.path, test.message, err)
+		}
+	}
+}
+
+func TestEventFlowValidateSharedNode(t *testing.T) {
+	route := EventFlowRouteEvent("Tickets", 42)
+
+	flow, err := NewEventFlowBuilder("Routing").
+		Start(EventFlowDefineBranches("Severity").
+			Define("critical", "event.priority == 'HIGH'", route).
+			CatchAll(route)).
+		Build()
+
+	if err != nil || flow == nil {
+		t.Errorf("expected a node reached by two branches to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,533 @@
+package ilert
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// flowCondition is a parsed branch condition e.g. event.priority == 'HIGH' && event.summary contains 'db'.
+//
+// The grammar is the one of the local evaluator, it covers common branch conditions but is not the parser of the
+// iLert API. Conditions outside of it fail to parse, the evaluation then fails instead of guessing a branch:
+//
+//	or         = and { ( "||" | "or" ) and }
+//	and        = not { ( "&&" | "and" ) not }
+//	not        = ( "!" | "not" ) not | comparison
+//	comparison = primary [ operator primary ]
+//	operator   = "==" | "!=" | "<" | "<=" | ">" | ">=" | "contains" | "startsWith" | "endsWith" | "matches" | "in"
+//	primary    = string | number | "true" | "false" | "null" | list | "(" or ")" | path
+//	list       = "[" [ or { "," or } ] "]"
+//	path       = ident { "." ident | "[" ( string | number ) "]" }
+//	string     = "'" { char } "'" | '"' { char } '"'   a backslash escapes the next char
+//	number     = [ "-" ] digit { digit | "." }
+//
+// Comparisons bind tighter than !, which binds tighter than && and ||. Paths not starting with "event" or "var"
+// are looked up in the event. == and != compare numbers numerically and other values by their string form,
+// ordering operators compare numbers numerically and other values as strings and are false for null.
+// contains tests lists for an item, objects for a key and strings for a substring, in requires a list on the right.
+type flowCondition interface {
+	eval(scope map[string]interface{}) (interface{}, error)
+}
+
+func parseFlowCondition(value string) (flowCondition, error) {
+	tokens, err := tokenizeFlowCondition(value)
+	if err != nil {
+		return nil, err
+	}
+	p := &flowConditionParser{tokens: tokens}
+	condition, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].value)
+	}
+	return condition, nil
+}
+
+// matchFlowCondition parses and evaluates a condition, the result is converted with truthy
+func matchFlowCondition(value string, scope map[string]interface{}) (bool, error) {
+	condition, err := parseFlowCondition(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition %q: %w", value, err)
+	}
+	result, err := condition.eval(scope)
+	if err != nil {
+		return false, fmt.Errorf("condition %q: %w", value, err)
+	}
+	return truthy(result), nil
+}
+
+type flowToken struct {
+	kind  string // ident, string, number, op
+	value string
+}
+
+func tokenizeFlowCondition(value string) ([]flowToken, error) {
+	tokens := make([]flowToken, 0)
+	runes := []rune(value)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			b := &strings.Builder{}
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				b.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, flowToken{kind: "string", value: b.String()})
+			i = j + 1
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, flowToken{kind: "number", value: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '-') {
+				j++
+			}
+			tokens = append(tokens, flowToken{kind: "ident", value: string(runes[i:j])})
+			i = j
+		default:
+			if i+1 < len(runes) {
+				if op := string(runes[i : i+2]); op == "==" || op == "!=" || op == "<=" || op == ">=" || op == "&&" || op == "||" {
+					tokens = append(tokens, flowToken{kind: "op", value: op})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("<>!()[],.", r) {
+				return nil, fmt.Errorf("unexpected %q at %d", r, i)
+			}
+			tokens = append(tokens, flowToken{kind: "op", value: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+type flowConditionParser struct {
+	tokens []flowToken
+	pos    int
+}
+
+func (p *flowConditionParser) peek() *flowToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+// accept consumes the next token if it is one of the given operators or keywords
+func (p *flowConditionParser) accept(values ...string) string {
+	t := p.peek()
+	if t == nil || t.kind == "string" || t.kind == "number" {
+		return ""
+	}
+	for _, value := range values {
+		if t.value == value {
+			p.pos++
+			return value
+		}
+	}
+	return ""
+}
+
+func (p *flowConditionParser) expect(value string) error {
+	if p.accept(value) == "" {
+		return fmt.Errorf("expected %q", value)
+	}
+	return nil
+}
+
+func (p *flowConditionParser) or() (flowCondition, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||", "or") != "" {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &flowLogical{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *flowConditionParser) and() (flowCondition, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&", "and") != "" {
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = &flowLogical{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *flowConditionParser) not() (flowCondition, error) {
+	if p.accept("!", "not") != "" {
+		operand, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &flowNot{operand: operand}, nil
+	}
+	return p.comparison()
+}
+
+func (p *flowConditionParser) comparison() (flowCondition, error) {
+	left, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	op := p.accept("==", "!=", "<=", ">=", "<", ">", "contains", "startsWith", "endsWith", "matches", "in")
+	if op == "" {
+		return left, nil
+	}
+	right, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if op == "matches" {
+		if literal, ok := right.(*flowLiteral); ok {
+			pattern, err := regexp.Compile(fmt.Sprint(literal.value))
+			if err != nil {
+				return nil, err
+			}
+			return &flowComparison{op: op, left: left, right: right, pattern: pattern}, nil
+		}
+	}
+	return &flowComparison{op: op, left: left, right: right}, nil
+}
+
+func (p *flowConditionParser) primary() (flowCondition, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+	switch {
+	case t.kind == "string":
+		p.pos++
+		return &flowLiteral{value: t.value}, nil
+	case t.kind == "number":
+		p.pos++
+		n, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", t.value)
+		}
+		return &flowLiteral{value: n}, nil
+	case t.kind == "op" && t.value == "(":
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	case t.kind == "op" && t.value == "[":
+		p.pos++
+		list := &flowList{}
+		if p.accept("]") != "" {
+			return list, nil
+		}
+		for {
+			item, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			list.items = append(list.items, item)
+			if p.accept(",") == "" {
+				break
+			}
+		}
+		return list, p.expect("]")
+	case t.kind == "ident":
+		p.pos++
+		switch t.value {
+		case "true":
+			return &flowLiteral{value: true}, nil
+		case "false":
+			return &flowLiteral{value: false}, nil
+		case "null":
+			return &flowLiteral{value: nil}, nil
+		}
+		path := &flowPath{segments: []string{t.value}}
+		for {
+			if p.accept(".") != "" {
+				next := p.peek()
+				if next == nil || next.kind != "ident" {
+					return nil, fmt.Errorf("expected field name after %q", strings.Join(path.segments, "."))
+				}
+				p.pos++
+				path.segments = append(path.segments, next.value)
+				continue
+			}
+			if p.accept("[") != "" {
+				next := p.peek()
+				if next == nil || (next.kind != "string" && next.kind != "number") {
+					return nil, fmt.Errorf("expected key after %q", strings.Join(path.segments, "."))
+				}
+				p.pos++
+				path.segments = append(path.segments, next.value)
+				if err := p.expect("]"); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return path, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q", t.value)
+}
+
+type flowLiteral struct {
+	value interface{}
+}
+
+func (l *flowLiteral) eval(map[string]interface{}) (interface{}, error) {
+	return l.value, nil
+}
+
+type flowList struct {
+	items []flowCondition
+}
+
+func (l *flowList) eval(scope map[string]interface{}) (interface{}, error) {
+	values := make([]interface{}, 0, len(l.items))
+	for _, item := range l.items {
+		value, err := item.eval(scope)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// flowPath looks up a value in the scope, paths not starting with a scope key are looked up in the event
+type flowPath struct {
+	segments []string
+}
+
+func (p *flowPath) eval(scope map[string]interface{}) (interface{}, error) {
+	segments := p.segments
+	var current interface{} = scope
+	if _, ok := scope[segments[0]]; !ok {
+		current = scope["event"]
+	}
+	return lookupPath(current, segments), nil
+}
+
+// lookupPath returns the value at the path, nil if it does not exist
+func lookupPath(current interface{}, segments []string) interface{} {
+	for _, segment := range segments {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			current = value[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil
+			}
+			current = value[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+type flowNot struct {
+	operand flowCondition
+}
+
+func (n *flowNot) eval(scope map[string]interface{}) (interface{}, error) {
+	value, err := n.operand.eval(scope)
+	if err != nil {
+		return nil, err
+	}
+	return !truthy(value), nil
+}
+
+type flowLogical struct {
+	op    string
+	left  flowCondition
+	right flowCondition
+}
+
+func (l *flowLogical) eval(scope map[string]interface{}) (interface{}, error) {
+	left, err := l.left.eval(scope)
+	if err != nil {
+		return nil, err
+	}
+	if l.op == "&&" && !truthy(left) {
+		return false, nil
+	}
+	if l.op == "||" && truthy(left) {
+		return true, nil
+	}
+	right, err := l.right.eval(scope)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(right), nil
+}
+
+type flowComparison struct {
+	op      string
+	left    flowCondition
+	right   flowCondition
+	pattern *regexp.Regexp
+}
+
+func (c *flowComparison) eval(scope map[string]interface{}) (interface{}, error) {
+	left, err := c.left.eval(scope)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.right.eval(scope)
+	if err != nil {
+		return nil, err
+	}
+	switch c.op {
+	case "==":
+		return looseEqual(left, right), nil
+	case "!=":
+		return !looseEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		l, lok := toNumber(left)
+		r, rok := toNumber(right)
+		if !lok || !rok {
+			if left == nil || right == nil {
+				return false, nil
+			}
+			ls, rs := fmt.Sprint(left), fmt.Sprint(right)
+			return compareOrdered(strings.Compare(ls, rs), c.op), nil
+		}
+		switch {
+		case l < r:
+			return compareOrdered(-1, c.op), nil
+		case l > r:
+			return compareOrdered(1, c.op), nil
+		}
+		return compareOrdered(0, c.op), nil
+	case "contains":
+		switch value := left.(type) {
+		case []interface{}:
+			for _, item := range value {
+				if looseEqual(item, right) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			_, ok := value[fmt.Sprint(right)]
+			return ok, nil
+		case nil:
+			return false, nil
+		}
+		return strings.Contains(fmt.Sprint(left), fmt.Sprint(right)), nil
+	case "startsWith":
+		return left != nil && strings.HasPrefix(fmt.Sprint(left), fmt.Sprint(right)), nil
+	case "endsWith":
+		return left != nil && strings.HasSuffix(fmt.Sprint(left), fmt.Sprint(right)), nil
+	case "matches":
+		pattern := c.pattern
+		if pattern == nil {
+			pattern, err = regexp.Compile(fmt.Sprint(right))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return left != nil && pattern.MatchString(fmt.Sprint(left)), nil
+	case "in":
+		items, ok := right.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("in requires a list")
+		}
+		for _, item := range items {
+			if looseEqual(left, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", c.op)
+}
+
+func compareOrdered(cmp int, op string) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+// looseEqual compares numbers numerically and everything else by its string representation
+func looseEqual(a interface{}, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if an, ok := toNumber(a); ok {
+		if bn, ok := toNumber(b); ok {
+			return an == bn
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
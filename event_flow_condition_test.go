@@ -0,0 +1,124 @@
+package ilert
+
+import (
+	"strings"
+	"testing"
+)
+
+func flowConditionScope() map[string]interface{} {
+	return map[string]interface{}{
+		"event": map[string]interface{}{
+			"priority": "HIGH",
+			"summary":  "db down",
+			"alertKey": "db-1",
+			"customDetails": map[string]interface{}{
+				"env":   "prod",
+				"count": float64(3),
+				"tags":  []interface{}{"a", "b"},
+			},
+		},
+		"var": map[string]interface{}{
+			"team": "ops",
+		},
+	}
+}
+
+func TestMatchFlowCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		// precedence
+		{"true || false && false", true},
+		{"(true || false) && false", false},
+		{"!false && false", false},
+		{"!(false && false)", true},
+		{"not event.priority == 'LOW'", true},
+		{"event.priority == 'LOW' or event.priority == 'HIGH' and event.summary == 'x'", false},
+		{"event.priority == 'LOW' || event.priority == 'HIGH' && event.summary == 'db down'", true},
+
+		// quoting
+		{`event.summary == "db down"`, true},
+		{`event.summary == 'db down'`, true},
+		{`'it\'s' == "it's"`, true},
+		{`"say \"hi\"" contains '"hi"'`, true},
+		{`'a || b' == 'a || b'`, true},
+
+		// comparisons
+		{"event.priority != 'LOW'", true},
+		{"event.customDetails.count > 2", true},
+		{"event.customDetails.count <= 2", false},
+		{"event.customDetails.count == '3'", true},
+		{"event.customDetails.count >= -1.5", true},
+		{"event.summary < 'e'", true},
+		{"event.missing > 1", false},
+		{"event.missing == null", true},
+		{"event.summary != null", true},
+
+		// string and list operators
+		{"event.summary contains 'db'", true},
+		{"event.summary startsWith 'db'", true},
+		{"event.summary endsWith 'up'", false},
+		{"event.summary matches '^db\\\\s'", true},
+		{"event.summary matches '^db\\s'", false}, // backslash escapes s, the pattern is ^dbs
+		{"event.priority in ['LOW', 'HIGH']", true},
+		{"event.priority in []", false},
+		{"event.customDetails.tags contains 'b'", true},
+		{"event.customDetails contains 'env'", true},
+		{"event.missing contains 'x'", false},
+
+		// paths
+		{"priority == 'HIGH'", true},
+		{"event.customDetails['env'] == 'prod'", true},
+		{"event.customDetails.tags[1] == 'b'", true},
+		{"event.customDetails.tags[5] == null", true},
+		{"var.team == 'ops'", true},
+		{"alertKey == 'db-1'", true},
+
+		// truthiness
+		{"event.summary", true},
+		{"event.missing", false},
+		{"event.customDetails.tags", true},
+	}
+	for _, test := range tests {
+		got, err := matchFlowCondition(test.condition, flowConditionScope())
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.condition, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.condition, test.want, got)
+		}
+	}
+}
+
+func TestMatchFlowConditionErrors(t *testing.T) {
+	tests := []struct {
+		condition string
+		err       string
+	}{
+		{"event.priority is 'HIGH'", `unexpected "is"`},
+		{"event.priority === 'HIGH'", "unexpected '='"},
+		{"event.priority ==", "unexpected end of condition"},
+		{"event.summary == 'db", "unterminated string"},
+		{"(event.priority == 'HIGH'", `expected ")"`},
+		{"event.priority == 'HIGH')", `unexpected ")"`},
+		{"['a', 'b'", `expected "]"`},
+		{"event.", "expected field name"},
+		{"event.tags[x]", "expected key"},
+		{"event.priority # 'HIGH'", "unexpected '#'"},
+		{"event.summary matches '('", "missing closing )"},
+		{"event.priority in 'HIGH'", "in requires a list"},
+		{"", "unexpected end of condition"},
+	}
+	for _, test := range tests {
+		_, err := matchFlowCondition(test.condition, flowConditionScope())
+		if err == nil {
+			t.Errorf("%s: expected error", test.condition)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error containing %q, got %q", test.condition, test.err, err)
+		}
+	}
+}
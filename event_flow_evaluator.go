@@ -0,0 +1,592 @@
+package ilert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iLert/ilert-go/v3/internal/objects"
+)
+
+// EventFlowEvaluation describes how an event passes an event flow
+type EventFlowEvaluation struct {
+	// nodes passed, starting with the root node
+	Steps []EventFlowStep
+
+	// the event after all transformations
+	Event *Event
+
+	// whether the event reached a ROUTE_EVENT node
+	Routed             bool
+	AlertSourceID      *int64
+	EscalationPolicyID *int64
+
+	// the priority of the created alert, the overwritten priority or the event's priority
+	Priority string
+
+	// time the event left the flow, after all waits
+	Time time.Time
+}
+
+// EventFlowStep is a node passed by an event
+type EventFlowStep struct {
+	// path of the node e.g. root.branches[0].target
+	Path     string
+	NodeID   int64
+	Name     string
+	NodeType string
+
+	// time the event entered the node
+	Time time.Time
+
+	// the branch taken, its condition or branch type, empty if the flow ended at the node
+	Branch string
+
+	// what happened at the node e.g. "waited 5m0s" or "outside support hours"
+	Detail string
+}
+
+// String returns one line per step
+func (e *EventFlowEvaluation) String() string {
+	b := &strings.Builder{}
+	for _, step := range e.Steps {
+		name := step.Name
+		if name == "" {
+			name = step.NodeType
+		}
+		fmt.Fprintf(b, "%s %s (%s)", step.Time.Format(time.RFC3339), name, step.NodeType)
+		if step.Detail != "" {
+			fmt.Fprintf(b, ": %s", step.Detail)
+		}
+		if step.Branch != "" {
+			fmt.Fprintf(b, " -> %s", step.Branch)
+		}
+		b.WriteString("\n")
+	}
+	if e.Routed {
+		fmt.Fprintf(b, "routed to alert source %d", *e.AlertSourceID)
+		if e.EscalationPolicyID != nil {
+			fmt.Fprintf(b, " with escalation policy %d", *e.EscalationPolicyID)
+		}
+		if e.Priority != "" {
+			fmt.Fprintf(b, " and priority %s", e.Priority)
+		}
+		b.WriteString("\n")
+	} else {
+		b.WriteString("not routed\n")
+	}
+	return b.String()
+}
+
+// EventFlowEvaluator evaluates event flows locally, e.g. to test flow changes without sending events
+type EventFlowEvaluator struct {
+	options *EventFlowEvaluatorOptions
+}
+
+// EventFlowEvaluatorOptions describes the time and support hours used to evaluate event flows
+type EventFlowEvaluatorOptions struct {
+	// returns the time the event is received
+	// Default: time.Now
+	Clock func() time.Time
+
+	// support hours per id used by SUPPORT_HOURS and WAIT nodes
+	SupportHours map[int64]*SupportHour
+}
+
+// EventFlowEvaluatorOption allows for options to be passed into NewEventFlowEvaluator for customization
+type EventFlowEvaluatorOption func(*EventFlowEvaluatorOptions)
+
+// WithEvaluatorClock sets the clock returning the time the event is received
+func WithEvaluatorClock(clock func() time.Time) EventFlowEvaluatorOption {
+	return func(o *EventFlowEvaluatorOptions) {
+		o.Clock = clock
+	}
+}
+
+// WithEvaluatorSupportHours adds support hour definitions referenced by SUPPORT_HOURS and WAIT nodes
+func WithEvaluatorSupportHours(supportHours ...*SupportHour) EventFlowEvaluatorOption {
+	return func(o *EventFlowEvaluatorOptions) {
+		for _, supportHour := range supportHours {
+			if supportHour != nil {
+				o.SupportHours[supportHour.ID] = supportHour
+			}
+		}
+	}
+}
+
+// NewEventFlowEvaluator creates a new event flow evaluator
+func NewEventFlowEvaluator(options ...EventFlowEvaluatorOption) *EventFlowEvaluator {
+	opts := &EventFlowEvaluatorOptions{
+		Clock:        time.Now,
+		SupportHours: make(map[int64]*SupportHour),
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &EventFlowEvaluator{options: opts}
+}
+
+// maxEventFlowSteps guards against cyclic flows
+const maxEventFlowSteps = 1000
+
+// Evaluate passes the event through the flow and reports the path taken and where the event is routed.
+// Branch conditions are evaluated against the event as "event", e.g. event.priority == 'HIGH', and PLAIN node
+// variables as "var", WAIT nodes advance the time. The event is not modified.
+func (e *EventFlowEvaluator) Evaluate(flow *EventFlowOutput, event *Event) (*EventFlowEvaluation, error) {
+	if flow == nil || flow.RootNode == nil {
+		return nil, errors.New("event flow with root node is required")
+	}
+	if event == nil {
+		return nil, errors.New("event is required")
+	}
+	document, err := eventDocument(event)
+	if err != nil {
+		return nil, err
+	}
+	scope := map[string]interface{}{
+		"event": document,
+		"var":   map[string]interface{}{},
+	}
+
+	evaluation := &EventFlowEvaluation{Steps: make([]EventFlowStep, 0), Time: e.options.Clock()}
+	root := &EventFlowNode{
+		ID:       flow.RootNode.ID,
+		Name:     flow.RootNode.Name,
+		NodeType: flow.RootNode.NodeType,
+		Branches: flow.RootNode.Branches,
+	}
+	node, path := root, "root"
+	for node != nil {
+		if len(evaluation.Steps) >= maxEventFlowSteps {
+			return nil, fmt.Errorf("%s: more than %d steps", path, maxEventFlowSteps)
+		}
+		step := EventFlowStep{Path: path, NodeID: node.ID, Name: node.Name, NodeType: node.NodeType, Time: evaluation.Time}
+		branch, err := e.evaluateNode(node, scope, evaluation, &step)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if branch >= 0 {
+			taken := node.Branches[branch]
+			step.Branch = taken.BranchType
+			if taken.BranchType == EventFlowBranchType.Branch {
+				step.Branch = taken.Condition
+			}
+			node, path = taken.Target, fmt.Sprintf("%s.branches[%d].target", path, branch)
+		} else {
+			node = nil
+		}
+		evaluation.Steps = append(evaluation.Steps, step)
+	}
+
+	evaluation.Event = &Event{}
+	if err := remarshalJSON(scope["event"], evaluation.Event); err != nil {
+		return nil, fmt.Errorf("invalid transformed event: %w", err)
+	}
+	if evaluation.Routed && evaluation.Priority == "" {
+		evaluation.Priority = evaluation.Event.Priority
+	}
+	return evaluation, nil
+}
+
+// evaluateNode applies the node and returns the index of the branch taken, -1 if the flow ends
+func (e *EventFlowEvaluator) evaluateNode(node *EventFlowNode, scope map[string]interface{}, evaluation *EventFlowEvaluation, step *EventFlowStep) (int, error) {
+	metadata, err := eventFlowNodeMetadata(node.Metadata)
+	if err != nil {
+		return -1, err
+	}
+	switch node.NodeType {
+	case EventFlowNodeType.Root:
+		return branchOfType(node, EventFlowBranchType.Accepted), nil
+	case EventFlowNodeType.Plain:
+		if metadata.VarKey != "" {
+			scope["var"].(map[string]interface{})[metadata.VarKey] = metadata.VarValue
+		}
+		return matchBranches(node, scope)
+	case EventFlowNodeType.DefineBranches:
+		for _, definition := range metadata.Definitions {
+			matched, err := matchFlowCondition(definition.Conditions, scope)
+			if err != nil {
+				return -1, fmt.Errorf("definition %q: %w", definition.BranchName, err)
+			}
+			if !matched {
+				continue
+			}
+			step.Detail = fmt.Sprintf("matched %q", definition.BranchName)
+			for i, branch := range node.Branches {
+				if branch.BranchType == EventFlowBranchType.Branch && branch.Condition == definition.BranchName {
+					return i, nil
+				}
+			}
+			return -1, fmt.Errorf("no branch for definition %q", definition.BranchName)
+		}
+		return branchOfType(node, EventFlowBranchType.CatchAll), nil
+	case EventFlowNodeType.SupportHours:
+		if metadata.SupportHoursID == nil {
+			return -1, errors.New("support hours id is required")
+		}
+		supportHour, err := e.supportHour(*metadata.SupportHoursID)
+		if err != nil {
+			return -1, err
+		}
+		during, err := duringSupportHours(supportHour, evaluation.Time)
+		if err != nil {
+			return -1, err
+		}
+		status := SupportStatus.Outside
+		step.Detail = fmt.Sprintf("outside support hours %s", supportHour.Name)
+		if during {
+			status = SupportStatus.During
+			step.Detail = fmt.Sprintf("during support hours %s", supportHour.Name)
+		}
+		for i, branch := range node.Branches {
+			if branch.BranchType == EventFlowBranchType.Branch && branch.Condition == status {
+				return i, nil
+			}
+		}
+		return branchOfType(node, EventFlowBranchType.CatchAll), nil
+	case EventFlowNodeType.Wait:
+		until := evaluation.Time
+		switch {
+		case metadata.WaitForDuration != "":
+			duration, err := parseScheduleDuration(metadata.WaitForDuration)
+			if err != nil {
+				return -1, fmt.Errorf("invalid wait duration %q", metadata.WaitForDuration)
+			}
+			until = duration.add(evaluation.Time, 1)
+		case metadata.WaitStartSupportHoursID != nil || metadata.WaitEndSupportHoursID != nil:
+			id, during := metadata.WaitStartSupportHoursID, true
+			if id == nil {
+				id, during = metadata.WaitEndSupportHoursID, false
+			}
+			supportHour, err := e.supportHour(*id)
+			if err != nil {
+				return -1, err
+			}
+			until, err = nextSupportHoursStatus(supportHour, evaluation.Time, during)
+			if err != nil {
+				return -1, err
+			}
+		}
+		step.Detail = fmt.Sprintf("waited %s", until.Sub(evaluation.Time))
+		evaluation.Time = until
+		return firstBranch(node), nil
+	case EventFlowNodeType.Transform:
+		if metadata.Condition != "" {
+			matched, err := matchFlowCondition(metadata.Condition, scope)
+			if err != nil {
+				return -1, err
+			}
+			if !matched {
+				step.Detail = "condition not matched"
+				return firstBranch(node), nil
+			}
+		}
+		applied := make([]string, 0, len(metadata.Rules))
+		for _, rule := range metadata.Rules {
+			if err := applyEventFlowRule(rule, scope); err != nil {
+				return -1, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			applied = append(applied, fmt.Sprintf("%s %s", strings.ToLower(rule.Operator), rule.Target))
+		}
+		step.Detail = strings.Join(applied, ", ")
+		return firstBranch(node), nil
+	case EventFlowNodeType.RouteEvent:
+		if metadata.AlertSourceID == nil {
+			return -1, errors.New("alert source id is required")
+		}
+		evaluation.Routed = true
+		evaluation.AlertSourceID = metadata.AlertSourceID
+		evaluation.EscalationPolicyID = metadata.EscalationPolicyID
+		evaluation.Priority = metadata.OverwritePriority
+		step.Detail = fmt.Sprintf("alert source %d", *metadata.AlertSourceID)
+		return -1, nil
+	}
+	return -1, fmt.Errorf("unknown node type %q", node.NodeType)
+}
+
+func (e *EventFlowEvaluator) supportHour(id int64) (*SupportHour, error) {
+	supportHour, ok := e.options.SupportHours[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown support hours %d, add it with WithEvaluatorSupportHours", id)
+	}
+	return supportHour, nil
+}
+
+// matchBranches returns the first branch whose condition matches, else the CATCH_ALL branch
+func matchBranches(node *EventFlowNode, scope map[string]interface{}) (int, error) {
+	for i, branch := range node.Branches {
+		if branch.BranchType != EventFlowBranchType.Branch {
+			continue
+		}
+		matched, err := matchFlowCondition(branch.Condition, scope)
+		if err != nil {
+			return -1, err
+		}
+		if matched {
+			return i, nil
+		}
+	}
+	return branchOfType(node, EventFlowBranchType.CatchAll), nil
+}
+
+func branchOfType(node *EventFlowNode, branchType string) int {
+	for i, branch := range node.Branches {
+		if branch.BranchType == branchType {
+			return i
+		}
+	}
+	return -1
+}
+
+func firstBranch(node *EventFlowNode) int {
+	if len(node.Branches) == 0 {
+		return -1
+	}
+	return 0
+}
+
+// applyEventFlowRule applies a TRANSFORM rule to the event in scope
+func applyEventFlowRule(rule EventFlowNodeRuleMetadata, scope map[string]interface{}) error {
+	event := scope["event"].(map[string]interface{})
+	target := strings.Split(strings.TrimPrefix(rule.Target, "event."), ".")
+	source := func() []string {
+		segments := strings.Split(rule.Source, ".")
+		if _, ok := scope[segments[0]]; ok {
+			return segments
+		}
+		return append([]string{"event"}, segments...)
+	}
+	switch rule.Operator {
+	case EventFlowNodeRuleOperator.Set:
+		return setPath(event, target, rule.Value)
+	case EventFlowNodeRuleOperator.Copy:
+		return setPath(event, target, objects.DeepCopy(lookupPath(scope, source())))
+	case EventFlowNodeRuleOperator.Map:
+		value := lookupPath(scope, source())
+		if value != nil {
+			if mapped, ok := rule.Mapping[fmt.Sprint(value)]; ok && mapped != nil {
+				return setPath(event, target, *mapped)
+			}
+		}
+		if rule.Default != nil {
+			return setPath(event, target, rule.Default)
+		}
+		return nil
+	case EventFlowNodeRuleOperator.Template:
+		return setPath(event, target, renderFlowTemplate(fmt.Sprint(rule.Value), scope))
+	case EventFlowNodeRuleOperator.Merge:
+		current, _ := lookupPath(event, target).(map[string]interface{})
+		merged := make(map[string]interface{}, len(current)+len(rule.Properties))
+		for key, value := range current {
+			merged[key] = value
+		}
+		for key, value := range rule.Properties {
+			if value == nil {
+				delete(merged, key)
+				continue
+			}
+			merged[key] = *value
+		}
+		return setPath(event, target, merged)
+	case EventFlowNodeRuleOperator.AppendArray:
+		current, _ := lookupPath(event, target).([]interface{})
+		items := append([]interface{}{}, current...)
+		for _, item := range rule.Items {
+			object := make(map[string]interface{}, len(item))
+			for key, value := range item {
+				if value != nil {
+					object[key] = *value
+				}
+			}
+			items = append(items, object)
+		}
+		return setPath(event, target, items)
+	}
+	return fmt.Errorf("unknown operator %q", rule.Operator)
+}
+
+// renderFlowTemplate replaces {{ path }} placeholders with values of the scope
+func renderFlowTemplate(template string, scope map[string]interface{}) string {
+	b := &strings.Builder{}
+	for {
+		start := strings.Index(template, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(template[start:], "}}")
+		if end < 0 {
+			break
+		}
+		b.WriteString(template[:start])
+		path := &flowPath{segments: strings.Split(strings.TrimSpace(template[start+2:start+end]), ".")}
+		if value, _ := path.eval(scope); value != nil {
+			b.WriteString(fmt.Sprint(value))
+		}
+		template = template[start+end+2:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+func setPath(document map[string]interface{}, segments []string, value interface{}) error {
+	current := document
+	for i, segment := range segments {
+		if segment == "" {
+			return errors.New("invalid target")
+		}
+		if i == len(segments)-1 {
+			current[segment] = value
+			return nil
+		}
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			if current[segment] != nil {
+				return fmt.Errorf("%s is not an object", strings.Join(segments[:i+1], "."))
+			}
+			next = make(map[string]interface{})
+			current[segment] = next
+		}
+		current = next
+	}
+	return nil
+}
+
+func eventDocument(event *Event) (map[string]interface{}, error) {
+	document := make(map[string]interface{})
+	if err := remarshalJSON(event, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func remarshalJSON(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// duringSupportHours reports whether t is within the support hours, exceptions take precedence over support days
+func duringSupportHours(supportHour *SupportHour, t time.Time) (bool, error) {
+	loc, err := supportHourLocation(supportHour)
+	if err != nil {
+		return false, err
+	}
+	for i, exception := range supportHour.Exceptions {
+		start, err := ParseScheduleTime(exception.Start, loc)
+		if err != nil {
+			return false, fmt.Errorf("invalid exception #%d: %w", i+1, err)
+		}
+		end, err := ParseScheduleTime(exception.End, loc)
+		if err != nil {
+			return false, fmt.Errorf("invalid exception #%d: %w", i+1, err)
+		}
+		if !start.After(t) && end.After(t) {
+			return exception.SupportStatus == SupportStatus.During, nil
+		}
+	}
+	local := t.In(loc)
+	for offset := -1; offset <= 0; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		start, end, ok, err := supportDayInterval(supportHour.SupportDays, day)
+		if err != nil {
+			return false, err
+		}
+		if ok && !start.After(t) && end.After(t) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// nextSupportHoursStatus returns the first time not before t that is during, or outside of, the support hours
+func nextSupportHoursStatus(supportHour *SupportHour, t time.Time, during bool) (time.Time, error) {
+	if current, err := duringSupportHours(supportHour, t); err != nil || current == during {
+		return t, err
+	}
+	loc, err := supportHourLocation(supportHour)
+	if err != nil {
+		return time.Time{}, err
+	}
+	bounds := make([]time.Time, 0)
+	local := t.In(loc)
+	for offset := -1; offset <= 370; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		start, end, ok, err := supportDayInterval(supportHour.SupportDays, day)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if ok {
+			bounds = append(bounds, start, end)
+		}
+	}
+	for _, exception := range supportHour.Exceptions {
+		for _, value := range []string{exception.Start, exception.End} {
+			if bound, err := ParseScheduleTime(value, loc); err == nil {
+				bounds = append(bounds, bound)
+			}
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+	for _, bound := range bounds {
+		if !bound.After(t) {
+			continue
+		}
+		current, err := duringSupportHours(supportHour, bound)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if current == during {
+			return bound, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("support hours %s do not change within a year", supportHour.Name)
+}
+
+// supportDayInterval returns the support interval starting on day, support days ending before they start end on the next day
+func supportDayInterval(days *SupportDays, day time.Time) (time.Time, time.Time, bool, error) {
+	if days == nil {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	supportDay := map[time.Weekday]*SupportDay{
+		time.Monday:    days.MONDAY,
+		time.Tuesday:   days.TUESDAY,
+		time.Wednesday: days.WEDNESDAY,
+		time.Thursday:  days.THURSDAY,
+		time.Friday:    days.FRIDAY,
+		time.Saturday:  days.SATURDAY,
+		time.Sunday:    days.SUNDAY,
+	}[day.Weekday()]
+	if supportDay == nil {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	start, err := parseTimeOfDay(supportDay.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid support day %s: %w", day.Weekday(), err)
+	}
+	end, err := parseTimeOfDay(supportDay.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid support day %s: %w", day.Weekday(), err)
+	}
+	from := atMinute(day, 0, start, day.Location())
+	until := atMinute(day, 0, end, day.Location())
+	if !until.After(from) {
+		until = atMinute(day, 1, end, day.Location())
+	}
+	return from, until, true, nil
+}
+
+func supportHourLocation(supportHour *SupportHour) (*time.Location, error) {
+	if supportHour.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(supportHour.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", supportHour.Timezone, err)
+	}
+	return loc, nil
+}
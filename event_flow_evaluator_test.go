@@ -0,0 +1,182 @@
+package ilert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestApplyEventFlowRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   EventFlowNodeRuleMetadata
+		target string
+		want   interface{}
+	}{
+		{
+			name:   "set",
+			rule:   EventFlowNodeRuleMetadata{Operator: "SET", Target: "event.priority", Value: "LOW"},
+			target: "priority",
+			want:   "LOW",
+		},
+		{
+			name:   "set nested",
+			rule:   EventFlowNodeRuleMetadata{Operator: "SET", Target: "customDetails.owner.team", Value: "ops"},
+			target: "customDetails.owner.team",
+			want:   "ops",
+		},
+		{
+			name:   "copy",
+			rule:   EventFlowNodeRuleMetadata{Operator: "COPY", Target: "details", Source: "customDetails.env"},
+			target: "details",
+			want:   "prod",
+		},
+		{
+			name:   "copy var",
+			rule:   EventFlowNodeRuleMetadata{Operator: "COPY", Target: "details", Source: "var.team"},
+			target: "details",
+			want:   "ops",
+		},
+		{
+			name:   "map",
+			rule:   EventFlowNodeRuleMetadata{Operator: "MAP", Target: "priority", Source: "customDetails.env", Mapping: map[string]*string{"prod": stringPtr("HIGH")}, Default: "LOW"},
+			target: "priority",
+			want:   "HIGH",
+		},
+		{
+			name:   "map default",
+			rule:   EventFlowNodeRuleMetadata{Operator: "MAP", Target: "priority", Source: "customDetails.env", Mapping: map[string]*string{"dev": stringPtr("HIGH")}, Default: "LOW"},
+			target: "priority",
+			want:   "LOW",
+		},
+		{
+			name:   "map without default",
+			rule:   EventFlowNodeRuleMetadata{Operator: "MAP", Target: "priority", Source: "customDetails.env", Mapping: map[string]*string{"dev": stringPtr("LOW")}},
+			target: "priority",
+			want:   "HIGH",
+		},
+		{
+			name:   "template",
+			rule:   EventFlowNodeRuleMetadata{Operator: "TEMPLATE", Target: "summary", Value: "[{{ customDetails.env }}] {{event.summary}}{{ missing }}"},
+			target: "summary",
+			want:   "[prod] db down",
+		},
+		{
+			name:   "merge",
+			rule:   EventFlowNodeRuleMetadata{Operator: "MERGE", Target: "customDetails", Properties: map[string]*string{"region": stringPtr("eu"), "env": nil}},
+			target: "customDetails",
+			want:   map[string]interface{}{"region": "eu", "count": float64(3), "tags": []interface{}{"a", "b"}},
+		},
+		{
+			name:   "append array",
+			rule:   EventFlowNodeRuleMetadata{Operator: "APPEND_ARRAY", Target: "links", Items: []map[string]*string{{"href": stringPtr("https://example.com"), "text": nil}}},
+			target: "links",
+			want:   []interface{}{map[string]interface{}{"href": "https://example.com"}},
+		},
+	}
+	for _, test := range tests {
+		scope := flowConditionScope()
+		if err := applyEventFlowRule(test.rule, scope); err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		got := lookupPath(scope["event"], strings.Split(test.target, "."))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: expected %#v, got %#v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestApplyEventFlowRuleCopiesObjects(t *testing.T) {
+	scope := flowConditionScope()
+	rule := EventFlowNodeRuleMetadata{Operator: "COPY", Target: "details", Source: "customDetails"}
+	if err := applyEventFlowRule(rule, scope); err != nil {
+		t.Fatal(err)
+	}
+
+	copied := lookupPath(scope["event"], []string{"details", "tags"}).([]interface{})
+	copied[0] = "changed"
+	if err := applyEventFlowRule(EventFlowNodeRuleMetadata{Operator: "SET", Target: "details.env", Value: "dev"}, scope); err != nil {
+		t.Fatal(err)
+	}
+
+	if source := flowConditionScope()["event"]; !reflect.DeepEqual(lookupPath(scope["event"], []string{"customDetails"}), lookupPath(source, []string{"customDetails"})) {
+		t.Errorf("expected changes of the copy not to change the source, got %#v", lookupPath(scope["event"], []string{"customDetails"}))
+	}
+}
+
+func TestApplyEventFlowRuleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rule EventFlowNodeRuleMetadata
+		err  string
+	}{
+		{"unknown operator", EventFlowNodeRuleMetadata{Operator: "DELETE", Target: "priority"}, `unknown operator "DELETE"`},
+		{"empty target", EventFlowNodeRuleMetadata{Operator: "SET", Target: "", Value: "x"}, "invalid target"},
+		{"target below a value", EventFlowNodeRuleMetadata{Operator: "SET", Target: "summary.text", Value: "x"}, "summary is not an object"},
+	}
+	for _, test := range tests {
+		err := applyEventFlowRule(test.rule, flowConditionScope())
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestEvaluateTransformAndBranches(t *testing.T) {
+	alertSourceID := int64(7)
+	route := &EventFlowNode{
+		NodeType: EventFlowNodeType.RouteEvent,
+		Metadata: &EventFlowNodeMetadata{AlertSourceID: &alertSourceID},
+	}
+	transform := &EventFlowNode{
+		Name:     "normalize",
+		NodeType: EventFlowNodeType.Transform,
+		Metadata: &EventFlowNodeMetadata{
+			Condition: "event.summary contains 'db'",
+			Rules: []EventFlowNodeRuleMetadata{
+				{Name: "team", Operator: "SET", Target: "customDetails.team", Value: "dba"},
+			},
+		},
+		Branches: []EventFlowBranch{{BranchType: EventFlowBranchType.CatchAll, Target: &EventFlowNode{
+			NodeType: EventFlowNodeType.Plain,
+			Branches: []EventFlowBranch{
+				{BranchType: EventFlowBranchType.Branch, Condition: "event.customDetails.team == 'dba'", Target: route},
+				{BranchType: EventFlowBranchType.CatchAll},
+			},
+		}}},
+	}
+	flow := &EventFlowOutput{RootNode: &EventFlowNodeOutput{
+		NodeType: EventFlowNodeType.Root,
+		Branches: []EventFlowBranch{{BranchType: EventFlowBranchType.Accepted, Target: transform}},
+	}}
+	evaluator := NewEventFlowEvaluator()
+
+	result, err := evaluator.Evaluate(flow, &Event{Summary: "db down", Priority: "HIGH"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Routed || *result.AlertSourceID != alertSourceID {
+		t.Errorf("expected the event to be routed to %d, got\n%s", alertSourceID, result)
+	}
+	if result.Event.CustomDetails["team"] != "dba" {
+		t.Errorf("expected transformed event, got %+v", result.Event.CustomDetails)
+	}
+
+	result, err = evaluator.Evaluate(flow, &Event{Summary: "disk full", Priority: "HIGH"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Routed || result.Steps[1].Detail != "condition not matched" {
+		t.Errorf("expected the transform to be skipped and the event not routed, got\n%s", result)
+	}
+
+	transform.Metadata.(*EventFlowNodeMetadata).Condition = "event.summary has 'db'"
+	if _, err := evaluator.Evaluate(flow, &Event{Summary: "db down"}); err == nil {
+		t.Error("expected an invalid condition to fail the evaluation")
+	}
+}
@@ -0,0 +1,609 @@
+package ilert
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrEventSenderClosed is returned when events are sent to a closed EventSender
+var ErrEventSenderClosed = errors.New("event sender is closed")
+
+// ErrEventQueueFull is returned when the queue of an EventSender without spool is full
+var ErrEventQueueFull = errors.New("event queue is full")
+
+// EventDelivery describes the outcome of an event handled by an EventSender
+type EventDelivery struct {
+	Event *Event
+
+	// response of the events API, nil if the event was not delivered
+	Output *CreateEventOutput
+
+	// last error, nil if the event was delivered
+	Err error
+
+	// number of CreateEvent calls made for the event
+	Attempts int
+
+	// whether the undelivered event was written to the spool for replay
+	Spooled bool
+}
+
+// EventSender queues events in memory and sends them with a pool of workers using CreateEvent.
+// Failed deliveries are retried with exponential backoff, events that could not be delivered are
+// optionally written to an on-disk spool (JSON lines) and replayed later, also by the next EventSender after a restart.
+type EventSender struct {
+	client           *Client
+	queueSize        int
+	workers          int
+	retryCount       int
+	retryWaitTime    time.Duration
+	retryMaxWaitTime time.Duration
+	spoolPath        string
+	onDelivery       func(delivery *EventDelivery)
+	deduplicator     *EventDeduplicator
+
+	queue  chan *queuedEvent
+	seq    uint64
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	spoolMu     sync.Mutex
+	spool       []*spooledEvent
+	spooled     map[uint64]*spooledEvent
+	spoolAcked  int // delivered events that are still in the spool file
+	spoolSignal chan struct{}
+
+	mu      sync.Mutex
+	closed  bool
+	pending int
+	idle    []chan struct{}
+}
+
+// queuedEvent is an event handed to the workers, seq identifies a single send so the same event may be sent twice
+type queuedEvent struct {
+	seq   uint64
+	event *Event
+}
+
+// spooledEvent is an event of the spool file that was not delivered yet
+type spooledEvent struct {
+	item    *queuedEvent
+	queued  bool
+	readyAt time.Time
+}
+
+// spoolCompactThreshold is the number of delivered events after which the spool file is rewritten without them
+const spoolCompactThreshold = 100
+
+// EventSenderOptions allows for options to be passed into the EventSender for customization
+type EventSenderOptions func(*EventSender)
+
+// WithEventSenderQueueSize sets the number of events held in memory
+// Default: 1000
+func WithEventSenderQueueSize(queueSize int) EventSenderOptions {
+	return func(s *EventSender) {
+		s.queueSize = queueSize
+	}
+}
+
+// WithEventSenderWorkers sets the number of events sent in parallel
+// Default: 4
+func WithEventSenderWorkers(workers int) EventSenderOptions {
+	return func(s *EventSender) {
+		s.workers = workers
+	}
+}
+
+// WithEventSenderRetry sets how often a failed delivery is retried and the backoff between attempts.
+// Only network errors, 5xx and 429 responses are retried, the Retry-After duration of the API takes precedence.
+// The retries configured on the client via WithRetry are not used for events sent by the EventSender.
+// Default: 5 retries, 1 second wait time, 30 seconds max wait time
+func WithEventSenderRetry(retryCount int, retryWaitTime time.Duration, retryMaxWaitTime time.Duration) EventSenderOptions {
+	return func(s *EventSender) {
+		s.retryCount = retryCount
+		s.retryWaitTime = retryWaitTime
+		s.retryMaxWaitTime = retryMaxWaitTime
+	}
+}
+
+// WithEventSenderSpool persists undelivered events to the given JSON lines file. Spooled events are queued again
+// once there is room in the queue, events that failed after all retries once the retry max wait time passed.
+// Events stay in the spool until they are delivered and are replayed by the next EventSender on start,
+// events delivered shortly before a crash may therefore be sent again.
+func WithEventSenderSpool(path string) EventSenderOptions {
+	return func(s *EventSender) {
+		s.spoolPath = path
+	}
+}
+
+// WithEventSenderDeliveryCallback sets a callback that is invoked for every delivered, failed or spooled event.
+// The callback is called from the worker goroutines and must be safe for concurrent use.
+func WithEventSenderDeliveryCallback(callback func(delivery *EventDelivery)) EventSenderOptions {
+	return func(s *EventSender) {
+		s.onDelivery = callback
+	}
+}
+
+// WithEventSenderDeduplicator sends events through the given deduplication layer instead of the client
+func WithEventSenderDeduplicator(deduplicator *EventDeduplicator) EventSenderOptions {
+	return func(s *EventSender) {
+		s.deduplicator = deduplicator
+	}
+}
+
+// NewEventSender creates and starts an EventSender, events found in the spool are queued for replay
+func NewEventSender(client *Client, options ...EventSenderOptions) (*EventSender, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	s := &EventSender{
+		client:           client,
+		queueSize:        1000,
+		workers:          4,
+		retryCount:       5,
+		retryWaitTime:    1 * time.Second,
+		retryMaxWaitTime: 30 * time.Second,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	if s.queueSize < 1 {
+		s.queueSize = 1
+	}
+	if s.workers < 1 {
+		s.workers = 1
+	}
+	if s.retryCount < 0 {
+		s.retryCount = 0
+	}
+
+	s.spooled = make(map[uint64]*spooledEvent)
+	s.spoolSignal = make(chan struct{}, 1)
+	if err := s.readSpool(); err != nil {
+		return nil, err
+	}
+
+	s.queue = make(chan *queuedEvent, s.queueSize)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+	if s.spoolPath != "" {
+		s.wg.Add(1)
+		go s.replay()
+	}
+
+	return s, nil
+}
+
+// Send queues an event without blocking. If the queue is full the event is written to the spool,
+// or ErrEventQueueFull is returned if no spool is configured.
+func (s *EventSender) Send(event *Event) error {
+	if event == nil {
+		return errors.New("event is required")
+	}
+	if err := s.acquire(); err != nil {
+		return err
+	}
+	item := s.newQueuedEvent(event)
+	select {
+	case s.queue <- item:
+		return nil
+	default:
+	}
+	s.release()
+	if s.spoolPath == "" {
+		return ErrEventQueueFull
+	}
+	return s.writeSpool(item, time.Time{})
+}
+
+// SendWithContext queues an event, blocking until there is room in the queue or the context is done
+func (s *EventSender) SendWithContext(ctx context.Context, event *Event) error {
+	if event == nil {
+		return errors.New("event is required")
+	}
+	if err := s.acquire(); err != nil {
+		return err
+	}
+	select {
+	case s.queue <- s.newQueuedEvent(event):
+		return nil
+	case <-ctx.Done():
+		s.release()
+		return ctx.Err()
+	case <-s.ctx.Done():
+		s.release()
+		return ErrEventSenderClosed
+	}
+}
+
+// Flush blocks until all queued events have been handled or the context is done,
+// events waiting in the spool are not waited for
+func (s *EventSender) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	if s.pending == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	idle := make(chan struct{})
+	s.idle = append(s.idle, idle)
+	s.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting events and flushes the queue. If the context is done before the queue is flushed,
+// in-flight deliveries are cancelled and remaining events are written to the spool if one is configured.
+func (s *EventSender) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	err := s.Flush(ctx)
+	s.cancel()
+	s.wg.Wait()
+
+	// all workers are gone, remaining events are drained here
+	for {
+		select {
+		case item := <-s.queue:
+			s.finish(item, nil, s.ctx.Err(), 0)
+		default:
+			if spoolErr := s.closeSpool(); spoolErr != nil {
+				return errors.Join(err, spoolErr)
+			}
+			return err
+		}
+	}
+}
+
+// newQueuedEvent numbers a send of the event
+func (s *EventSender) newQueuedEvent(event *Event) *queuedEvent {
+	return &queuedEvent{seq: atomic.AddUint64(&s.seq, 1), event: event}
+}
+
+// acquire registers an event that is about to be queued
+func (s *EventSender) acquire() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrEventSenderClosed
+	}
+	s.pending++
+	return nil
+}
+
+// release unregisters a handled or rejected event and notifies flushes once the sender is idle
+func (s *EventSender) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending--
+	if s.pending == 0 {
+		for _, idle := range s.idle {
+			close(idle)
+		}
+		s.idle = nil
+	}
+}
+
+func (s *EventSender) work() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case item := <-s.queue:
+			output, attempts, err := s.deliver(item.event)
+			s.finish(item, output, err, attempts)
+		}
+	}
+}
+
+// deliver sends an event, retrying retryable errors with exponential backoff
+func (s *EventSender) deliver(event *Event) (*CreateEventOutput, int, error) {
+	ctx := withoutRetries(s.ctx)
+	var err error
+	for attempt := 0; attempt <= s.retryCount; attempt++ {
+		var output *CreateEventOutput
+		if s.deduplicator != nil {
+			output, err = s.deduplicator.CreateEventWithContext(ctx, &CreateEventInput{Event: event})
+		} else {
+			output, err = s.client.CreateEventWithContext(ctx, &CreateEventInput{Event: event})
+		}
+		if err == nil {
+			return output, attempt + 1, nil
+		}
+		if !isRetryableError(err) || attempt == s.retryCount || s.ctx.Err() != nil {
+			return nil, attempt + 1, err
+		}
+
+		timer := time.NewTimer(s.backoff(err, attempt))
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			timer.Stop()
+			return nil, attempt + 1, s.ctx.Err()
+		}
+	}
+	return nil, s.retryCount + 1, err
+}
+
+// finish spools undelivered events that may succeed later, removes handled events from the spool and reports the delivery
+func (s *EventSender) finish(item *queuedEvent, output *CreateEventOutput, err error, attempts int) {
+	defer s.release()
+	delivery := &EventDelivery{Event: item.event, Output: output, Err: err, Attempts: attempts}
+	if s.spoolPath != "" {
+		retry := err != nil && (isRetryableError(err) || errors.Is(err, context.Canceled))
+		spooled, spoolErr := s.updateSpool(item, retry)
+		if spoolErr != nil {
+			delivery.Err = errors.Join(err, spoolErr)
+		}
+		delivery.Spooled = spooled
+	}
+	if s.onDelivery != nil {
+		s.onDelivery(delivery)
+	}
+}
+
+// backoff returns the capped exponential backoff with jitter for the given attempt
+func (s *EventSender) backoff(err error, attempt int) time.Duration {
+	var apiErr *RetryableAPIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	wait := s.retryWaitTime << uint(attempt)
+	if wait <= 0 || wait > s.retryMaxWaitTime {
+		wait = s.retryMaxWaitTime
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// replay queues spooled events once they are due, blocking until there is room in the queue
+func (s *EventSender) replay() {
+	defer s.wg.Done()
+	for {
+		entry, wait := s.nextSpooled()
+		if entry == nil {
+			timer := time.NewTimer(wait)
+			select {
+			case <-s.ctx.Done():
+				timer.Stop()
+				return
+			case <-s.spoolSignal:
+				timer.Stop()
+			case <-timer.C:
+			}
+			continue
+		}
+		if err := s.acquire(); err != nil {
+			s.unqueueSpooled(entry)
+			return
+		}
+		select {
+		case s.queue <- entry.item:
+		case <-s.ctx.Done():
+			s.release()
+			s.unqueueSpooled(entry)
+			return
+		}
+	}
+}
+
+// nextSpooled marks the next due spooled event as queued and returns it,
+// or returns the time until the next spooled event is due
+func (s *EventSender) nextSpooled() (*spooledEvent, time.Duration) {
+	s.spoolMu.Lock()
+	defer s.spoolMu.Unlock()
+	now := time.Now()
+	wait := time.Hour
+	for _, entry := range s.spool {
+		if entry.queued {
+			continue
+		}
+		if !entry.readyAt.After(now) {
+			entry.queued = true
+			return entry, 0
+		}
+		if d := entry.readyAt.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	return nil, wait
+}
+
+func (s *EventSender) unqueueSpooled(entry *spooledEvent) {
+	s.spoolMu.Lock()
+	defer s.spoolMu.Unlock()
+	entry.queued = false
+}
+
+// updateSpool keeps events that may succeed later in the spool and removes delivered or rejected events from it,
+// it reports whether the event is in the spool
+func (s *EventSender) updateSpool(item *queuedEvent, retry bool) (bool, error) {
+	s.spoolMu.Lock()
+	entry, ok := s.spooled[item.seq]
+	if !ok {
+		s.spoolMu.Unlock()
+		if !retry {
+			return false, nil
+		}
+		if err := s.writeSpool(item, time.Now().Add(s.spoolRetryDelay())); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	defer s.spoolMu.Unlock()
+	if retry {
+		entry.queued = false
+		entry.readyAt = time.Now().Add(s.spoolRetryDelay())
+		return true, nil
+	}
+
+	delete(s.spooled, item.seq)
+	for i, e := range s.spool {
+		if e == entry {
+			s.spool = append(s.spool[:i], s.spool[i+1:]...)
+			break
+		}
+	}
+	s.spoolAcked++
+	if s.spoolAcked >= spoolCompactThreshold || len(s.spool) == 0 {
+		return false, s.compactSpool()
+	}
+	return false, nil
+}
+
+// spoolRetryDelay returns how long spooled events that failed after all retries wait before they are queued again
+func (s *EventSender) spoolRetryDelay() time.Duration {
+	if s.retryMaxWaitTime < time.Second {
+		return time.Second
+	}
+	return s.retryMaxWaitTime
+}
+
+// writeSpool appends an event to the spool file, it is queued again once ready
+func (s *EventSender) writeSpool(item *queuedEvent, readyAt time.Time) error {
+	s.spoolMu.Lock()
+	defer s.spoolMu.Unlock()
+	line, err := json.Marshal(item.event)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.spoolPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	s.addSpooled(item, readyAt)
+	select {
+	case s.spoolSignal <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// addSpooled tracks an event of the spool file, the spool must be locked by the caller
+func (s *EventSender) addSpooled(item *queuedEvent, readyAt time.Time) {
+	entry := &spooledEvent{item: item, readyAt: readyAt}
+	s.spool = append(s.spool, entry)
+	s.spooled[item.seq] = entry
+}
+
+// compactSpool atomically rewrites the spool file with the events that were not delivered yet,
+// the spool must be locked by the caller
+func (s *EventSender) compactSpool() error {
+	s.spoolAcked = 0
+	if len(s.spool) == 0 {
+		if err := os.Remove(s.spoolPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	var content bytes.Buffer
+	for _, entry := range s.spool {
+		line, err := json.Marshal(entry.item.event)
+		if err != nil {
+			return err
+		}
+		content.Write(append(line, '\n'))
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.spoolPath), filepath.Base(s.spoolPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content.Bytes()); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0600); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), s.spoolPath)
+}
+
+// closeSpool removes delivered events from the spool file
+func (s *EventSender) closeSpool() error {
+	if s.spoolPath == "" {
+		return nil
+	}
+	s.spoolMu.Lock()
+	defer s.spoolMu.Unlock()
+	if s.spoolAcked == 0 {
+		return nil
+	}
+	return s.compactSpool()
+}
+
+// readSpool reads the events of the spool file for replay, undecodable lines are skipped.
+// The file is kept until the events are delivered.
+func (s *EventSender) readSpool() error {
+	if s.spoolPath == "" {
+		return nil
+	}
+	s.spoolMu.Lock()
+	defer s.spoolMu.Unlock()
+	content, err := os.ReadFile(s.spoolPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		event := &Event{}
+		if err := json.Unmarshal(line, event); err != nil {
+			continue
+		}
+		s.addSpooled(s.newQueuedEvent(event), time.Time{})
+	}
+	return scanner.Err()
+}
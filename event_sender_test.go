@@ -0,0 +1,296 @@
+package ilert_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+// deliveries collects the deliveries reported by an EventSender
+type deliveries struct {
+	mu    sync.Mutex
+	items []*ilert.EventDelivery
+}
+
+func (d *deliveries) add(delivery *ilert.EventDelivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items = append(d.items, delivery)
+}
+
+func (d *deliveries) all() []*ilert.EventDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*ilert.EventDelivery(nil), d.items...)
+}
+
+func alertEvent(alertKey string) *ilert.Event {
+	return &ilert.Event{APIKey: "key", EventType: ilert.EventTypes.Alert, Summary: "db down", AlertKey: alertKey}
+}
+
+func TestEventSenderRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		inject   func(srv *ilerttest.Server)
+		attempts int
+		failed   bool
+		minWait  time.Duration
+	}{
+		{
+			name:     "delivered",
+			inject:   func(srv *ilerttest.Server) {},
+			attempts: 1,
+		},
+		{
+			name: "rate limited",
+			inject: func(srv *ilerttest.Server) {
+				srv.InjectRateLimit("/api/events", time.Second, 1)
+			},
+			attempts: 2,
+			minWait:  time.Second,
+		},
+		{
+			name: "server error",
+			inject: func(srv *ilerttest.Server) {
+				srv.InjectError(http.MethodPost, "/api/events", http.StatusServiceUnavailable, "UNAVAILABLE", 2)
+			},
+			attempts: 3,
+		},
+		{
+			name: "server error after all retries",
+			inject: func(srv *ilerttest.Server) {
+				srv.InjectError(http.MethodPost, "/api/events", http.StatusInternalServerError, "ERROR", 0)
+			},
+			attempts: 4,
+			failed:   true,
+		},
+		{
+			name: "bad request",
+			inject: func(srv *ilerttest.Server) {
+				srv.InjectError(http.MethodPost, "/api/events", http.StatusBadRequest, "BAD_REQUEST", 0)
+			},
+			attempts: 1,
+			failed:   true,
+		},
+	}
+	for _, test := range tests {
+		srv := ilerttest.NewServer()
+		test.inject(srv)
+		d := &deliveries{}
+		sender, err := ilert.NewEventSender(srv.Client(),
+			ilert.WithEventSenderRetry(3, time.Millisecond, 5*time.Millisecond),
+			ilert.WithEventSenderDeliveryCallback(d.add))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Now()
+		if err := sender.Send(alertEvent("db-1")); err != nil {
+			t.Fatal(err)
+		}
+		if err := sender.Close(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		elapsed := time.Since(start)
+		srv.Close()
+
+		got := d.all()
+		if len(got) != 1 {
+			t.Errorf("%s: expected 1 delivery, got %d", test.name, len(got))
+			continue
+		}
+		if got[0].Attempts != test.attempts || (got[0].Err != nil) != test.failed || got[0].Spooled {
+			t.Errorf("%s: expected %d attempts and failed %v, got %+v", test.name, test.attempts, test.failed, got[0])
+		}
+		if elapsed < test.minWait {
+			t.Errorf("%s: expected to wait at least %s, waited %s", test.name, test.minWait, elapsed)
+		}
+		if n := countRequests(srv, http.MethodPost, "/api/events"); n != test.attempts {
+			t.Errorf("%s: expected %d requests, got %d", test.name, test.attempts, n)
+		}
+	}
+}
+
+func TestEventSenderRateLimitError(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	srv.InjectRateLimit("/api/events", time.Second, 1)
+
+	_, err := srv.Client().CreateEvent(&ilert.CreateEventInput{Event: alertEvent("db-1")})
+
+	var retryable *ilert.RetryableAPIError
+	if !errors.As(err, &retryable) || retryable.RetryAfter != time.Second {
+		t.Fatalf("expected a retryable error with Retry-After, got %#v", err)
+	}
+}
+
+// waitFor polls the condition until it holds or fails the test after a few seconds
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func spoolLines(t *testing.T, path string) int {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(content, []byte("\n"))
+}
+
+func TestEventSenderCloseDrainsQueue(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	d := &deliveries{}
+	sender, err := ilert.NewEventSender(srv.Client(), ilert.WithEventSenderWorkers(2), ilert.WithEventSenderDeliveryCallback(d.add))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		if err := sender.Send(alertEvent(fmt.Sprintf("db-%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := sender.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(srv.Events()); n != 20 {
+		t.Errorf("expected 20 delivered events, got %d", n)
+	}
+	for _, delivery := range d.all() {
+		if delivery.Err != nil {
+			t.Errorf("unexpected delivery error %v", delivery.Err)
+		}
+	}
+	if err := sender.Send(alertEvent("db-late")); !errors.Is(err, ilert.ErrEventSenderClosed) {
+		t.Errorf("expected a closed sender error, got %v", err)
+	}
+}
+
+func TestEventSenderCloseSpoolsUndeliveredEvents(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	srv.InjectError(http.MethodPost, "/api/events", http.StatusServiceUnavailable, "UNAVAILABLE", 0)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	d := &deliveries{}
+	sender, err := ilert.NewEventSender(srv.Client(),
+		ilert.WithEventSenderWorkers(1),
+		ilert.WithEventSenderRetry(5, time.Minute, time.Minute),
+		ilert.WithEventSenderSpool(path),
+		ilert.WithEventSenderDeliveryCallback(d.add))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := sender.Send(alertEvent(fmt.Sprintf("db-%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := sender.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the close to time out, got %v", err)
+	}
+
+	got := d.all()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 deliveries, got %d", len(got))
+	}
+	for _, delivery := range got {
+		if !delivery.Spooled || delivery.Err == nil {
+			t.Errorf("expected an undelivered spooled event, got %+v", delivery)
+		}
+	}
+	if n := spoolLines(t, path); n != 3 {
+		t.Errorf("expected 3 spooled events, got %d", n)
+	}
+}
+
+func TestEventSenderReplaysSpoolAfterRestart(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	srv.InjectError(http.MethodPost, "/api/events", http.StatusServiceUnavailable, "UNAVAILABLE", 0)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sender, err := ilert.NewEventSender(srv.Client(), ilert.WithEventSenderRetry(0, 0, 0), ilert.WithEventSenderSpool(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Send(alertEvent("db-1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := spoolLines(t, path); n != 1 {
+		t.Fatalf("expected 1 spooled event, got %d", n)
+	}
+
+	srv.Reset()
+	sender, err = ilert.NewEventSender(srv.Client(), ilert.WithEventSenderSpool(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the spooled event", func() bool { return len(srv.Events()) == 1 })
+	if err := sender.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if events := srv.Events(); events[0].AlertKey != "db-1" {
+		t.Errorf("unexpected replayed event %+v", events[0])
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the delivered spool to be removed, got %v", err)
+	}
+}
+
+func TestEventSenderSpoolsTheSameEventTwice(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	srv.InjectError(http.MethodPost, "/api/events", http.StatusServiceUnavailable, "UNAVAILABLE", 2)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sender, err := ilert.NewEventSender(srv.Client(),
+		ilert.WithEventSenderWorkers(1),
+		ilert.WithEventSenderRetry(0, 0, 0),
+		ilert.WithEventSenderSpool(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := alertEvent("db-1")
+	for i := 0; i < 2; i++ {
+		if err := sender.Send(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitFor(t, "both replayed events", func() bool { return len(srv.Events()) == 2 })
+	if err := sender.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := spoolLines(t, path); n != 0 {
+		t.Errorf("expected both sends to leave the spool, %d remain", n)
+	}
+}
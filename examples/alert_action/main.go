@@ -37,13 +37,9 @@ func main() {
 	log.Printf("New alert source is created:\n%+v\n", *ras.AlertSource)
 
 	rcr, err := client.CreateConnector(&ilert.CreateConnectorInput{
-		Connector: &ilert.Connector{
-			Name: "Test GitHub Connector",
-			Type: ilert.ConnectorTypes.Github,
-			Params: &ilert.ConnectorParamsGithub{
-				APIKey: "my api key",
-			},
-		},
+		Connector: ilert.NewConnector("Test GitHub Connector", &ilert.ConnectorParamsGithub{
+			APIKey: "my api key",
+		}),
 	})
 	if err != nil {
 		log.Println(rcr)
@@ -51,19 +47,15 @@ func main() {
 	}
 	log.Printf("New connector is created:\n%+v\n", *rcr.Connector)
 
+	alertAction := ilert.NewAlertAction("Test GitHub AlertAction", rcr.Connector.ID, &ilert.AlertActionParamsGithub{
+		Owner:      "my-org",
+		Repository: "my-repo",
+	})
+	alertAction.TriggerMode = ilert.AlertActionTriggerModes.Automatic
+	alertAction.TriggerTypes = ilert.AlertActionTriggerTypesAll
+	alertAction.AlertSources = &[]ilert.AlertSource{*ras.AlertSource}
 	rcn, err := client.CreateAlertAction(&ilert.CreateAlertActionInput{
-		AlertAction: &ilert.AlertAction{
-			Name:          "Test GitHub AlertAction",
-			ConnectorType: ilert.ConnectorTypes.Github,
-			ConnectorID:   rcr.Connector.ID,
-			TriggerMode:   ilert.AlertActionTriggerModes.Automatic,
-			TriggerTypes:  ilert.AlertActionTriggerTypesAll,
-			AlertSources:  &[]ilert.AlertSource{*ras.AlertSource},
-			Params: ilert.AlertActionParamsGithub{
-				Owner:      "my-org",
-				Repository: "my-repo",
-			},
-		},
+		AlertAction: alertAction,
 	})
 	if err != nil {
 		log.Println(rcn)
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+func main() {
+	var apiKey = "alert source API Key"
+	var apiToken = "your API token"
+	client := ilert.NewClient(ilert.WithAPIToken(apiToken))
+
+	sender, err := ilert.NewEventSender(client,
+		ilert.WithEventSenderWorkers(2),
+		ilert.WithEventSenderSpool("/var/lib/my-agent/ilert-events.jsonl"),
+		ilert.WithEventSenderDeliveryCallback(func(delivery *ilert.EventDelivery) {
+			if delivery.Err != nil {
+				log.Println("WARN:", delivery.Err, "spooled:", delivery.Spooled)
+				return
+			}
+			log.Println("Alert key:", delivery.Output.EventResponse.AlertKey)
+		}),
+	)
+	if err != nil {
+		log.Fatalln("ERROR:", err)
+	}
+
+	err = sender.Send(&ilert.Event{
+		APIKey:    apiKey,
+		EventType: ilert.EventTypes.Alert,
+		Summary:   "My test alert summary",
+		AlertKey:  "123456",
+	})
+	if err != nil {
+		log.Println("ERROR:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := sender.Close(ctx); err != nil {
+		log.Println("WARN: not all events were delivered:", err)
+	}
+}
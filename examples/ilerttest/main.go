@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func main() {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	result, err := client.CreateEvent(&ilert.CreateEventInput{Event: &ilert.Event{
+		APIKey:    "alert source API Key",
+		EventType: ilert.EventTypes.Alert,
+		Summary:   "My test alert summary",
+		AlertKey:  "123456",
+	}})
+	if err != nil {
+		log.Fatalln("ERROR:", err)
+	}
+	log.Println("Alert key:", result.EventResponse.AlertKey)
+
+	alerts, err := client.GetAlerts(&ilert.GetAlertsInput{})
+	if err != nil {
+		log.Fatalln("ERROR:", err)
+	}
+	log.Printf("Fake server holds %d alerts\n", len(alerts.Alerts))
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"log"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+func main() {
+	var apiToken = "your API token"
+	client := ilert.NewClient(ilert.WithAPIToken(apiToken))
+
+	count := 0
+	err := client.GetAlertsPages(&ilert.GetAlertsInput{
+		States: []*string{ilert.String(ilert.AlertStatuses.Pending)},
+	}, func(page *ilert.GetAlertsOutput) bool {
+		for _, alert := range page.Alerts {
+			log.Printf("%d: %s\n", alert.ID, alert.Summary)
+		}
+		count += len(page.Alerts)
+		return count < 1000
+	}, ilert.WithPageConcurrency(2))
+	if err != nil {
+		log.Fatalln("ERROR:", err)
+	}
+	log.Printf("Found %d pending alerts\n", count)
+}
@@ -0,0 +1,18 @@
+package ilert
+
+import "time"
+
+// SetRateLimitClock replaces the clock of the rate limiter, the client must use WithRateLimit
+func SetRateLimitClock(c *Client, now func() time.Time) {
+	c.rateLimiter.now = now
+}
+
+// SetDeduplicatorClock replaces the clock of the event deduplicator
+func SetDeduplicatorClock(d *EventDeduplicator, now func() time.Time) {
+	d.now = now
+}
+
+// HeartbeatNextInterval returns the jittered interval until the next ping
+func HeartbeatNextInterval(p *HeartbeatPinger) time.Duration {
+	return p.nextInterval()
+}
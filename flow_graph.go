@@ -0,0 +1,298 @@
+package ilert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FlowGraph is an event flow or call flow as nodes and edges, rendered with DOT or Mermaid
+type FlowGraph struct {
+	Name  string
+	Nodes []FlowGraphNode
+	Edges []FlowGraphEdge
+}
+
+// FlowGraphNode is a node of a flow graph
+type FlowGraphNode struct {
+	// stable id in depth-first order e.g. n0 for the root node, so renderings of unchanged flows do not differ
+	ID       string
+	NodeType string
+	Name     string
+
+	// key metadata e.g. targets, conditions or wait durations
+	Details []string
+}
+
+// FlowGraphEdge is a branch of a flow graph
+type FlowGraphEdge struct {
+	From string
+	To   string
+
+	// the branch condition, or its branch type if it has none
+	Label string
+}
+
+// flowGraphMaxText is the max length of texts such as messages and conditions
+const flowGraphMaxText = 60
+
+// EventFlowGraph returns the graph of an event flow
+func EventFlowGraph(flow *EventFlowOutput) (*FlowGraph, error) {
+	if flow == nil || flow.RootNode == nil {
+		return nil, errors.New("event flow with root node is required")
+	}
+	graph := &FlowGraph{Name: flow.Name, Nodes: make([]FlowGraphNode, 0), Edges: make([]FlowGraphEdge, 0)}
+	root := &EventFlowNode{
+		Name:     flow.RootNode.Name,
+		NodeType: flow.RootNode.NodeType,
+		Metadata: flow.RootNode.Metadata,
+		Branches: flow.RootNode.Branches,
+	}
+	if _, err := graph.addEventFlowNode(root); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+func (g *FlowGraph) addEventFlowNode(node *EventFlowNode) (string, error) {
+	id := fmt.Sprintf("n%d", len(g.Nodes))
+	metadata, err := eventFlowNodeMetadata(node.Metadata)
+	if err != nil {
+		return "", fmt.Errorf("node %s: %w", id, err)
+	}
+	g.Nodes = append(g.Nodes, FlowGraphNode{ID: id, NodeType: node.NodeType, Name: node.Name, Details: eventFlowNodeDetails(node.NodeType, metadata)})
+	for _, branch := range node.Branches {
+		if branch.Target == nil {
+			continue
+		}
+		label := branch.BranchType
+		if branch.Condition != "" {
+			label = truncateText(branch.Condition)
+		}
+		g.Edges = append(g.Edges, FlowGraphEdge{From: id, To: fmt.Sprintf("n%d", len(g.Nodes)), Label: label})
+		if _, err := g.addEventFlowNode(branch.Target); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+func eventFlowNodeDetails(nodeType string, metadata *EventFlowNodeMetadata) []string {
+	details := make([]string, 0)
+	switch nodeType {
+	case EventFlowNodeType.Plain:
+		if metadata.VarKey != "" {
+			details = append(details, fmt.Sprintf("%s = %s", metadata.VarKey, truncateText(metadata.VarValue)))
+		}
+	case EventFlowNodeType.SupportHours:
+		if metadata.SupportHoursID != nil {
+			details = append(details, fmt.Sprintf("support hours %d", *metadata.SupportHoursID))
+		}
+	case EventFlowNodeType.RouteEvent:
+		if metadata.AlertSourceID != nil {
+			details = append(details, fmt.Sprintf("alert source %d", *metadata.AlertSourceID))
+		}
+		if metadata.EscalationPolicyID != nil {
+			details = append(details, fmt.Sprintf("escalation policy %d", *metadata.EscalationPolicyID))
+		}
+		if metadata.OverwritePriority != "" {
+			details = append(details, fmt.Sprintf("priority %s", metadata.OverwritePriority))
+		}
+	case EventFlowNodeType.DefineBranches:
+		for _, definition := range metadata.Definitions {
+			details = append(details, fmt.Sprintf("%s: %s", definition.BranchName, truncateText(definition.Conditions)))
+		}
+	case EventFlowNodeType.Wait:
+		switch {
+		case metadata.WaitForDuration != "":
+			details = append(details, fmt.Sprintf("wait %s", metadata.WaitForDuration))
+		case metadata.WaitStartSupportHoursID != nil:
+			details = append(details, fmt.Sprintf("until support hours %d start", *metadata.WaitStartSupportHoursID))
+		case metadata.WaitEndSupportHoursID != nil:
+			details = append(details, fmt.Sprintf("until support hours %d end", *metadata.WaitEndSupportHoursID))
+		}
+	case EventFlowNodeType.Transform:
+		if metadata.Condition != "" {
+			details = append(details, fmt.Sprintf("if %s", truncateText(metadata.Condition)))
+		}
+		for _, rule := range metadata.Rules {
+			switch {
+			case rule.Source != "":
+				details = append(details, fmt.Sprintf("%s %s from %s", rule.Operator, rule.Target, rule.Source))
+			case rule.Value != nil:
+				details = append(details, fmt.Sprintf("%s %s = %s", rule.Operator, rule.Target, truncateText(fmt.Sprint(rule.Value))))
+			default:
+				details = append(details, fmt.Sprintf("%s %s", rule.Operator, rule.Target))
+			}
+		}
+	}
+	return details
+}
+
+// CallFlowGraph returns the graph of a call flow
+func CallFlowGraph(flow *CallFlowOutput) (*FlowGraph, error) {
+	if flow == nil || flow.RootNode == nil {
+		return nil, errors.New("call flow with root node is required")
+	}
+	graph := &FlowGraph{Name: flow.Name, Nodes: make([]FlowGraphNode, 0), Edges: make([]FlowGraphEdge, 0)}
+	root := &CallFlowNode{
+		Name:     flow.RootNode.Name,
+		NodeType: flow.RootNode.NodeType,
+		Metadata: flow.RootNode.Metadata,
+		Branches: flow.RootNode.Branches,
+	}
+	if _, err := graph.addCallFlowNode(root); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+func (g *FlowGraph) addCallFlowNode(node *CallFlowNode) (string, error) {
+	id := fmt.Sprintf("n%d", len(g.Nodes))
+	metadata, err := callFlowNodeMetadata(node.Metadata)
+	if err != nil {
+		return "", fmt.Errorf("node %s: %w", id, err)
+	}
+	g.Nodes = append(g.Nodes, FlowGraphNode{ID: id, NodeType: node.NodeType, Name: node.Name, Details: callFlowNodeDetails(node.NodeType, metadata)})
+	for _, branch := range node.Branches {
+		if branch.Target == nil {
+			continue
+		}
+		label := branch.BranchType
+		if branch.Condition != "" {
+			label = truncateText(branch.Condition)
+		}
+		g.Edges = append(g.Edges, FlowGraphEdge{From: id, To: fmt.Sprintf("n%d", len(g.Nodes)), Label: label})
+		if _, err := g.addCallFlowNode(branch.Target); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+func callFlowNodeDetails(nodeType string, metadata *CallFlowNodeMetadata) []string {
+	details := make([]string, 0)
+	if metadata.TextMessage != "" {
+		details = append(details, fmt.Sprintf("%q", truncateText(metadata.TextMessage)))
+	} else if metadata.CustomAudioUrl != "" {
+		details = append(details, fmt.Sprintf("audio %s", truncateText(metadata.CustomAudioUrl)))
+	}
+	if metadata.AIVoiceModel != "" || metadata.Language != "" {
+		details = append(details, strings.TrimSpace(fmt.Sprintf("voice %s %s", metadata.AIVoiceModel, metadata.Language)))
+	}
+	switch nodeType {
+	case CallFlowNodeType.IVRMenu:
+		if len(metadata.EnabledOptions) > 0 {
+			details = append(details, fmt.Sprintf("options %s", strings.Join(metadata.EnabledOptions, ", ")))
+		}
+	case CallFlowNodeType.Plain:
+		if metadata.VarKey != "" {
+			details = append(details, fmt.Sprintf("%s = %s", metadata.VarKey, truncateText(metadata.VarValue)))
+		}
+	case CallFlowNodeType.PinCode:
+		for _, code := range metadata.Codes {
+			details = append(details, fmt.Sprintf("code %s", code.Label))
+		}
+	case CallFlowNodeType.SupportHours:
+		if metadata.SupportHoursId != 0 {
+			details = append(details, fmt.Sprintf("support hours %d", metadata.SupportHoursId))
+		}
+	case CallFlowNodeType.RouteCall, CallFlowNodeType.ParallelRouteCall:
+		for _, target := range metadata.Targets {
+			details = append(details, fmt.Sprintf("call %s %s", target.Type, target.Target))
+		}
+		if metadata.CallStyle != "" {
+			details = append(details, fmt.Sprintf("style %s", metadata.CallStyle))
+		}
+		if metadata.CallTimeoutSec != 0 {
+			details = append(details, fmt.Sprintf("timeout %ds", metadata.CallTimeoutSec))
+		}
+	case CallFlowNodeType.CreateAlert:
+		if metadata.AlertSourceId != 0 {
+			details = append(details, fmt.Sprintf("alert source %d", metadata.AlertSourceId))
+		}
+	case CallFlowNodeType.BlockNumbers:
+		details = append(details, fmt.Sprintf("%d blocked numbers", len(metadata.Blacklist)))
+	case CallFlowNodeType.Agentic:
+		for _, intent := range metadata.Intents {
+			details = append(details, fmt.Sprintf("intent %s", callFlowIntentCondition(intent)))
+		}
+		for _, gather := range metadata.Gathers {
+			details = append(details, fmt.Sprintf("gather %s", gather.Type))
+		}
+	}
+	if metadata.Retries != 0 {
+		details = append(details, fmt.Sprintf("retries %d", metadata.Retries))
+	}
+	return details
+}
+
+// DOT renders the graph in the Graphviz DOT language
+func (g *FlowGraph) DOT() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(g.Name))
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, style=rounded, fontname=\"Helvetica\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\"];\n")
+	for _, node := range g.Nodes {
+		shape := ""
+		if node.NodeType == EventFlowNodeType.Root {
+			shape = ", shape=circle"
+		}
+		fmt.Fprintf(b, "  %s [label=%s%s];\n", node.ID, dotQuote(strings.Join(node.lines(), "\n")), shape)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(b, "  %s -> %s [label=%s];\n", edge.From, edge.To, dotQuote(edge.Label))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as Mermaid flowchart
+func (g *FlowGraph) Mermaid() string {
+	b := &strings.Builder{}
+	if g.Name != "" {
+		fmt.Fprintf(b, "---\ntitle: %s\n---\n", mermaidEscape(g.Name))
+	}
+	b.WriteString("flowchart TD\n")
+	for _, node := range g.Nodes {
+		label := mermaidEscape(strings.Join(node.lines(), "\n"))
+		label = strings.ReplaceAll(label, "\n", "<br/>")
+		if node.NodeType == EventFlowNodeType.Root {
+			fmt.Fprintf(b, "  %s((\"%s\"))\n", node.ID, label)
+			continue
+		}
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", node.ID, label)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(b, "  %s -->|\"%s\"| %s\n", edge.From, mermaidEscape(edge.Label), edge.To)
+	}
+	return b.String()
+}
+
+// lines returns the label lines of a node, its type, name and details
+func (n FlowGraphNode) lines() []string {
+	lines := []string{n.NodeType}
+	if n.Name != "" {
+		lines = append(lines, n.Name)
+	}
+	return append(lines, n.Details...)
+}
+
+func truncateText(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); len(runes) > flowGraphMaxText {
+		return string(runes[:flowGraphMaxText-1]) + "…"
+	}
+	return value
+}
+
+func dotQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func mermaidEscape(value string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+	return replacer.Replace(value)
+}
@@ -0,0 +1,76 @@
+package ilert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlowValidationError is a structural error of an event flow or call flow node
+type FlowValidationError struct {
+	// path of the node or field e.g. root.branches[0].target.metadata
+	Path    string
+	Message string
+}
+
+func (e *FlowValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// FlowValidationErrors holds all structural errors of a flow
+type FlowValidationErrors []*FlowValidationError
+
+func (e FlowValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("invalid flow: %s", strings.Join(messages, "; "))
+}
+
+// flowValidator collects validation errors
+type flowValidator struct {
+	errors FlowValidationErrors
+	// nodes maps the validated nodes to whether they are on the path being validated
+	nodes map[interface{}]bool
+}
+
+func (v *flowValidator) addf(path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, &FlowValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// enter reports whether the node is validated for the first time, a node reached from itself is a cycle
+func (v *flowValidator) enter(path string, node interface{}) bool {
+	if v.nodes == nil {
+		v.nodes = make(map[interface{}]bool)
+	}
+	active, seen := v.nodes[node]
+	if active {
+		v.addf(path, "cycles back to a node it is reached from")
+	}
+	if seen {
+		return false
+	}
+	v.nodes[node] = true
+	return true
+}
+
+// leave marks the node as validated once its branches are
+func (v *flowValidator) leave(node interface{}) {
+	v.nodes[node] = false
+}
+
+func (v *flowValidator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return v.errors
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
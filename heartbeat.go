@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
@@ -30,6 +31,11 @@ type PingHeartbeatOutput struct {
 
 // PingHeartbeat gets list available ilert phone numbers. https://api.ilert.com/api-docs/#tag/Heartbeats/paths/~1heartbeats~1{key}/get
 func (c *Client) PingHeartbeat(input *PingHeartbeatInput) (*PingHeartbeatOutput, error) {
+	return c.PingHeartbeatWithContext(context.Background(), input)
+}
+
+// PingHeartbeatWithContext is the same as PingHeartbeat with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) PingHeartbeatWithContext(ctx context.Context, input *PingHeartbeatInput) (*PingHeartbeatOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -40,7 +46,7 @@ func (c *Client) PingHeartbeat(input *PingHeartbeatInput) (*PingHeartbeatOutput,
 		input.Method = String(HeartbeatMethods.HEAD)
 	}
 
-	resp, err := c.httpClient.R().Execute(*input.Method, fmt.Sprintf("%s/%s", apiRoutes.heartbeats, *input.APIKey))
+	resp, err := c.httpClient.R().SetContext(ctx).Execute(*input.Method, fmt.Sprintf("%s/%s", apiRoutes.heartbeats, *input.APIKey))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -41,6 +42,11 @@ type CreateHeartbeatMonitorOutput struct {
 
 // CreateHeartbeatMonitor creates a new heartbeat monitor resource. https://api.ilert.com/api-docs/#tag/heartbeat-monitors/post/heartbeat-monitors
 func (c *Client) CreateHeartbeatMonitor(input *CreateHeartbeatMonitorInput) (*CreateHeartbeatMonitorOutput, error) {
+	return c.CreateHeartbeatMonitorWithContext(context.Background(), input)
+}
+
+// CreateHeartbeatMonitorWithContext is the same as CreateHeartbeatMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateHeartbeatMonitorWithContext(ctx context.Context, input *CreateHeartbeatMonitorInput) (*CreateHeartbeatMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -53,7 +59,7 @@ func (c *Client) CreateHeartbeatMonitor(input *CreateHeartbeatMonitorInput) (*Cr
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.HeartbeatMonitor).Post(fmt.Sprintf("%s?%s", apiRoutes.heartbeatMonitors, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.HeartbeatMonitor).Post(fmt.Sprintf("%s?%s", apiRoutes.heartbeatMonitors, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +94,11 @@ type GetHeartbeatMonitorOutput struct {
 
 // GetHeartbeatMonitor gets the heartbeat monitors resource with specified id. https://api.ilert.com/api-docs/#tag/heartbeat-monitors/get/heartbeat-monitors/{id}
 func (c *Client) GetHeartbeatMonitor(input *GetHeartbeatMonitorInput) (*GetHeartbeatMonitorOutput, error) {
+	return c.GetHeartbeatMonitorWithContext(context.Background(), input)
+}
+
+// GetHeartbeatMonitorWithContext is the same as GetHeartbeatMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetHeartbeatMonitorWithContext(ctx context.Context, input *GetHeartbeatMonitorInput) (*GetHeartbeatMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -100,7 +111,7 @@ func (c *Client) GetHeartbeatMonitor(input *GetHeartbeatMonitorInput) (*GetHeart
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d?%s", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d?%s", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +148,11 @@ type GetHeartbeatMonitorsOutput struct {
 
 // GetHeartbeatMonitors lists existing heartbeat monitor resources. https://api.ilert.com/api-docs/#tag/heartbeat-monitors/get/heartbeat-monitors
 func (c *Client) GetHeartbeatMonitors(input *GetHeartbeatMonitorsInput) (*GetHeartbeatMonitorsOutput, error) {
+	return c.GetHeartbeatMonitorsWithContext(context.Background(), input)
+}
+
+// GetHeartbeatMonitorsWithContext is the same as GetHeartbeatMonitors with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetHeartbeatMonitorsWithContext(ctx context.Context, input *GetHeartbeatMonitorsInput) (*GetHeartbeatMonitorsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -145,7 +161,7 @@ func (c *Client) GetHeartbeatMonitors(input *GetHeartbeatMonitorsInput) (*GetHea
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.heartbeatMonitors, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.heartbeatMonitors, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +178,32 @@ func (c *Client) GetHeartbeatMonitors(input *GetHeartbeatMonitorsInput) (*GetHea
 	return &GetHeartbeatMonitorsOutput{HeartbeatMonitors: HeartbeatMonitors}, nil
 }
 
+// GetHeartbeatMonitorsPages iterates over all pages of a GetHeartbeatMonitors operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetHeartbeatMonitorsPages(input *GetHeartbeatMonitorsInput, fn func(page *GetHeartbeatMonitorsOutput) bool, options ...PaginateOption) error {
+	return c.GetHeartbeatMonitorsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetHeartbeatMonitorsPagesWithContext is the same as GetHeartbeatMonitorsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetHeartbeatMonitorsPagesWithContext(ctx context.Context, input *GetHeartbeatMonitorsInput, fn func(page *GetHeartbeatMonitorsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetHeartbeatMonitorsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*HeartbeatMonitor, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetHeartbeatMonitorsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.HeartbeatMonitors, nil
+	}
+	return Paginate(ctx, fetch, func(page []*HeartbeatMonitor) bool {
+		return fn(&GetHeartbeatMonitorsOutput{HeartbeatMonitors: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchHeartbeatMonitorInput represents the input of a SearchHeartbeatMonitor operation.
 type SearchHeartbeatMonitorInput struct {
 	_                    struct{}
@@ -176,6 +218,11 @@ type SearchHeartbeatMonitorOutput struct {
 
 // SearchHeartbeatMonitor gets the heartbeat monitor resource with specified name.
 func (c *Client) SearchHeartbeatMonitor(input *SearchHeartbeatMonitorInput) (*SearchHeartbeatMonitorOutput, error) {
+	return c.SearchHeartbeatMonitorWithContext(context.Background(), input)
+}
+
+// SearchHeartbeatMonitorWithContext is the same as SearchHeartbeatMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchHeartbeatMonitorWithContext(ctx context.Context, input *SearchHeartbeatMonitorInput) (*SearchHeartbeatMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -186,7 +233,7 @@ func (c *Client) SearchHeartbeatMonitor(input *SearchHeartbeatMonitorInput) (*Se
 	q := url.Values{}
 	q.Add("include", "integrationUrl")
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s?%s", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorName, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s?%s", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorName, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -222,6 +269,11 @@ type UpdateHeartbeatMonitorOutput struct {
 
 // UpdateHeartbeatMonitor updates an existing heartbeat monitor resource. https://api.ilert.com/api-docs/#tag/heartbeat-monitors/put/heartbeat-monitors/{id}
 func (c *Client) UpdateHeartbeatMonitor(input *UpdateHeartbeatMonitorInput) (*UpdateHeartbeatMonitorOutput, error) {
+	return c.UpdateHeartbeatMonitorWithContext(context.Background(), input)
+}
+
+// UpdateHeartbeatMonitorWithContext is the same as UpdateHeartbeatMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateHeartbeatMonitorWithContext(ctx context.Context, input *UpdateHeartbeatMonitorInput) (*UpdateHeartbeatMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -237,7 +289,7 @@ func (c *Client) UpdateHeartbeatMonitor(input *UpdateHeartbeatMonitorInput) (*Up
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.HeartbeatMonitor).Put(fmt.Sprintf("%s/%d?%s", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.HeartbeatMonitor).Put(fmt.Sprintf("%s/%d?%s", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -267,6 +319,11 @@ type DeleteHeartbeatMonitorOutput struct {
 
 // DeleteHeartbeatMonitor deletes the specified heartbeat monitor resource. https://api.ilert.com/api-docs/#tag/heartbeat-monitors/delete/heartbeat-monitors/{id}
 func (c *Client) DeleteHeartbeatMonitor(input *DeleteHeartbeatMonitorInput) (*DeleteHeartbeatMonitorOutput, error) {
+	return c.DeleteHeartbeatMonitorWithContext(context.Background(), input)
+}
+
+// DeleteHeartbeatMonitorWithContext is the same as DeleteHeartbeatMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteHeartbeatMonitorWithContext(ctx context.Context, input *DeleteHeartbeatMonitorInput) (*DeleteHeartbeatMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -274,7 +331,7 @@ func (c *Client) DeleteHeartbeatMonitor(input *DeleteHeartbeatMonitorInput) (*De
 		return nil, errors.New("heartbeat monitor id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.heartbeatMonitors, *input.HeartbeatMonitorID))
 	if err != nil {
 		return nil, err
 	}
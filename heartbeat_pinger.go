@@ -0,0 +1,215 @@
+package ilert
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// HeartbeatHealthCheck reports whether the application is healthy, a non-nil error suppresses the next ping
+type HeartbeatHealthCheck func(ctx context.Context) error
+
+// HeartbeatPingResult describes the outcome of a single HeartbeatPinger tick
+type HeartbeatPingResult struct {
+	Time time.Time
+
+	// error of the PingHeartbeat call, nil if the ping succeeded or was suppressed
+	Err error
+
+	// error of the first failing health check, non-nil if the ping was suppressed
+	HealthErr error
+}
+
+// HeartbeatPinger pings a heartbeat integration key on an interval until it is stopped.
+// The ping interval is derived from the heartbeat monitor's interval minus a safety margin and
+// randomized with jitter, pings are suppressed while any health check fails.
+type HeartbeatPinger struct {
+	client         *Client
+	integrationKey string
+	monitorPeriod  time.Duration
+	interval       time.Duration
+	safetyMargin   float64
+	jitter         float64
+	method         string
+	healthChecks   []HeartbeatHealthCheck
+	onPing         func(result *HeartbeatPingResult)
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// HeartbeatPingerOptions allows for options to be passed into the HeartbeatPinger for customization
+type HeartbeatPingerOptions func(*HeartbeatPinger)
+
+// WithHeartbeatSafetyMargin sets the fraction of the monitor interval that is subtracted from the ping interval,
+// e.g. 0.5 pings a monitor with 60 seconds interval every 30 seconds
+// Default: 0.5
+func WithHeartbeatSafetyMargin(safetyMargin float64) HeartbeatPingerOptions {
+	return func(p *HeartbeatPinger) {
+		p.safetyMargin = safetyMargin
+	}
+}
+
+// WithHeartbeatJitter sets the fraction by which each ping interval is randomly shortened
+// Default: 0.1
+func WithHeartbeatJitter(jitter float64) HeartbeatPingerOptions {
+	return func(p *HeartbeatPinger) {
+		p.jitter = jitter
+	}
+}
+
+// WithHeartbeatInterval sets the ping interval explicitly instead of deriving it from the monitor interval
+func WithHeartbeatInterval(interval time.Duration) HeartbeatPingerOptions {
+	return func(p *HeartbeatPinger) {
+		p.interval = interval
+	}
+}
+
+// WithHeartbeatMethod sets the http method used for pings, see HeartbeatMethods
+// Default: HEAD
+func WithHeartbeatMethod(method string) HeartbeatPingerOptions {
+	return func(p *HeartbeatPinger) {
+		p.method = method
+	}
+}
+
+// WithHeartbeatHealthCheck adds a health check that must pass before each ping
+func WithHeartbeatHealthCheck(check HeartbeatHealthCheck) HeartbeatPingerOptions {
+	return func(p *HeartbeatPinger) {
+		p.healthChecks = append(p.healthChecks, check)
+	}
+}
+
+// WithHeartbeatCallback sets a callback that is invoked after every tick with its result
+func WithHeartbeatCallback(callback func(result *HeartbeatPingResult)) HeartbeatPingerOptions {
+	return func(p *HeartbeatPinger) {
+		p.onPing = callback
+	}
+}
+
+// NewHeartbeatPinger creates a pinger for the given heartbeat integration key and monitor interval in seconds
+func NewHeartbeatPinger(client *Client, integrationKey string, intervalSec int64, options ...HeartbeatPingerOptions) (*HeartbeatPinger, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	if integrationKey == "" {
+		return nil, errors.New("integration key is required")
+	}
+	p := &HeartbeatPinger{
+		client:         client,
+		integrationKey: integrationKey,
+		monitorPeriod:  time.Duration(intervalSec) * time.Second,
+		safetyMargin:   0.5,
+		jitter:         0.1,
+		method:         HeartbeatMethods.HEAD,
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	if p.interval <= 0 {
+		if p.monitorPeriod <= 0 {
+			return nil, errors.New("interval is required")
+		}
+		if p.safetyMargin < 0 || p.safetyMargin >= 1 {
+			return nil, errors.New("safety margin must be between 0 and 1")
+		}
+		p.interval = time.Duration(float64(p.monitorPeriod) * (1 - p.safetyMargin))
+	}
+	if p.jitter < 0 || p.jitter >= 1 {
+		return nil, errors.New("jitter must be between 0 and 1")
+	}
+	return p, nil
+}
+
+// NewHeartbeatPingerForMonitor creates a pinger for the integration key and interval of a heartbeat monitor
+func NewHeartbeatPingerForMonitor(client *Client, monitor *HeartbeatMonitor, options ...HeartbeatPingerOptions) (*HeartbeatPinger, error) {
+	if monitor == nil {
+		return nil, errors.New("heartbeat monitor is required")
+	}
+	return NewHeartbeatPinger(client, monitor.IntegrationKey, monitor.IntervalSec, options...)
+}
+
+// Interval returns the ping interval before jitter is applied
+func (p *HeartbeatPinger) Interval() time.Duration {
+	return p.interval
+}
+
+// Start runs the pinger in the background until Stop is called
+func (p *HeartbeatPinger) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		p.Run(ctx)
+	}(p.done)
+}
+
+// Stop stops a pinger started with Start and waits until an in-flight ping returned
+func (p *HeartbeatPinger) Stop() {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.cancel, p.done = nil, nil
+	p.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Run pings immediately and then on every interval until the context is done
+func (p *HeartbeatPinger) Run(ctx context.Context) error {
+	for {
+		p.Ping(ctx)
+
+		timer := time.NewTimer(p.nextInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Ping runs the health checks and pings the heartbeat if all of them pass
+func (p *HeartbeatPinger) Ping(ctx context.Context) *HeartbeatPingResult {
+	result := &HeartbeatPingResult{Time: time.Now()}
+	for _, check := range p.healthChecks {
+		if err := check(ctx); err != nil {
+			result.HealthErr = err
+			break
+		}
+	}
+	if result.HealthErr == nil {
+		_, result.Err = p.client.PingHeartbeatWithContext(ctx, &PingHeartbeatInput{
+			APIKey: String(p.integrationKey),
+			Method: String(p.method),
+		})
+	}
+	if p.onPing != nil {
+		p.onPing(result)
+	}
+	return result
+}
+
+// nextInterval returns the ping interval shortened by a random jitter
+func (p *HeartbeatPinger) nextInterval() time.Duration {
+	if p.jitter <= 0 {
+		return p.interval
+	}
+	maxJitter := int64(float64(p.interval) * p.jitter)
+	if maxJitter <= 0 {
+		return p.interval
+	}
+	return p.interval - time.Duration(rand.Int63n(maxJitter))
+}
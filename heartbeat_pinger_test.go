@@ -0,0 +1,168 @@
+package ilert_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestHeartbeatPingerInterval(t *testing.T) {
+	client := ilert.NewClient()
+	tests := []struct {
+		name        string
+		intervalSec int64
+		options     []ilert.HeartbeatPingerOptions
+		want        time.Duration
+	}{
+		{"default safety margin", 60, nil, 30 * time.Second},
+		{"safety margin", 60, []ilert.HeartbeatPingerOptions{ilert.WithHeartbeatSafetyMargin(0.2)}, 48 * time.Second},
+		{"no safety margin", 60, []ilert.HeartbeatPingerOptions{ilert.WithHeartbeatSafetyMargin(0)}, time.Minute},
+		{"explicit interval", 0, []ilert.HeartbeatPingerOptions{ilert.WithHeartbeatInterval(10 * time.Second), ilert.WithHeartbeatSafetyMargin(2)}, 10 * time.Second},
+	}
+	for _, test := range tests {
+		pinger, err := ilert.NewHeartbeatPinger(client, "key", test.intervalSec, test.options...)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if pinger.Interval() != test.want {
+			t.Errorf("%s: expected interval %s, got %s", test.name, test.want, pinger.Interval())
+		}
+	}
+
+	errorTests := []struct {
+		name        string
+		client      *ilert.Client
+		key         string
+		intervalSec int64
+		options     []ilert.HeartbeatPingerOptions
+	}{
+		{"client", nil, "key", 60, nil},
+		{"integration key", client, "", 60, nil},
+		{"interval", client, "key", 0, nil},
+		{"safety margin", client, "key", 60, []ilert.HeartbeatPingerOptions{ilert.WithHeartbeatSafetyMargin(1)}},
+		{"jitter", client, "key", 60, []ilert.HeartbeatPingerOptions{ilert.WithHeartbeatJitter(1)}},
+	}
+	for _, test := range errorTests {
+		if _, err := ilert.NewHeartbeatPinger(test.client, test.key, test.intervalSec, test.options...); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestHeartbeatPingerJitter(t *testing.T) {
+	pinger, err := ilert.NewHeartbeatPinger(ilert.NewClient(), "key", 60, ilert.WithHeartbeatJitter(0.1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	distinct := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		interval := ilert.HeartbeatNextInterval(pinger)
+		if interval <= 27*time.Second || interval > 30*time.Second {
+			t.Fatalf("expected the interval to be shortened by at most 10%%, got %s", interval)
+		}
+		distinct[interval] = true
+	}
+	if len(distinct) < 2 {
+		t.Error("expected randomized intervals")
+	}
+
+	pinger, err = ilert.NewHeartbeatPinger(ilert.NewClient(), "key", 60, ilert.WithHeartbeatJitter(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if interval := ilert.HeartbeatNextInterval(pinger); interval != 30*time.Second {
+		t.Errorf("expected no jitter, got %s", interval)
+	}
+}
+
+func TestHeartbeatPingerHealthChecks(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	checkErr := errors.New("database unavailable")
+	pinger, err := ilert.NewHeartbeatPinger(srv.Client(), "key", 60,
+		ilert.WithHeartbeatHealthCheck(func(ctx context.Context) error { return nil }),
+		ilert.WithHeartbeatHealthCheck(func(ctx context.Context) error { return checkErr }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := pinger.Ping(context.Background())
+	if !errors.Is(result.HealthErr, checkErr) || result.Err != nil || srv.HeartbeatPings("key") != 0 {
+		t.Errorf("expected the failing health check to suppress the ping, got %+v and %d pings", result, srv.HeartbeatPings("key"))
+	}
+
+	checkErr = nil
+	result = pinger.Ping(context.Background())
+	if result.HealthErr != nil || result.Err != nil || srv.HeartbeatPings("key") != 1 {
+		t.Errorf("expected a ping, got %+v and %d pings", result, srv.HeartbeatPings("key"))
+	}
+}
+
+func TestHeartbeatPingerStartStop(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	mu := sync.Mutex{}
+	results := 0
+	pinger, err := ilert.NewHeartbeatPinger(srv.Client(), "key", 0,
+		ilert.WithHeartbeatInterval(10*time.Millisecond),
+		ilert.WithHeartbeatCallback(func(result *ilert.HeartbeatPingResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			results++
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pinger.Start()
+	pinger.Start()
+	waitFor(t, "pings", func() bool { return srv.HeartbeatPings("key") >= 3 })
+	pinger.Stop()
+	pings := srv.HeartbeatPings("key")
+	time.Sleep(50 * time.Millisecond)
+	pinger.Stop()
+
+	if n := srv.HeartbeatPings("key"); n != pings {
+		t.Errorf("expected no pings after Stop, got %d more", n-pings)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if results != pings {
+		t.Errorf("expected a callback per ping, got %d callbacks for %d pings", results, pings)
+	}
+}
+
+func TestHeartbeatPingerStopWaitsForPing(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	started := make(chan struct{})
+	returned := false
+	pinger, err := ilert.NewHeartbeatPinger(srv.Client(), "key", 60,
+		ilert.WithHeartbeatHealthCheck(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			returned = true
+			return ctx.Err()
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pinger.Start()
+	<-started
+	pinger.Stop()
+
+	if !returned {
+		t.Error("expected Stop to wait until the in-flight ping returned")
+	}
+	if srv.HeartbeatPings("key") != 0 {
+		t.Error("expected the canceled ping to be suppressed")
+	}
+}
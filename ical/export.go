@@ -0,0 +1,216 @@
+package ical
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// AddShifts adds the shifts of a schedule, e.g. the output of GetScheduleShifts, as events.
+// The schedule is optional and used for the summary and a stable event uid.
+func (c *Calendar) AddShifts(schedule *ilert.Schedule, shifts []*ilert.Shift) error {
+	return c.addShifts(schedule, shifts, false)
+}
+
+// AddOverrides adds the overrides of a schedule, e.g. the output of GetScheduleOverrides, as events
+func (c *Calendar) AddOverrides(schedule *ilert.Schedule, overrides []*ilert.Shift) error {
+	return c.addShifts(schedule, overrides, true)
+}
+
+func (c *Calendar) addShifts(schedule *ilert.Schedule, shifts []*ilert.Shift, override bool) error {
+	loc, err := location(schedule)
+	if err != nil {
+		return err
+	}
+	for i, shift := range shifts {
+		if shift == nil {
+			continue
+		}
+		start, err := ilert.ParseScheduleTime(shift.Start, loc)
+		if err != nil {
+			return fmt.Errorf("invalid shift #%d: %w", i+1, err)
+		}
+		end, err := ilert.ParseScheduleTime(shift.End, loc)
+		if err != nil {
+			return fmt.Errorf("invalid shift #%d: %w", i+1, err)
+		}
+
+		event := &Event{
+			Start:      start,
+			End:        end,
+			Categories: []string{"On-call"},
+		}
+		summary := "On call"
+		if override {
+			summary = "On call (override)"
+			event.Categories = append(event.Categories, "Override")
+		}
+		uidParts := []string{"shift", userKey(shift.User), start.UTC().Format(dateTimeLayout), end.UTC().Format(dateTimeLayout)}
+		if schedule != nil {
+			summary = fmt.Sprintf("%s: %s", summary, schedule.Name)
+			event.Description = fmt.Sprintf("Schedule %s", schedule.Name)
+			event.Categories = append(event.Categories, schedule.Name)
+			uidParts = append(uidParts, fmt.Sprint(schedule.ID))
+		}
+		if override {
+			uidParts = append(uidParts, "override")
+		}
+		event.Summary = fmt.Sprintf("%s (%s)", summary, ilert.UserDisplayName(shift.User))
+		event.UID = uid(uidParts...)
+		if shift.User.Email != "" {
+			event.Attendees = []string{shift.User.Email}
+		}
+		c.Events = append(c.Events, event)
+	}
+	return nil
+}
+
+// ShiftsOfUser returns the shifts of the given user, users are compared with ilert.SameUser
+func ShiftsOfUser(shifts []*ilert.Shift, user *ilert.User) []*ilert.Shift {
+	result := make([]*ilert.Shift, 0)
+	if user == nil {
+		return result
+	}
+	for _, shift := range shifts {
+		if shift != nil && ilert.SameUser(shift.User, *user) {
+			result = append(result, shift)
+		}
+	}
+	return result
+}
+
+// AddSupportHour adds the support days of a support hour between from and until as one event per day,
+// computed in the support hour's timezone, and its exceptions as events
+func (c *Calendar) AddSupportHour(supportHour *ilert.SupportHour, from time.Time, until time.Time) error {
+	if supportHour == nil {
+		return errors.New("support hour is required")
+	}
+	if !until.After(from) {
+		return errors.New("until must be after from")
+	}
+	loc := time.UTC
+	if supportHour.Timezone != "" {
+		tz, err := time.LoadLocation(supportHour.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", supportHour.Timezone, err)
+		}
+		loc = tz
+	}
+
+	if days := supportHour.SupportDays; days != nil {
+		byWeekday := map[time.Weekday]*ilert.SupportDay{
+			time.Monday:    days.MONDAY,
+			time.Tuesday:   days.TUESDAY,
+			time.Wednesday: days.WEDNESDAY,
+			time.Thursday:  days.THURSDAY,
+			time.Friday:    days.FRIDAY,
+			time.Saturday:  days.SATURDAY,
+			time.Sunday:    days.SUNDAY,
+		}
+		local := from.In(loc)
+		// start a day earlier to include support days reaching past midnight
+		day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, loc)
+		for day.Before(until) {
+			supportDay := byWeekday[day.Weekday()]
+			next := day.AddDate(0, 0, 1)
+			if supportDay == nil {
+				day = next
+				continue
+			}
+			start, err := atTimeOfDay(day, supportDay.Start)
+			if err != nil {
+				return fmt.Errorf("invalid support day %s: %w", day.Weekday(), err)
+			}
+			end, err := atTimeOfDay(day, supportDay.End)
+			if err != nil {
+				return fmt.Errorf("invalid support day %s: %w", day.Weekday(), err)
+			}
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+			if end.After(from) && start.Before(until) {
+				c.Events = append(c.Events, &Event{
+					UID:         uid("support-hour", fmt.Sprint(supportHour.ID), supportHour.Name, start.UTC().Format(dateTimeLayout)),
+					Summary:     fmt.Sprintf("Support hours: %s", supportHour.Name),
+					Description: fmt.Sprintf("%s to %s (%s)", supportDay.Start, supportDay.End, loc),
+					Categories:  []string{"Support hours"},
+					Start:       start,
+					End:         end,
+				})
+			}
+			day = next
+		}
+	}
+
+	for i, exception := range supportHour.Exceptions {
+		start, err := ilert.ParseScheduleTime(exception.Start, loc)
+		if err != nil {
+			return fmt.Errorf("invalid exception #%d: %w", i+1, err)
+		}
+		end, err := ilert.ParseScheduleTime(exception.End, loc)
+		if err != nil {
+			return fmt.Errorf("invalid exception #%d: %w", i+1, err)
+		}
+		if !end.After(from) || !start.Before(until) {
+			continue
+		}
+		status := "outside support hours"
+		if exception.SupportStatus == ilert.SupportStatus.During {
+			status = "during support hours"
+		}
+		name := exception.Name
+		if name == "" {
+			name = "Exception"
+		}
+		c.Events = append(c.Events, &Event{
+			UID:         uid("support-hour-exception", fmt.Sprint(supportHour.ID), supportHour.Name, exception.Start, exception.End),
+			Summary:     fmt.Sprintf("%s: %s (%s)", supportHour.Name, name, status),
+			Description: fmt.Sprintf("Support hour exception, %s", status),
+			Categories:  []string{"Support hours", "Exception"},
+			Start:       start,
+			End:         end,
+		})
+	}
+	return nil
+}
+
+func location(schedule *ilert.Schedule) (*time.Location, error) {
+	if schedule == nil || schedule.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", schedule.Timezone, err)
+	}
+	return loc, nil
+}
+
+// atTimeOfDay returns the given time of day e.g. 08:00 on day
+func atTimeOfDay(day time.Time, value string) (time.Time, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q", value)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
+
+func userKey(user ilert.User) string {
+	switch {
+	case user.ID != 0:
+		return fmt.Sprint(user.ID)
+	case user.Username != "":
+		return user.Username
+	}
+	return strings.ToLower(user.Email)
+}
+
+// uid returns a stable event uid, so calendar clients update events on re-import
+func uid(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:12]) + "@ilert-go"
+}
@@ -0,0 +1,413 @@
+// Package ical converts schedules and support hours to iCalendar (RFC 5545) and imports absences from it.
+//
+// Shifts and support hours are exported as VEVENTs in UTC:
+//
+//	cal := ical.NewCalendar("SRE on-call")
+//	err := cal.AddShifts(schedule, shifts)
+//	...
+//	_, err = cal.WriteTo(w)
+//
+// Absences, e.g. exported by an HR tool, are parsed with Parse and turned into shift overrides with ImportAbsences.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Calendar is a list of events
+type Calendar struct {
+	Name   string
+	Events []*Event
+}
+
+// Event is a VEVENT
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  []string
+
+	Start time.Time
+	End   time.Time
+
+	// whether start and end are dates without time, End is exclusive
+	AllDay bool
+
+	// email addresses of the organizer and attendees
+	Organizer string
+	Attendees []string
+}
+
+// NewCalendar creates an empty calendar with the given name
+func NewCalendar(name string) *Calendar {
+	return &Calendar{Name: name, Events: make([]*Event, 0)}
+}
+
+const (
+	dateTimeLayout      = "20060102T150405Z"
+	localDateTimeLayout = "20060102T150405"
+	dateLayout          = "20060102"
+)
+
+// WriteTo writes the calendar as iCalendar, times are written in UTC
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	cw := &contentWriter{w: w}
+	now := time.Now().UTC().Format(dateTimeLayout)
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", "-//iLert//ilert-go//EN")
+	cw.line("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		cw.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	for _, event := range c.Events {
+		cw.line("BEGIN", "VEVENT")
+		cw.line("UID", escapeText(event.UID))
+		cw.line("DTSTAMP", now)
+		if event.AllDay {
+			cw.line("DTSTART;VALUE=DATE", event.Start.Format(dateLayout))
+			cw.line("DTEND;VALUE=DATE", event.End.Format(dateLayout))
+		} else {
+			cw.line("DTSTART", event.Start.UTC().Format(dateTimeLayout))
+			cw.line("DTEND", event.End.UTC().Format(dateTimeLayout))
+		}
+		if event.Summary != "" {
+			cw.line("SUMMARY", escapeText(event.Summary))
+		}
+		if event.Description != "" {
+			cw.line("DESCRIPTION", escapeText(event.Description))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, 0, len(event.Categories))
+			for _, category := range event.Categories {
+				categories = append(categories, escapeText(category))
+			}
+			cw.line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if event.Organizer != "" {
+			cw.line("ORGANIZER", "mailto:"+event.Organizer)
+		}
+		for _, attendee := range event.Attendees {
+			cw.line("ATTENDEE", "mailto:"+attendee)
+		}
+		cw.line("TRANSP", "OPAQUE")
+		cw.line("END", "VEVENT")
+	}
+	cw.line("END", "VCALENDAR")
+	return cw.n, cw.err
+}
+
+// String returns the calendar as iCalendar
+func (c *Calendar) String() string {
+	b := &strings.Builder{}
+	c.WriteTo(b)
+	return b.String()
+}
+
+// contentWriter writes content lines folded at 75 octets
+type contentWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *contentWriter) line(name string, value string) {
+	if cw.err != nil {
+		return
+	}
+	line := name + ":" + value
+	b := &strings.Builder{}
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	n, err := io.WriteString(cw.w, b.String())
+	cw.n += int64(n)
+	cw.err = err
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+func unescapeText(value string) string {
+	b := &strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(value[i])
+			}
+			continue
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// Parse reads the VEVENTs of an iCalendar stream. Floating times and dates are interpreted in loc,
+// times with a TZID that is not a known IANA timezone as well. Defaults to UTC if loc is nil.
+// Recurring events (RRULE or RDATE) are not expanded and fail the parse.
+func Parse(r io.Reader, loc *time.Location) (*Calendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := NewCalendar("")
+	var event *Event
+	var duration string
+	depth := 0
+	for i, line := range lines {
+		name, params, value, err := parseContentLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				event = &Event{}
+				duration = ""
+			}
+			depth++
+			continue
+		case "END":
+			depth--
+			if strings.EqualFold(value, "VEVENT") && event != nil {
+				if event.End.IsZero() {
+					event.End = event.Start
+					if duration != "" {
+						d, err := parseDuration(duration)
+						if err != nil {
+							return nil, fmt.Errorf("event %q: %w", event.UID, err)
+						}
+						event.End = event.Start.Add(d)
+					} else if event.AllDay {
+						event.End = event.Start.AddDate(0, 0, 1)
+					}
+				}
+				cal.Events = append(cal.Events, event)
+				event = nil
+			}
+			continue
+		case "X-WR-CALNAME":
+			if event == nil {
+				cal.Name = unescapeText(value)
+			}
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		switch name {
+		case "UID":
+			event.UID = unescapeText(value)
+		case "SUMMARY":
+			event.Summary = unescapeText(value)
+		case "DESCRIPTION":
+			event.Description = unescapeText(value)
+		case "CATEGORIES":
+			for _, category := range splitText(value) {
+				event.Categories = append(event.Categories, unescapeText(category))
+			}
+		case "ORGANIZER":
+			event.Organizer = mailAddress(value)
+		case "ATTENDEE":
+			if address := mailAddress(value); address != "" {
+				event.Attendees = append(event.Attendees, address)
+			}
+		case "DTSTART":
+			t, allDay, err := parseDateTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			event.Start, event.AllDay = t, allDay
+		case "DTEND":
+			t, _, err := parseDateTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			event.End = t
+		case "DURATION":
+			duration = value
+		case "RRULE", "RDATE":
+			return nil, fmt.Errorf("line %d: recurring events are not supported", i+1)
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced BEGIN and END")
+	}
+	return cal, nil
+}
+
+// unfold reads content lines joining folded continuation lines
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseContentLine splits a content line into its upper case name, parameters and value
+func parseContentLine(line string) (string, map[string]string, string, error) {
+	params := make(map[string]string)
+	inQuotes := false
+	nameEnd := -1
+	valueStart := -1
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ';' && !inQuotes && nameEnd < 0:
+			nameEnd = i
+		case r == ':' && !inQuotes:
+			valueStart = i + 1
+		}
+		if valueStart >= 0 {
+			break
+		}
+	}
+	if valueStart < 0 {
+		return "", nil, "", fmt.Errorf("invalid content line %q", line)
+	}
+	if nameEnd < 0 {
+		nameEnd = valueStart - 1
+	}
+	name := strings.ToUpper(line[:nameEnd])
+	if nameEnd < valueStart-1 {
+		for _, param := range splitParams(line[nameEnd+1 : valueStart-1]) {
+			key, value, _ := strings.Cut(param, "=")
+			params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return name, params, line[valueStart:], nil
+}
+
+func splitParams(value string) []string {
+	params := make([]string, 0)
+	inQuotes := false
+	start := 0
+	for i, r := range value {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ';' && !inQuotes:
+			params = append(params, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(params, value[start:])
+}
+
+// splitText splits a list of TEXT values at unescaped commas
+func splitText(value string) []string {
+	parts := make([]string, 0)
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+func mailAddress(value string) string {
+	if len(value) >= 7 && strings.EqualFold(value[:7], "mailto:") {
+		return value[7:]
+	}
+	if strings.Contains(value, "@") {
+		return value
+	}
+	return ""
+}
+
+// parseDateTime parses DATE and DATE-TIME values and reports whether the value is a date
+func parseDateTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date time %q", value)
+		}
+		return t, false, nil
+	}
+	if tzid := params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = tz
+		}
+	}
+	t, err := time.ParseInLocation(localDateTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date time %q", value)
+	}
+	return t, false, nil
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses a DURATION value, days are counted as 24 hours
+func parseDuration(value string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := []time.Duration{0, 0, 7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	d := time.Duration(0)
+	for i := 2; i < len(m); i++ {
+		if m[i] == "" {
+			continue
+		}
+		v, err := strconv.Atoi(m[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		d += time.Duration(v) * units[i]
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
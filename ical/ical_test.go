@@ -0,0 +1,185 @@
+package ical
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"X-WR-CALNAME:Absences",
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Berlin",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:vacation-1",
+		"SUMMARY:Vacation\\, Jane",
+		"DESCRIPTION:first line\\nsecond",
+		"  line",
+		"CATEGORIES:Vacation,Out\\,of office",
+		"ORGANIZER;CN=HR:mailto:hr@example.com",
+		`ATTENDEE;CN="Doe; Jane":MAILTO:jane@example.com`,
+		"DTSTART;TZID=Europe/Berlin:20240304T090000",
+		"DTEND;TZID=Europe/Berlin:20240304T170000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:sick-1",
+		"DTSTART;VALUE=DATE:20240305",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:training-1",
+		"DTSTART:20240306T080000Z",
+		"DURATION:PT2H30M",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:floating-1",
+		"DTSTART:20240307T080000",
+		"DTEND;TZID=Unknown/Zone:20240307T100000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n") + "\r\n"
+
+	cal, err := Parse(strings.NewReader(content), berlin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cal.Name != "Absences" || len(cal.Events) != 4 {
+		t.Fatalf("expected calendar Absences with 4 events, got %q with %d", cal.Name, len(cal.Events))
+	}
+	expected := []*Event{
+		{
+			UID:         "vacation-1",
+			Summary:     "Vacation, Jane",
+			Description: "first line\nsecond line",
+			Categories:  []string{"Vacation", "Out,of office"},
+			Start:       time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC),
+			End:         time.Date(2024, 3, 4, 16, 0, 0, 0, time.UTC),
+			Organizer:   "hr@example.com",
+			Attendees:   []string{"jane@example.com"},
+		},
+		{UID: "sick-1", Start: time.Date(2024, 3, 4, 23, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 5, 23, 0, 0, 0, time.UTC), AllDay: true},
+		{UID: "training-1", Start: time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 6, 10, 30, 0, 0, time.UTC)},
+		{UID: "floating-1", Start: time.Date(2024, 3, 7, 7, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 7, 9, 0, 0, 0, time.UTC)},
+	}
+	for i, event := range cal.Events {
+		assertEvent(t, expected[i], event)
+	}
+}
+
+func assertEvent(t *testing.T, expected *Event, got *Event) {
+	t.Helper()
+	if !got.Start.Equal(expected.Start) || !got.End.Equal(expected.End) {
+		t.Errorf("%s: expected %s to %s, got %s to %s", expected.UID, expected.Start, expected.End, got.Start, got.End)
+	}
+	want, actual := *expected, *got
+	want.Start, want.End, actual.Start, actual.End = time.Time{}, time.Time{}, time.Time{}, time.Time{}
+	if !reflect.DeepEqual(want, actual) {
+		t.Errorf("%s: expected %+v, got %+v", expected.UID, want, actual)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"recurring event", "BEGIN:VEVENT\nDTSTART:20240304T090000Z\nRRULE:FREQ=WEEKLY\nEND:VEVENT", "recurring events are not supported"},
+		{"recurrence dates", "BEGIN:VEVENT\nRDATE:20240311T090000Z\nEND:VEVENT", "recurring events are not supported"},
+		{"unbalanced", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VEVENT", "unbalanced BEGIN and END"},
+		{"invalid date time", "BEGIN:VEVENT\nDTSTART:2024-03-04\nEND:VEVENT", "invalid date time"},
+		{"invalid duration", "BEGIN:VEVENT\nDTSTART:20240304T090000Z\nDURATION:2 hours\nEND:VEVENT", "invalid duration"},
+		{"invalid content line", "BEGIN:VEVENT\nSUMMARY\nEND:VEVENT", "invalid content line"},
+	}
+	for _, test := range tests {
+		_, err := Parse(strings.NewReader(test.content), nil)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestWriteToFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("Bereitschaft für Überstunden ", 8)
+	cal := NewCalendar("On-call")
+	cal.Events = append(cal.Events, &Event{
+		UID:     "shift-1",
+		Summary: summary,
+		Start:   time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC),
+		End:     time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC),
+	})
+
+	content := cal.String()
+
+	lines := strings.Split(strings.TrimSuffix(content, "\r\n"), "\r\n")
+	folded := 0
+	for _, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("expected at most 75 octets, got %d in %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("expected folding not to split a character, got %q", line)
+		}
+		if strings.HasPrefix(line, " ") {
+			folded++
+		}
+	}
+	if folded == 0 {
+		t.Fatal("expected the summary to be folded")
+	}
+	unfolded, err := unfold(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, line := range unfolded {
+		found = found || line == "SUMMARY:"+summary
+	}
+	if !found {
+		t.Errorf("expected the unfolded summary, got %q", unfolded)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	cal := NewCalendar("SRE; on-call, primary")
+	cal.Events = append(cal.Events,
+		&Event{
+			UID:         "shift-1@ilert-go",
+			Summary:     "On call: Primary (Jane Doe)",
+			Description: "Schedule Primary\nhandover at 09:00",
+			Categories:  []string{"On-call", "Primary, EU"},
+			Start:       time.Date(2024, 3, 30, 8, 0, 0, 0, time.UTC),
+			End:         time.Date(2024, 3, 31, 7, 0, 0, 0, time.UTC),
+			Organizer:   "ops@example.com",
+			Attendees:   []string{"jane@example.com", "john@example.com"},
+		},
+		&Event{
+			UID:    "holiday-1@ilert-go",
+			Start:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+			AllDay: true,
+		},
+	)
+
+	parsed, err := Parse(strings.NewReader(cal.String()), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.Name != cal.Name || len(parsed.Events) != len(cal.Events) {
+		t.Fatalf("expected calendar %q with %d events, got %q with %d", cal.Name, len(cal.Events), parsed.Name, len(parsed.Events))
+	}
+	for i, event := range parsed.Events {
+		assertEvent(t, cal.Events[i], event)
+	}
+}
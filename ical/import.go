@@ -0,0 +1,150 @@
+package ical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// ImportAbsencesInput represents the input of an ImportAbsences operation.
+type ImportAbsencesInput struct {
+	_          struct{}
+	ScheduleID *int64
+	Events     []*Event
+
+	// returns the email of the absent user of an event, events without one are skipped
+	// Default: the first attendee, else the organizer
+	AbsentUser func(event *Event) string
+
+	// returns the user covering the given shift of the absent user, nil skips the shift
+	Substitute func(absent *ilert.User, shift *ilert.Shift) (*ilert.User, error)
+
+	// only computes the overrides without adding them
+	DryRun *bool
+}
+
+// ImportAbsencesOutput represents the output of an ImportAbsences operation.
+type ImportAbsencesOutput struct {
+	_ struct{}
+
+	// overrides added, or that would be added on a dry run
+	Overrides []*ilert.Shift
+
+	// events that did not result in an override
+	Skipped []*Event
+}
+
+// ImportAbsences adds shift overrides for the on-call shifts of users that are absent according to the events,
+// e.g. parsed with Parse from the ICS export of an HR tool. Only the part of a shift during the absence is overridden.
+func ImportAbsences(ctx context.Context, client *ilert.Client, input *ImportAbsencesInput) (*ImportAbsencesOutput, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.ScheduleID == nil {
+		return nil, errors.New("schedule id is required")
+	}
+	if input.Substitute == nil {
+		return nil, errors.New("substitute is required")
+	}
+	absentUser := input.AbsentUser
+	if absentUser == nil {
+		absentUser = defaultAbsentUser
+	}
+	dryRun := input.DryRun != nil && *input.DryRun
+
+	output := &ImportAbsencesOutput{Overrides: make([]*ilert.Shift, 0), Skipped: make([]*Event, 0)}
+	users := make(map[string]*ilert.User)
+	for _, event := range input.Events {
+		email := strings.ToLower(absentUser(event))
+		if email == "" || !event.End.After(event.Start) {
+			output.Skipped = append(output.Skipped, event)
+			continue
+		}
+		absent, ok := users[email]
+		if !ok {
+			result, err := client.SearchUserWithContext(ctx, &ilert.SearchUserInput{UserEmail: ilert.String(email)})
+			if err != nil && !errors.Is(err, ilert.ErrNotFound) {
+				return output, fmt.Errorf("could not find user %s: %w", email, err)
+			}
+			if result != nil {
+				absent = result.User
+			}
+			users[email] = absent
+		}
+		if absent == nil {
+			output.Skipped = append(output.Skipped, event)
+			continue
+		}
+
+		result, err := client.GetScheduleShiftsWithContext(ctx, &ilert.GetScheduleShiftsInput{
+			ScheduleID: input.ScheduleID,
+			From:       ilert.String(event.Start.UTC().Format(time.RFC3339)),
+			Until:      ilert.String(event.End.UTC().Format(time.RFC3339)),
+		})
+		if err != nil {
+			return output, fmt.Errorf("could not get shifts of schedule %d: %w", *input.ScheduleID, err)
+		}
+		shifts := ShiftsOfUser(result.Shifts, absent)
+		sort.SliceStable(shifts, func(i, j int) bool { return shifts[i].Start < shifts[j].Start })
+
+		added := 0
+		for _, shift := range shifts {
+			start, err := ilert.ParseScheduleTime(shift.Start, time.UTC)
+			if err != nil {
+				return output, err
+			}
+			end, err := ilert.ParseScheduleTime(shift.End, time.UTC)
+			if err != nil {
+				return output, err
+			}
+			if event.Start.After(start) {
+				start = event.Start
+			}
+			if event.End.Before(end) {
+				end = event.End
+			}
+			if !end.After(start) {
+				continue
+			}
+			absentShift := &ilert.Shift{User: shift.User, Start: start.UTC().Format(time.RFC3339), End: end.UTC().Format(time.RFC3339)}
+			substitute, err := input.Substitute(absent, absentShift)
+			if err != nil {
+				return output, err
+			}
+			if substitute == nil || ilert.SameUser(*substitute, *absent) {
+				continue
+			}
+			override := &ilert.Shift{User: *substitute, Start: absentShift.Start, End: absentShift.End}
+			if !dryRun {
+				_, err = client.AddScheduleShiftOverrideWithContext(ctx, &ilert.AddScheduleShiftOverrideInput{
+					ScheduleID: input.ScheduleID,
+					Shift:      override,
+				})
+				if err != nil {
+					return output, fmt.Errorf("could not add override %s to %s: %w", override.Start, override.End, err)
+				}
+			}
+			output.Overrides = append(output.Overrides, override)
+			added++
+		}
+		if added == 0 {
+			output.Skipped = append(output.Skipped, event)
+		}
+	}
+	return output, nil
+}
+
+func defaultAbsentUser(event *Event) string {
+	if len(event.Attendees) > 0 {
+		return event.Attendees[0]
+	}
+	return event.Organizer
+}
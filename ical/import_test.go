@@ -0,0 +1,111 @@
+package ical_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ical"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestImportAbsencesDryRun(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	jane, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Email: "jane@example.com", Username: "jane"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	john, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Email: "john@example.com", Username: "john"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := client.CreateSchedule(&ilert.CreateScheduleInput{Schedule: &ilert.Schedule{
+		Name:     "Primary",
+		Timezone: "UTC",
+		Type:     ilert.ScheduleType.Recurring,
+		ScheduleLayers: []ilert.ScheduleLayer{{
+			Name:     "Daily",
+			StartsOn: "2024-03-04T00:00:00Z",
+			Users:    []ilert.User{{ID: jane.User.ID}, {ID: john.User.ID}},
+			Rotation: "P1D",
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:vacation-jane",
+		"DTSTART:20240304T120000Z",
+		"DTEND:20240306T000000Z",
+		"ATTENDEE:mailto:Jane@Example.com",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:vacation-unknown",
+		"DTSTART:20240304T120000Z",
+		"DTEND:20240306T000000Z",
+		"ATTENDEE:mailto:unknown@example.com",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	cal, err := ical.Parse(strings.NewReader(content), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	substitutes := 0
+	dryRun := true
+
+	output, err := ical.ImportAbsences(context.Background(), client, &ical.ImportAbsencesInput{
+		ScheduleID: &schedule.Schedule.ID,
+		Events:     cal.Events,
+		Substitute: func(absent *ilert.User, shift *ilert.Shift) (*ilert.User, error) {
+			substitutes++
+			return john.User, nil
+		},
+		DryRun: &dryRun,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// jane is on call on March 4 and 6, only the afternoon of March 4 is within the absence
+	if substitutes != 1 || len(output.Overrides) != 1 {
+		t.Fatalf("expected 1 override, got %d overrides for %d substitutes", len(output.Overrides), substitutes)
+	}
+	override := output.Overrides[0]
+	if override.User.ID != john.User.ID || override.Start != "2024-03-04T12:00:00Z" || override.End != "2024-03-05T00:00:00Z" {
+		t.Errorf("unexpected override %+v", override)
+	}
+	if len(output.Skipped) != 1 || output.Skipped[0].UID != "vacation-unknown" {
+		t.Errorf("expected the absence of the unknown user to be skipped, got %+v", output.Skipped)
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.Path, "/overrides") {
+			t.Fatalf("expected a dry run not to add overrides, got %s %s", r.Method, r.Path)
+		}
+	}
+
+	output, err = ical.ImportAbsences(context.Background(), client, &ical.ImportAbsencesInput{
+		ScheduleID: &schedule.Schedule.ID,
+		Events:     cal.Events[:1],
+		Substitute: func(absent *ilert.User, shift *ilert.Shift) (*ilert.User, error) {
+			return john.User, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := client.GetScheduleOverrides(&ilert.GetScheduleOverridesInput{ScheduleID: &schedule.Schedule.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Overrides) != 1 || len(overrides.Overrides) != 1 || overrides.Overrides[0].Start != override.Start {
+		t.Errorf("expected the import to add the override of the dry run, got %+v", overrides.Overrides)
+	}
+}
@@ -0,0 +1,398 @@
+package ilerttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// EventResponseCodes defines the response codes returned by the fake events route
+var EventResponseCodes = struct {
+	NewAlertCreated       string
+	EventAddedToAlert     string
+	AlertAccepted         string
+	AlertResolved         string
+	NoOpenAlertWithKey    string
+	InvalidIntegrationKey string
+}{
+	NewAlertCreated:       "NEW_ALERT_CREATED",
+	EventAddedToAlert:     "EVENT_ADDED_TO_ALERT",
+	AlertAccepted:         "ALERT_ACCEPTED",
+	AlertResolved:         "ALERT_RESOLVED",
+	NoOpenAlertWithKey:    "NO_OPEN_ALERT_WITH_KEY",
+	InvalidIntegrationKey: "INVALID_INTEGRATION_KEY",
+}
+
+// Events returns all events received by the events route
+func (s *Server) Events() []ilert.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ilert.Event(nil), s.events...)
+}
+
+// Alerts returns copies of all alerts created by events or seeded
+func (s *Server) Alerts() []ilert.Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	alerts := make([]ilert.Alert, 0, len(s.alerts))
+	for _, alert := range s.alerts {
+		alerts = append(alerts, *alert)
+	}
+	return alerts
+}
+
+// SeedAlert stores an alert and returns its id, a missing id is generated
+func (s *Server) SeedAlert(alert ilert.Alert) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if alert.ID == 0 {
+		alert.ID = s.generateID()
+	}
+	if alert.Status == "" {
+		alert.Status = ilert.AlertStatuses.Pending
+	}
+	if alert.ReportTime == "" {
+		alert.ReportTime = s.now().UTC().Format(time.RFC3339)
+	}
+	s.alerts = append(s.alerts, &alert)
+	return alert.ID
+}
+
+// HeartbeatPings returns the number of pings received for the given heartbeat integration key
+func (s *Server) HeartbeatPings(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pings[key]
+}
+
+// Series returns all series ingested for the given metric integration key
+func (s *Server) Series(key string) []ilert.SingleSeries {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ilert.SingleSeries(nil), s.series[key]...)
+}
+
+// alertSourceByKey returns the seeded alert source with the given integration key
+func (s *Server) alertSourceByKey(key string) (*ilert.AlertSource, bool) {
+	c, ok := s.collections["/api/alert-sources"]
+	if !ok || len(c.ids) == 0 {
+		return nil, true
+	}
+	item := c.findBy("integrationKey", key)
+	if item == nil {
+		return nil, false
+	}
+	alertSource := &ilert.AlertSource{}
+	if err := remarshal(item, alertSource); err != nil {
+		return nil, false
+	}
+	return alertSource, true
+}
+
+func (s *Server) openAlert(apiKey string, alertKey string) *ilert.Alert {
+	if alertKey == "" {
+		return nil
+	}
+	for _, alert := range s.alerts {
+		if alert.AlertKey == alertKey && alert.Status != ilert.AlertStatuses.Resolved && s.alertAPIKey(alert) == apiKey {
+			return alert
+		}
+	}
+	return nil
+}
+
+func (s *Server) alertAPIKey(alert *ilert.Alert) string {
+	if alert.AlertSource == nil {
+		return ""
+	}
+	return alert.AlertSource.IntegrationKey
+}
+
+// handleEvent implements the events route: ALERT events open or append to alerts, ACCEPT and RESOLVE events
+// transition the open alert with the same alert key
+func (s *Server) handleEvent(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+		return
+	}
+	event := ilert.Event{}
+	if err := decodeJSON(body, &event); err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	if event.APIKey == "" {
+		writeBadRequest(w, "apiKey is required")
+		return
+	}
+	alertSource, ok := s.alertSourceByKey(event.APIKey)
+	if !ok {
+		writeError(w, http.StatusBadRequest, EventResponseCodes.InvalidIntegrationKey, "No alert source with this integration key")
+		return
+	}
+	if alertSource == nil {
+		alertSource = &ilert.AlertSource{IntegrationKey: event.APIKey}
+	}
+	s.events = append(s.events, event)
+
+	alert := s.openAlert(event.APIKey, event.AlertKey)
+	responseCode := ""
+	switch event.EventType {
+	case ilert.EventTypes.Alert:
+		if alert != nil {
+			responseCode = EventResponseCodes.EventAddedToAlert
+			break
+		}
+		if event.Summary == "" {
+			writeBadRequest(w, "summary is required")
+			return
+		}
+		alert = &ilert.Alert{
+			ID:            s.generateID(),
+			Summary:       event.Summary,
+			Details:       event.Details,
+			ReportTime:    s.now().UTC().Format(time.RFC3339),
+			Status:        ilert.AlertStatuses.Pending,
+			AlertSource:   alertSource,
+			Priority:      event.Priority,
+			AlertKey:      event.AlertKey,
+			Images:        event.Images,
+			Links:         event.Links,
+			CustomDetails: event.CustomDetails,
+		}
+		if alert.Priority == "" {
+			alert.Priority = ilert.AlertPriorities.High
+		}
+		if alert.AlertKey == "" {
+			alert.AlertKey = strconv.FormatInt(alert.ID, 10)
+		}
+		s.alerts = append(s.alerts, alert)
+		responseCode = EventResponseCodes.NewAlertCreated
+	case ilert.EventTypes.Accept, ilert.EventTypes.Resolve:
+		if alert == nil {
+			writeError(w, http.StatusBadRequest, EventResponseCodes.NoOpenAlertWithKey, fmt.Sprintf("No open alert with key %s", event.AlertKey))
+			return
+		}
+		if event.EventType == ilert.EventTypes.Accept {
+			alert.Status = ilert.AlertStatuses.Accepted
+			responseCode = EventResponseCodes.AlertAccepted
+		} else {
+			alert.Status = ilert.AlertStatuses.Resolved
+			alert.ResolvedOn = s.now().UTC().Format(time.RFC3339)
+			responseCode = EventResponseCodes.AlertResolved
+		}
+	default:
+		writeBadRequest(w, fmt.Sprintf("invalid event type %s", event.EventType))
+		return
+	}
+
+	s.addLogEntry(alert, ilert.AlertLogEntryTypes.AlertReceivedLogEntry, fmt.Sprintf("%s event received: %s", event.EventType, event.Summary))
+	writeJSON(w, http.StatusAccepted, &ilert.EventResponse{
+		AlertKey:     alert.AlertKey,
+		AlertURL:     fmt.Sprintf("%s/alert/view.jsf?id=%d", s.URL, alert.ID),
+		ResponseCode: responseCode,
+	})
+}
+
+func (s *Server) addLogEntry(alert *ilert.Alert, logEntryType string, text string) {
+	s.logEntries[alert.ID] = append(s.logEntries[alert.ID], &ilert.AlertLogEntry{
+		ID:           s.generateID(),
+		Timestamp:    s.now().UTC().Format(time.RFC3339),
+		LogEntryType: logEntryType,
+		Text:         text,
+		AlertID:      alert.ID,
+	})
+}
+
+// handleAlerts implements the alert routes, sub is the path below /api/alerts
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request, sub string) {
+	segments := strings.Split(strings.TrimPrefix(sub, "/"), "/")
+	if sub == "" || sub == "/count" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+			return
+		}
+		alerts := s.filterAlerts(r)
+		if sub == "/count" {
+			writeJSON(w, http.StatusOK, &ilert.GenericCountResponse{Count: len(alerts)})
+			return
+		}
+		writeJSON(w, http.StatusOK, paginate(alerts, r))
+		return
+	}
+
+	alertID, err := strconv.ParseInt(segments[0], 10, 64)
+	if err != nil {
+		writeBadRequest(w, fmt.Sprintf("invalid alert id %s", segments[0]))
+		return
+	}
+	var alert *ilert.Alert
+	for _, a := range s.alerts {
+		if a.ID == alertID {
+			alert = a
+		}
+	}
+	if alert == nil {
+		writeNotFound(w, "/api/alerts/"+segments[0])
+		return
+	}
+
+	action := ""
+	if len(segments) > 1 {
+		action = segments[1]
+	}
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, alert)
+	case action == "log-entries" && r.Method == http.MethodGet:
+		entries := s.logEntries[alert.ID]
+		if entries == nil {
+			entries = []*ilert.AlertLogEntry{}
+		}
+		writeJSON(w, http.StatusOK, entries)
+	case action == "suggested-responders" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, []*ilert.AlertResponder{})
+	case action == "accept" && r.Method == http.MethodPut:
+		if alert.Status == ilert.AlertStatuses.Resolved {
+			writeBadRequest(w, "alert is already resolved")
+			return
+		}
+		alert.Status = ilert.AlertStatuses.Accepted
+		s.addLogEntry(alert, ilert.AlertLogEntryTypes.UserResponseLogEntry, "Alert accepted")
+		writeJSON(w, http.StatusOK, alert)
+	case action == "resolve" && r.Method == http.MethodPut:
+		alert.Status = ilert.AlertStatuses.Resolved
+		alert.ResolvedOn = s.now().UTC().Format(time.RFC3339)
+		s.addLogEntry(alert, ilert.AlertLogEntryTypes.UserResponseLogEntry, "Alert resolved")
+		writeJSON(w, http.StatusOK, alert)
+	case action == "assign" && r.Method == http.MethodPut:
+		s.assignAlert(w, r, alert)
+	default:
+		writeNotFound(w, "/api/alerts"+sub)
+	}
+}
+
+func (s *Server) assignAlert(w http.ResponseWriter, r *http.Request, alert *ilert.Alert) {
+	q := r.URL.Query()
+	userID := q.Get("user-id")
+	if userID == "" {
+		if q.Get("policy-id") == "" && q.Get("schedule-id") == "" {
+			writeBadRequest(w, "one of assignments is required")
+			return
+		}
+		s.addLogEntry(alert, ilert.AlertLogEntryTypes.AlertAssignedByUserLogEntry, "Alert reassigned")
+		writeJSON(w, http.StatusOK, alert)
+		return
+	}
+	users := s.collection("/api/users")
+	item, ok := users.items[userID]
+	if !ok {
+		item = users.findBy("username", userID)
+	}
+	if item == nil {
+		writeNotFound(w, "/api/users/"+userID)
+		return
+	}
+	user := &ilert.User{}
+	if err := remarshal(item, user); err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	alert.AssignedTo = user
+	s.addLogEntry(alert, ilert.AlertLogEntryTypes.AlertAssignedByUserLogEntry, fmt.Sprintf("Alert assigned to %s", user.GetUsername()))
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) filterAlerts(r *http.Request) []*ilert.Alert {
+	q := r.URL.Query()
+	states := q["state"]
+	sources := q["alert-source"]
+	assignees := q["assigned-to"]
+	from, _ := time.Parse(time.RFC3339, q.Get("from"))
+	until, _ := time.Parse(time.RFC3339, q.Get("until"))
+
+	alerts := make([]*ilert.Alert, 0)
+	for _, alert := range s.alerts {
+		if len(states) > 0 && !contains(states, alert.Status) {
+			continue
+		}
+		if len(sources) > 0 && (alert.AlertSource == nil || !contains(sources, strconv.FormatInt(alert.AlertSource.ID, 10))) {
+			continue
+		}
+		if len(assignees) > 0 && (alert.AssignedTo == nil ||
+			!(contains(assignees, strconv.FormatInt(alert.AssignedTo.ID, 10)) || contains(assignees, alert.AssignedTo.Username))) {
+			continue
+		}
+		reportTime, err := time.Parse(time.RFC3339, alert.ReportTime)
+		if err == nil && !from.IsZero() && reportTime.Before(from) {
+			continue
+		}
+		if err == nil && !until.IsZero() && reportTime.After(until) {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// handleHeartbeat implements the heartbeat ping route
+func (s *Server) handleHeartbeat(w http.ResponseWriter, r *http.Request, key string) {
+	if r.Method != http.MethodHead && r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+		return
+	}
+	if c, ok := s.collections["/api/heartbeat-monitors"]; ok && len(c.ids) > 0 && c.findBy("integrationKey", key) == nil {
+		writeNotFound(w, "/api/heartbeats/"+key)
+		return
+	}
+	s.pings[key]++
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleSeries implements the series ingestion route for single and multiple series
+func (s *Server) handleSeries(w http.ResponseWriter, r *http.Request, key string, body []byte) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+		return
+	}
+	if c, ok := s.collections["/api/metrics"]; ok && len(c.ids) > 0 && c.findBy("integrationKey", key) == nil {
+		writeNotFound(w, "/api/series/"+key)
+		return
+	}
+	multiple := &ilert.MultipleSeries{}
+	if err := decodeJSON(body, multiple); err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	if multiple.Series == nil {
+		single := ilert.SingleSeries{}
+		if err := decodeJSON(body, &single); err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		multiple.Series = []ilert.SingleSeries{single}
+	}
+	s.series[key] = append(s.series[key], multiple.Series...)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func remarshal(in interface{}, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package ilerttest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// scheduleSubRoutes defines the schedule routes that are not plain resource routes
+var scheduleSubRoutes = []string{"shifts", "overrides", "user-on-call"}
+
+func isScheduleSubRoute(path string) bool {
+	segments := strings.Split(strings.TrimPrefix(path, "/api/schedules/"), "/")
+	return len(segments) == 2 && contains(scheduleSubRoutes, segments[1])
+}
+
+// handleScheduleSubRoute implements the shift, override and on-call routes of a schedule.
+// Shifts are rendered from the schedule's layers or shifts and its overrides using ilert.RenderSchedule.
+func (s *Server) handleScheduleSubRoute(w http.ResponseWriter, r *http.Request, path string, body []byte) {
+	segments := strings.Split(strings.TrimPrefix(path, "/api/schedules/"), "/")
+	schedules := s.collection("/api/schedules")
+	item, ok := schedules.items[segments[0]]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+	schedule := &ilert.Schedule{}
+	if err := remarshal(item, schedule); err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	overridesRoute := fmt.Sprintf("/api/schedules/%s/overrides", segments[0])
+	overrides := s.collection(overridesRoute)
+
+	switch {
+	case segments[1] == "overrides" && r.Method == http.MethodGet:
+		shifts := make([]*ilert.Shift, 0)
+		for _, o := range overrides.all() {
+			shift := &ilert.Shift{}
+			if err := remarshal(o, shift); err == nil {
+				shifts = append(shifts, shift)
+			}
+		}
+		writeJSON(w, http.StatusOK, shifts)
+	case segments[1] == "overrides" && r.Method == http.MethodPost:
+		shift := make(map[string]interface{})
+		if err := decodeJSON(body, &shift); err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		s.create(overridesRoute, shift)
+		writeJSON(w, http.StatusOK, item)
+	case segments[1] == "shifts" && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		from, err := time.Parse(time.RFC3339, q.Get("from"))
+		if err != nil {
+			from = s.now()
+		}
+		until, err := time.Parse(time.RFC3339, q.Get("until"))
+		if err != nil {
+			until = from.AddDate(0, 0, 7)
+		}
+		shifts, err := s.scheduleShifts(schedule, overrides, q.Get("exclude-overrides") != "true", from, until)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, shifts)
+	case segments[1] == "user-on-call" && r.Method == http.MethodGet:
+		now := s.now()
+		shifts, err := s.scheduleShifts(schedule, overrides, true, now, now.AddDate(0, 1, 0))
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		if len(shifts) == 0 || !shiftStartsAt(shifts[0], now) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, shifts[0])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+	}
+}
+
+// scheduleShifts renders the shifts of a schedule within the window
+func (s *Server) scheduleShifts(schedule *ilert.Schedule, overrides *collection, includeOverrides bool, from time.Time, until time.Time) ([]ilert.Shift, error) {
+	shifts := make([]ilert.Shift, 0)
+	if includeOverrides {
+		for _, o := range overrides.all() {
+			shift := ilert.Shift{}
+			if err := remarshal(o, &shift); err == nil {
+				shifts = append(shifts, shift)
+			}
+		}
+	}
+	return ilert.RenderSchedule(schedule, from, until, shifts...)
+}
+
+// shiftStartsAt reports whether a shift starts at the given time
+func shiftStartsAt(shift ilert.Shift, t time.Time) bool {
+	start, err := time.Parse(time.RFC3339, shift.Start)
+	return err == nil && start.Equal(t.Truncate(time.Second))
+}
@@ -0,0 +1,269 @@
+// Package ilerttest provides an in-process fake of the iLert API for testing code built on ilert-go.
+//
+// The fake keeps all resources in memory and answers with the status codes and error bodies of the real API,
+// so it can be used with any client created via ilert.WithAPIEndpoint:
+//
+//	srv := ilerttest.NewServer()
+//	defer srv.Close()
+//	client := srv.Client()
+package ilerttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// Server is an in-process fake of the iLert API backed by in-memory state
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	now         func() time.Time
+	apiToken    string
+	collections map[string]*collection
+	alerts      []*ilert.Alert
+	logEntries  map[int64][]*ilert.AlertLogEntry
+	events      []ilert.Event
+	pings       map[string]int
+	series      map[string][]ilert.SingleSeries
+	faults      []*fault
+	requests    []Request
+	nextID      int64
+}
+
+// Request describes a request received by the fake server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// ServerOptions allows for options to be passed into the Server for customization
+type ServerOptions func(*Server)
+
+// WithAPIToken requires every request to carry the given api token as bearer authorization,
+// requests without it are answered with 401 Unauthorized
+func WithAPIToken(apiToken string) ServerOptions {
+	return func(s *Server) {
+		s.apiToken = apiToken
+	}
+}
+
+// WithClock replaces the clock used for timestamps and on-call lookups
+func WithClock(now func() time.Time) ServerOptions {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a new fake iLert API server, it must be closed by the caller
+func NewServer(options ...ServerOptions) *Server {
+	s := &Server{
+		now:         time.Now,
+		collections: make(map[string]*collection),
+		logEntries:  make(map[int64][]*ilert.AlertLogEntry),
+		pings:       make(map[string]int),
+		series:      make(map[string][]ilert.SingleSeries),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client creates an API client that talks to the fake server.
+// Retries are disabled so that injected errors surface immediately, pass ilert.WithRetry to enable them again.
+func (s *Server) Client(options ...ilert.ClientOptions) *ilert.Client {
+	apiToken := s.apiToken
+	if apiToken == "" {
+		apiToken = "ilerttest"
+	}
+	opts := []ilert.ClientOptions{
+		ilert.WithAPIEndpoint(s.URL),
+		ilert.WithAPIToken(apiToken),
+		ilert.WithRetry(0, 0, 0),
+	}
+	return ilert.NewClient(append(opts, options...)...)
+}
+
+// Requests returns all requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset drops all resources, recorded requests and injected errors
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections = make(map[string]*collection)
+	s.alerts = nil
+	s.logEntries = make(map[int64][]*ilert.AlertLogEntry)
+	s.events = nil
+	s.pings = make(map[string]int)
+	s.series = make(map[string][]ilert.SingleSeries)
+	s.faults = nil
+	s.requests = nil
+}
+
+// fault describes an injected error response
+type fault struct {
+	method    string
+	path      string
+	status    int
+	code      string
+	message   string
+	header    http.Header
+	remaining int
+}
+
+// InjectError makes the next times requests matching method and path prefix fail with the given status and error code.
+// An empty method matches any method, times <= 0 fails forever.
+func (s *Server) InjectError(method string, pathPrefix string, status int, code string, times int) {
+	s.injectFault(&fault{
+		method:    method,
+		path:      pathPrefix,
+		status:    status,
+		code:      code,
+		message:   http.StatusText(status),
+		header:    http.Header{},
+		remaining: times,
+	})
+}
+
+// InjectRateLimit makes the next times requests matching the path prefix fail with 429 Too Many Requests
+// and the given Retry-After value, rounded up to whole seconds.
+func (s *Server) InjectRateLimit(pathPrefix string, retryAfter time.Duration, times int) {
+	header := http.Header{}
+	header.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	s.injectFault(&fault{
+		path:      pathPrefix,
+		status:    http.StatusTooManyRequests,
+		code:      "TOO_MANY_REQUESTS",
+		message:   "Rate limit exceeded",
+		header:    header,
+		remaining: times,
+	})
+}
+
+func (s *Server) injectFault(f *fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, f)
+}
+
+// takeFault returns the first injected fault matching the request, if any
+func (s *Server) takeFault(method string, path string) *fault {
+	for i, f := range s.faults {
+		if f.method != "" && f.method != method {
+			continue
+		}
+		if !strings.HasPrefix(path, f.path) {
+			continue
+		}
+		if f.remaining > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// apiError is the error body parsed by the client
+type apiError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&apiError{Status: status, Code: code, Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeNotFound(w http.ResponseWriter, path string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Resource %s not found", path))
+}
+
+func writeBadRequest(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusBadRequest, "BAD_REQUEST", message)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := readBody(r)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+	w.Header().Set("X-Request-Id", fmt.Sprintf("ilerttest-%d", len(s.requests)))
+
+	if f := s.takeFault(r.Method, r.URL.Path); f != nil {
+		for key, values := range f.header {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		writeError(w, f.status, f.code, f.message)
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case strings.HasPrefix(path, "/api/heartbeats/"):
+		s.handleHeartbeat(w, r, strings.TrimPrefix(path, "/api/heartbeats/"))
+		return
+	case path == "/api/events":
+		s.handleEvent(w, r, body)
+		return
+	}
+
+	if s.apiToken != "" && r.Header.Get("Authorization") != "Bearer "+s.apiToken {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Missing or invalid api token")
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(path, "/api/series/"):
+		s.handleSeries(w, r, strings.TrimPrefix(path, "/api/series/"), body)
+	case path == "/api/alerts" || strings.HasPrefix(path, "/api/alerts/"):
+		s.handleAlerts(w, r, strings.TrimPrefix(path, "/api/alerts"))
+	case strings.HasPrefix(path, "/api/schedules/") && isScheduleSubRoute(path):
+		s.handleScheduleSubRoute(w, r, path, body)
+	case strings.HasPrefix(path, "/api/"):
+		s.handleResource(w, r, path, body)
+	default:
+		writeNotFound(w, path)
+	}
+}
+
+func (s *Server) generateID() int64 {
+	s.nextID++
+	return s.nextID
+}
@@ -0,0 +1,140 @@
+package ilerttest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestInjectRateLimitRoundsUpRetryAfter(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		want       string
+	}{
+		{0, "0"},
+		{200 * time.Millisecond, "1"},
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+	}
+	for _, test := range tests {
+		srv := ilerttest.NewServer()
+		srv.InjectRateLimit("/api/teams", test.retryAfter, 1)
+
+		res, err := http.Get(srv.URL + "/api/teams")
+		if err != nil {
+			srv.Close()
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		srv.Close()
+
+		if res.StatusCode != http.StatusTooManyRequests || res.Header.Get("Retry-After") != test.want {
+			t.Errorf("%s: expected 429 with Retry-After %s, got %d with %q", test.retryAfter, test.want, res.StatusCode, res.Header.Get("Retry-After"))
+		}
+	}
+}
+
+func TestInjectErrorTimes(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	srv.InjectError(http.MethodGet, "/api/teams", http.StatusNotFound, "NOT_FOUND", 2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTeams(&ilert.GetTeamsInput{}); !errors.Is(err, ilert.ErrNotFound) {
+			t.Fatalf("request %d: expected not found, got %v", i, err)
+		}
+	}
+	if _, err := client.GetTeams(&ilert.GetTeamsInput{}); err != nil {
+		t.Fatalf("expected the fault to be used up, got %v", err)
+	}
+	if _, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: "ops"}}); err != nil {
+		t.Fatalf("expected other methods not to fail, got %v", err)
+	}
+}
+
+func TestAPIToken(t *testing.T) {
+	srv := ilerttest.NewServer(ilerttest.WithAPIToken("secret"))
+	defer srv.Close()
+
+	if _, err := srv.Client().GetTeams(&ilert.GetTeamsInput{}); err != nil {
+		t.Fatalf("expected the server client to be authorized, got %v", err)
+	}
+	if _, err := srv.Client(ilert.WithAPIToken("other")).GetTeams(&ilert.GetTeamsInput{}); !errors.Is(err, ilert.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized, got %v", err)
+	}
+}
+
+func TestResourcesAndRequests(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	created, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: "ops"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Team.ID == 0 {
+		t.Fatal("expected the created team to get an id")
+	}
+	for _, name := range []string{"dev", "sre"} {
+		if _, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: name}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := client.GetTeams(&ilert.GetTeamsInput{StartIndex: ilert.Int(1), MaxResults: ilert.Int(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Teams) != 1 || page.Teams[0].Name != "dev" {
+		t.Fatalf("expected the second team, got %+v", page.Teams)
+	}
+
+	if _, err := client.DeleteTeam(&ilert.DeleteTeamInput{TeamID: &created.Team.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTeam(&ilert.GetTeamInput{TeamID: &created.Team.ID}); !errors.Is(err, ilert.ErrNotFound) {
+		t.Fatalf("expected the deleted team not to be found, got %v", err)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 6 || requests[3].Method != http.MethodGet || requests[3].Query != "max-results=1&start-index=1" {
+		t.Fatalf("unexpected requests %+v", requests)
+	}
+
+	srv.Reset()
+	if len(srv.Requests()) != 0 || len(srv.Resources("/api/teams")) != 0 {
+		t.Fatal("expected reset to drop resources and requests")
+	}
+}
+
+func TestEventsCreateAndResolveAlerts(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	apiKey := "db-key"
+	if _, err := srv.Seed("/api/alert-sources", &ilert.AlertSource{Name: "db", IntegrationType: "API", IntegrationKey: apiKey}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateEvent(&ilert.CreateEventInput{Event: &ilert.Event{APIKey: apiKey, EventType: ilert.EventTypes.Alert, Summary: "db down", AlertKey: "db-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateEvent(&ilert.CreateEventInput{Event: &ilert.Event{APIKey: apiKey, EventType: ilert.EventTypes.Resolve, AlertKey: "db-1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateEvent(&ilert.CreateEventInput{Event: &ilert.Event{APIKey: "unknown", EventType: ilert.EventTypes.Alert, Summary: "db down"}}); err == nil {
+		t.Fatal("expected an unknown integration key to be rejected")
+	}
+
+	alerts := srv.Alerts()
+	if len(srv.Events()) != 2 || len(alerts) != 1 || alerts[0].Status != ilert.AlertStatuses.Resolved {
+		t.Fatalf("expected one resolved alert, got %+v", alerts)
+	}
+}
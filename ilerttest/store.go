@@ -0,0 +1,345 @@
+package ilerttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// stringIDRoutes defines routes whose resources are identified by string ids
+var stringIDRoutes = map[string]bool{
+	"/api/alert-actions":    true,
+	"/api/automation-rules": true,
+	"/api/connectors":       true,
+	"/api/v1/connections":   true,
+}
+
+// listRoutes defines routes that hold a plain list instead of identifiable resources
+var listRoutes = map[string]bool{
+	"private-subscribers": true,
+}
+
+// collection holds the resources of a single route in insertion order
+type collection struct {
+	stringIDs bool
+	ids       []string
+	items     map[string]map[string]interface{}
+	list      []interface{}
+}
+
+func newCollection(route string) *collection {
+	return &collection{
+		stringIDs: stringIDRoutes[route],
+		items:     make(map[string]map[string]interface{}),
+	}
+}
+
+func (c *collection) all() []map[string]interface{} {
+	all := make([]map[string]interface{}, 0, len(c.ids))
+	for _, id := range c.ids {
+		all = append(all, c.items[id])
+	}
+	return all
+}
+
+func (c *collection) put(id string, item map[string]interface{}) {
+	if _, ok := c.items[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = item
+}
+
+func (c *collection) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, v := range c.ids {
+		if v == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (c *collection) findBy(field string, value string) map[string]interface{} {
+	for _, item := range c.all() {
+		if fmt.Sprint(item[field]) == value {
+			return item
+		}
+	}
+	return nil
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	defer r.Body.Close()
+	return io.ReadAll(r.Body)
+}
+
+func decodeJSON(body []byte, out interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errors.New("request body is required")
+	}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(out); err != nil {
+		return fmt.Errorf("invalid request body: %s", err.Error())
+	}
+	return nil
+}
+
+// collection returns the collection of the given route, creating it if required
+func (s *Server) collection(route string) *collection {
+	c, ok := s.collections[route]
+	if !ok {
+		c = newCollection(route)
+		s.collections[route] = c
+	}
+	return c
+}
+
+// isID reports whether the last segment of a path identifies a resource of its parent route
+func (s *Server) isID(parent string, segment string) bool {
+	if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+		return true
+	}
+	if stringIDRoutes[parent] {
+		return true
+	}
+	if c, ok := s.collections[parent]; ok {
+		_, ok := c.items[segment]
+		return ok
+	}
+	return false
+}
+
+// Seed stores a resource under the given api route (e.g. "/api/teams") and returns its id.
+// A missing id is generated like the server does for created resources.
+func (s *Server) Seed(route string, resource interface{}) (string, error) {
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return "", err
+	}
+	item := make(map[string]interface{})
+	if err := decodeJSON(body, &item); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.create(route, item), nil
+}
+
+// Resources returns the raw resources stored under the given api route
+func (s *Server) Resources(route string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.collections[route]; ok {
+		return c.all()
+	}
+	return []map[string]interface{}{}
+}
+
+func (s *Server) create(route string, item map[string]interface{}) string {
+	c := s.collection(route)
+	id := ""
+	if v, ok := item["id"]; ok && v != nil && fmt.Sprint(v) != "" && fmt.Sprint(v) != "0" {
+		id = fmt.Sprint(v)
+	} else if c.stringIDs {
+		id = fmt.Sprintf("%016x", s.generateID())
+		item["id"] = id
+	} else {
+		n := s.generateID()
+		id = strconv.FormatInt(n, 10)
+		item["id"] = n
+	}
+	c.put(id, item)
+	return id
+}
+
+// handleResource implements the generic create, list, get, search, update and delete routes
+func (s *Server) handleResource(w http.ResponseWriter, r *http.Request, route string, body []byte) {
+	parent, last := path.Split(route)
+	parent = strings.TrimSuffix(parent, "/")
+
+	if listRoutes[last] {
+		s.handleList(w, r, route, body)
+		return
+	}
+
+	if path.Base(parent) == "name" {
+		s.handleSearch(w, r, strings.TrimSuffix(parent, "/name"), "name", last)
+		return
+	}
+
+	switch last {
+	case "current":
+		if parent == "/api/users" {
+			s.handleCurrentUser(w, r, body)
+			return
+		}
+	case "count":
+		if r.Method == http.MethodGet {
+			writeJSON(w, http.StatusOK, map[string]int{"count": len(s.collection(parent).ids)})
+			return
+		}
+	case "search-email":
+		s.handleSearchBody(w, r, parent, "email", "email", body)
+		return
+	case "search-target":
+		s.handleSearchBody(w, r, parent, "target", "target", body)
+		return
+	}
+
+	if s.isID(parent, last) {
+		s.handleItem(w, r, parent, last, body)
+		return
+	}
+
+	c := s.collection(route)
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, paginate(c.all(), r))
+	case http.MethodPost:
+		item := make(map[string]interface{})
+		if err := decodeJSON(body, &item); err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		delete(item, "id")
+		s.create(route, item)
+		status := http.StatusCreated
+		if route == "/api/incidents" {
+			status = http.StatusOK
+		}
+		writeJSON(w, status, item)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+	}
+}
+
+func (s *Server) handleItem(w http.ResponseWriter, r *http.Request, route string, id string, body []byte) {
+	c := s.collection(route)
+	item, ok := c.items[id]
+	if !ok {
+		writeNotFound(w, route+"/"+id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, item)
+	case http.MethodPut:
+		updated := make(map[string]interface{})
+		if err := decodeJSON(body, &updated); err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		updated["id"] = item["id"]
+		c.put(id, updated)
+		writeJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		c.remove(id)
+		for key := range s.collections {
+			if strings.HasPrefix(key, route+"/"+id+"/") {
+				delete(s.collections, key)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+	}
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request, route string, field string, value string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+		return
+	}
+	item := s.collection(route).findBy(field, value)
+	if item == nil {
+		writeNotFound(w, route+"/name/"+value)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleSearchBody(w http.ResponseWriter, r *http.Request, route string, bodyField string, field string, body []byte) {
+	query := make(map[string]interface{})
+	if err := decodeJSON(body, &query); err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	value := fmt.Sprint(query[bodyField])
+	item := s.collection(route).findBy(field, value)
+	if item == nil {
+		writeNotFound(w, route)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleCurrentUser(w http.ResponseWriter, r *http.Request, body []byte) {
+	users := s.collection("/api/users")
+	if len(users.ids) == 0 {
+		writeNotFound(w, "/api/users/current")
+		return
+	}
+	s.handleItem(w, r, "/api/users", users.ids[0], body)
+}
+
+// handleList implements routes holding a plain list, e.g. private subscribers
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request, route string, body []byte) {
+	c := s.collection(route)
+	switch r.Method {
+	case http.MethodGet:
+		list := c.list
+		if list == nil {
+			list = []interface{}{}
+		}
+		writeJSON(w, http.StatusOK, list)
+	case http.MethodPost, http.MethodPut:
+		items := make([]interface{}, 0)
+		if err := decodeJSON(body, &items); err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		if r.Method == http.MethodPost {
+			c.list = append(c.list, items...)
+		} else {
+			c.list = items
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodDelete:
+		c.list = nil
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Method %s not allowed", r.Method))
+	}
+}
+
+// paginate applies the start-index and max-results query parameters to a list
+func paginate[T any](items []T, r *http.Request) []T {
+	q := r.URL.Query()
+	start, _ := strconv.Atoi(q.Get("start-index"))
+	if start < 0 {
+		start = 0
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	end := len(items)
+	if max, err := strconv.Atoi(q.Get("max-results")); err == nil && max >= 0 && start+max < end {
+		end = start + max
+	}
+	return items[start:end]
+}
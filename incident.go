@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -119,13 +120,18 @@ type CreateIncidentOutput struct {
 
 // CreateIncident creates a new incident. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents/post
 func (c *Client) CreateIncident(input *CreateIncidentInput) (*CreateIncidentOutput, error) {
+	return c.CreateIncidentWithContext(context.Background(), input)
+}
+
+// CreateIncidentWithContext is the same as CreateIncident with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateIncidentWithContext(ctx context.Context, input *CreateIncidentInput) (*CreateIncidentOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.Incident == nil {
 		return nil, errors.New("incident input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Incident).Post(apiRoutes.incidents)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Incident).Post(apiRoutes.incidents)
 	if err != nil {
 		return nil, err
 	}
@@ -177,6 +183,11 @@ type GetIncidentsOutput struct {
 
 // GetIncidents lists existing incidents. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents/get
 func (c *Client) GetIncidents(input *GetIncidentsInput) (*GetIncidentsOutput, error) {
+	return c.GetIncidentsWithContext(context.Background(), input)
+}
+
+// GetIncidentsWithContext is the same as GetIncidents with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetIncidentsWithContext(ctx context.Context, input *GetIncidentsInput) (*GetIncidentsOutput, error) {
 	if input == nil {
 		input = &GetIncidentsInput{}
 	}
@@ -212,7 +223,7 @@ func (c *Client) GetIncidents(input *GetIncidentsInput) (*GetIncidentsOutput, er
 		q.Add("until", *input.Until)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.incidents, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.incidents, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -229,6 +240,36 @@ func (c *Client) GetIncidents(input *GetIncidentsInput) (*GetIncidentsOutput, er
 	return &GetIncidentsOutput{Incidents: incidents}, nil
 }
 
+// GetIncidentsPages iterates over all pages of a GetIncidents operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetIncidentsPages(input *GetIncidentsInput, fn func(page *GetIncidentsOutput) bool, options ...PaginateOption) error {
+	return c.GetIncidentsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetIncidentsPagesWithContext is the same as GetIncidentsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetIncidentsPagesWithContext(ctx context.Context, input *GetIncidentsInput, fn func(page *GetIncidentsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetIncidentsInput{}
+	}
+	maxPageSize := 100
+	if len(input.Include) > 0 {
+		maxPageSize = 25
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*Incident, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetIncidentsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Incidents, nil
+	}
+	return Paginate(ctx, fetch, func(page []*Incident) bool {
+		return fn(&GetIncidentsOutput{Incidents: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, maxPageSize, options)...)
+}
+
 // GetIncidentInput represents the input of a GetIncident operation.
 type GetIncidentInput struct {
 	_          struct{}
@@ -247,6 +288,11 @@ type GetIncidentOutput struct {
 
 // GetIncident gets an incident by id. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}/get
 func (c *Client) GetIncident(input *GetIncidentInput) (*GetIncidentOutput, error) {
+	return c.GetIncidentWithContext(context.Background(), input)
+}
+
+// GetIncidentWithContext is the same as GetIncident with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetIncidentWithContext(ctx context.Context, input *GetIncidentInput) (*GetIncidentOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -262,7 +308,7 @@ func (c *Client) GetIncident(input *GetIncidentInput) (*GetIncidentOutput, error
 
 	var url = fmt.Sprintf("%s/%d?%s", apiRoutes.incidents, *input.IncidentID, q.Encode())
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -299,6 +345,11 @@ type GetIncidentSubscribersOutput struct {
 
 // GetIncidentSubscribers gets subscribers of an incident by id. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}~1private-subscribers/get
 func (c *Client) GetIncidentSubscribers(input *GetIncidentSubscribersInput) (*GetIncidentSubscribersOutput, error) {
+	return c.GetIncidentSubscribersWithContext(context.Background(), input)
+}
+
+// GetIncidentSubscribersWithContext is the same as GetIncidentSubscribers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetIncidentSubscribersWithContext(ctx context.Context, input *GetIncidentSubscribersInput) (*GetIncidentSubscribersOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -308,7 +359,7 @@ func (c *Client) GetIncidentSubscribers(input *GetIncidentSubscribersInput) (*Ge
 
 	var url = fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.incidents, *input.IncidentID)
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -339,6 +390,11 @@ type GetIncidentAffectedOutput struct {
 
 // GetIncidentAffected forecasts the affected subscribers and status pages. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1publish-info/post
 func (c *Client) GetIncidentAffected(input *GetIncidentAffectedInput) (*GetIncidentAffectedOutput, error) {
+	return c.GetIncidentAffectedWithContext(context.Background(), input)
+}
+
+// GetIncidentAffectedWithContext is the same as GetIncidentAffected with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetIncidentAffectedWithContext(ctx context.Context, input *GetIncidentAffectedInput) (*GetIncidentAffectedOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -348,7 +404,7 @@ func (c *Client) GetIncidentAffected(input *GetIncidentAffectedInput) (*GetIncid
 
 	url := fmt.Sprintf("%s/publish-info", apiRoutes.incidents)
 
-	resp, err := c.httpClient.R().SetBody(input.Incident).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Incident).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -379,6 +435,11 @@ type AddIncidentSubscribersOutput struct {
 
 // AddIncidentSubscribers adds a new subscriber to an incident. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}~1private-subscribers/post
 func (c *Client) AddIncidentSubscribers(input *AddIncidentSubscribersInput) (*AddIncidentSubscribersOutput, error) {
+	return c.AddIncidentSubscribersWithContext(context.Background(), input)
+}
+
+// AddIncidentSubscribersWithContext is the same as AddIncidentSubscribers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) AddIncidentSubscribersWithContext(ctx context.Context, input *AddIncidentSubscribersInput) (*AddIncidentSubscribersOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -391,7 +452,7 @@ func (c *Client) AddIncidentSubscribers(input *AddIncidentSubscribersInput) (*Ad
 
 	url := fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.incidents, *input.IncidentID)
 
-	resp, err := c.httpClient.R().SetBody(input.Subscribers).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Subscribers).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -424,6 +485,11 @@ type UpdateIncidentOutput struct {
 
 // UpdateIncident updates the specific incident. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}/put
 func (c *Client) UpdateIncident(input *UpdateIncidentInput) (*UpdateIncidentOutput, error) {
+	return c.UpdateIncidentWithContext(context.Background(), input)
+}
+
+// UpdateIncidentWithContext is the same as UpdateIncident with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateIncidentWithContext(ctx context.Context, input *UpdateIncidentInput) (*UpdateIncidentOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -436,7 +502,7 @@ func (c *Client) UpdateIncident(input *UpdateIncidentInput) (*UpdateIncidentOutp
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.incidents, *input.IncidentID)
 
-	req := c.httpClient.R()
+	req := c.httpClient.R().SetContext(ctx)
 	if input.ETag != nil && *input.ETag != "" {
 		req.SetHeader("If-Match", *input.ETag)
 	}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,13 +34,18 @@ type CreateIncidentTemplateOutput struct {
 
 // CreateIncidentTemplate creates a new incident template. https://api.ilert.com/api-docs/#tag/Incident-Templates/paths/~1incident-templates/post
 func (c *Client) CreateIncidentTemplate(input *CreateIncidentTemplateInput) (*CreateIncidentTemplateOutput, error) {
+	return c.CreateIncidentTemplateWithContext(context.Background(), input)
+}
+
+// CreateIncidentTemplateWithContext is the same as CreateIncidentTemplate with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateIncidentTemplateWithContext(ctx context.Context, input *CreateIncidentTemplateInput) (*CreateIncidentTemplateOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.IncidentTemplate == nil {
 		return nil, errors.New("incidentTemplate input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.IncidentTemplate).Post(apiRoutes.incidentTemplates)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.IncidentTemplate).Post(apiRoutes.incidentTemplates)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +82,11 @@ type GetIncidentTemplatesOutput struct {
 
 // GetIncidentTemplates lists existing incident templates. https://api.ilert.com/api-docs/#tag/Incident-Templates/paths/~1incident-templates/get
 func (c *Client) GetIncidentTemplates(input *GetIncidentTemplatesInput) (*GetIncidentTemplatesOutput, error) {
+	return c.GetIncidentTemplatesWithContext(context.Background(), input)
+}
+
+// GetIncidentTemplatesWithContext is the same as GetIncidentTemplates with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetIncidentTemplatesWithContext(ctx context.Context, input *GetIncidentTemplatesInput) (*GetIncidentTemplatesOutput, error) {
 	if input == nil {
 		input = &GetIncidentTemplatesInput{}
 	}
@@ -92,7 +103,7 @@ func (c *Client) GetIncidentTemplates(input *GetIncidentTemplatesInput) (*GetInc
 		q.Add("max-results", "50")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.incidentTemplates, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.incidentTemplates, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +120,32 @@ func (c *Client) GetIncidentTemplates(input *GetIncidentTemplatesInput) (*GetInc
 	return &GetIncidentTemplatesOutput{IncidentTemplates: incidentTemplates}, nil
 }
 
+// GetIncidentTemplatesPages iterates over all pages of a GetIncidentTemplates operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetIncidentTemplatesPages(input *GetIncidentTemplatesInput, fn func(page *GetIncidentTemplatesOutput) bool, options ...PaginateOption) error {
+	return c.GetIncidentTemplatesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetIncidentTemplatesPagesWithContext is the same as GetIncidentTemplatesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetIncidentTemplatesPagesWithContext(ctx context.Context, input *GetIncidentTemplatesInput, fn func(page *GetIncidentTemplatesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetIncidentTemplatesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*IncidentTemplate, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetIncidentTemplatesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.IncidentTemplates, nil
+	}
+	return Paginate(ctx, fetch, func(page []*IncidentTemplate) bool {
+		return fn(&GetIncidentTemplatesOutput{IncidentTemplates: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // GetIncidentTemplateInput represents the input of a GetIncidentTemplate operation.
 type GetIncidentTemplateInput struct {
 	_                  struct{}
@@ -123,6 +160,11 @@ type GetIncidentTemplateOutput struct {
 
 // GetIncidentTemplate gets a incidentTemplate by id. https://api.ilert.com/api-docs/#tag/Incident-Templates/paths/~1incident-templates~1{id}/get
 func (c *Client) GetIncidentTemplate(input *GetIncidentTemplateInput) (*GetIncidentTemplateOutput, error) {
+	return c.GetIncidentTemplateWithContext(context.Background(), input)
+}
+
+// GetIncidentTemplateWithContext is the same as GetIncidentTemplate with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetIncidentTemplateWithContext(ctx context.Context, input *GetIncidentTemplateInput) (*GetIncidentTemplateOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -134,7 +176,7 @@ func (c *Client) GetIncidentTemplate(input *GetIncidentTemplateInput) (*GetIncid
 
 	var url = fmt.Sprintf("%s/%d?%s", apiRoutes.incidentTemplates, *input.IncidentTemplateID, q.Encode())
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +207,11 @@ type SearchIncidentTemplateOutput struct {
 
 // SearchIncidentTemplate gets the incident template with specified name.
 func (c *Client) SearchIncidentTemplate(input *SearchIncidentTemplateInput) (*SearchIncidentTemplateOutput, error) {
+	return c.SearchIncidentTemplateWithContext(context.Background(), input)
+}
+
+// SearchIncidentTemplateWithContext is the same as SearchIncidentTemplate with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchIncidentTemplateWithContext(ctx context.Context, input *SearchIncidentTemplateInput) (*SearchIncidentTemplateOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -172,7 +219,7 @@ func (c *Client) SearchIncidentTemplate(input *SearchIncidentTemplateInput) (*Se
 		return nil, errors.New("incident template name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.incidentTemplates, *input.IncidentTemplateName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.incidentTemplates, *input.IncidentTemplateName))
 	if err != nil {
 		return nil, err
 	}
@@ -204,6 +251,11 @@ type UpdateIncidentTemplateOutput struct {
 
 // UpdateIncidentTemplate updates the specific incident template. https://api.ilert.com/api-docs/#tag/Incident-Templates/paths/~1incident-templates~1{id}/put
 func (c *Client) UpdateIncidentTemplate(input *UpdateIncidentTemplateInput) (*UpdateIncidentTemplateOutput, error) {
+	return c.UpdateIncidentTemplateWithContext(context.Background(), input)
+}
+
+// UpdateIncidentTemplateWithContext is the same as UpdateIncidentTemplate with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateIncidentTemplateWithContext(ctx context.Context, input *UpdateIncidentTemplateInput) (*UpdateIncidentTemplateOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -216,7 +268,7 @@ func (c *Client) UpdateIncidentTemplate(input *UpdateIncidentTemplateInput) (*Up
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.incidentTemplates, *input.IncidentTemplateID)
 
-	resp, err := c.httpClient.R().SetBody(input.IncidentTemplate).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.IncidentTemplate).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -246,6 +298,11 @@ type DeleteIncidentTemplateOutput struct {
 
 // DeleteIncidentTemplate deletes the specified incident template. https://api.ilert.com/api-docs/#tag/Incident-Templates/paths/~1incident-templates~1{id}/delete
 func (c *Client) DeleteIncidentTemplate(input *DeleteIncidentTemplateInput) (*DeleteIncidentTemplateOutput, error) {
+	return c.DeleteIncidentTemplateWithContext(context.Background(), input)
+}
+
+// DeleteIncidentTemplateWithContext is the same as DeleteIncidentTemplate with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteIncidentTemplateWithContext(ctx context.Context, input *DeleteIncidentTemplateInput) (*DeleteIncidentTemplateOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -255,7 +312,7 @@ func (c *Client) DeleteIncidentTemplate(input *DeleteIncidentTemplateInput) (*De
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.incidentTemplates, *input.IncidentTemplateID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
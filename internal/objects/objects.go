@@ -0,0 +1,79 @@
+// Package objects contains helpers shared by the backup and reconcile packages and the event flow evaluator
+// to work on resources decoded into generic objects
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NameIdentity identifies resources by their name
+func NameIdentity(obj map[string]interface{}) string {
+	name, _ := obj["name"].(string)
+	return name
+}
+
+// UserIdentity identifies users by their case insensitive email, falling back to the username
+func UserIdentity(obj map[string]interface{}) string {
+	if email, _ := obj["email"].(string); email != "" {
+		return strings.ToLower(email)
+	}
+	username, _ := obj["username"].(string)
+	return username
+}
+
+// ContactIdentity identifies contacts by their case insensitive target
+func ContactIdentity(obj map[string]interface{}) string {
+	target, _ := obj["target"].(string)
+	return strings.ToLower(target)
+}
+
+// ObjectID returns the id of a resource as string, empty if it has none
+func ObjectID(obj map[string]interface{}) string {
+	return IDString(obj["id"])
+}
+
+// IDString returns a numeric or string id as string, empty if it is not set
+func IDString(value interface{}) string {
+	switch id := value.(type) {
+	case string:
+		return id
+	case json.Number:
+		if id.String() == "0" {
+			return ""
+		}
+		return id.String()
+	}
+	return ""
+}
+
+// ParseID parses a numeric resource id
+func ParseID(id string) (int64, error) {
+	v, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", id)
+	}
+	return v, nil
+}
+
+// DeepCopy copies nested objects and arrays, other values are shared
+func DeepCopy(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[key] = DeepCopy(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = DeepCopy(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,51 @@
+package objects
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserIdentity(t *testing.T) {
+	tests := []struct {
+		obj  map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"email": "Jane@Example.com", "username": "jane"}, "jane@example.com"},
+		{map[string]interface{}{"email": "", "username": "Jane"}, "Jane"},
+		{map[string]interface{}{}, ""},
+	}
+	for _, test := range tests {
+		if got := UserIdentity(test.obj); got != test.want {
+			t.Errorf("%v: expected %q, got %q", test.obj, test.want, got)
+		}
+	}
+}
+
+func TestObjectID(t *testing.T) {
+	tests := []struct {
+		id   interface{}
+		want string
+	}{
+		{json.Number("42"), "42"},
+		{json.Number("0"), ""},
+		{"abc", "abc"},
+		{float64(42), ""},
+		{nil, ""},
+	}
+	for _, test := range tests {
+		if got := ObjectID(map[string]interface{}{"id": test.id}); got != test.want {
+			t.Errorf("%v: expected %q, got %q", test.id, test.want, got)
+		}
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	obj := map[string]interface{}{"rules": []interface{}{map[string]interface{}{"timeout": 1}}}
+
+	copied := DeepCopy(obj).(map[string]interface{})
+	copied["rules"].([]interface{})[0].(map[string]interface{})["timeout"] = 2
+
+	if obj["rules"].([]interface{})[0].(map[string]interface{})["timeout"] != 1 {
+		t.Fatal("expected nested values to be copied")
+	}
+}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -85,6 +86,11 @@ type CreateMetricOutput struct {
 
 // CreateMetric creates a new metric. https://api.ilert.com/api-docs/#tag/Metrics/paths/~1metrics/post
 func (c *Client) CreateMetric(input *CreateMetricInput) (*CreateMetricOutput, error) {
+	return c.CreateMetricWithContext(context.Background(), input)
+}
+
+// CreateMetricWithContext is the same as CreateMetric with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateMetricWithContext(ctx context.Context, input *CreateMetricInput) (*CreateMetricOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -97,7 +103,7 @@ func (c *Client) CreateMetric(input *CreateMetricInput) (*CreateMetricOutput, er
 	if input.Metric.DataSource != nil && input.Metric.Metadata == nil {
 		return nil, errors.New("provider metadata is required when setting metric data source")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Metric).Post(apiRoutes.metrics)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Metric).Post(apiRoutes.metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +144,11 @@ type GetMetricsOutput struct {
 
 // GetMetrics lists existing metrics. https://api.ilert.com/api-docs/#tag/Metrics/paths/~1metrics/get
 func (c *Client) GetMetrics(input *GetMetricsInput) (*GetMetricsOutput, error) {
+	return c.GetMetricsWithContext(context.Background(), input)
+}
+
+// GetMetricsWithContext is the same as GetMetrics with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetMetricsWithContext(ctx context.Context, input *GetMetricsInput) (*GetMetricsOutput, error) {
 	if input == nil {
 		input = &GetMetricsInput{}
 	}
@@ -158,7 +169,7 @@ func (c *Client) GetMetrics(input *GetMetricsInput) (*GetMetricsOutput, error) {
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.metrics, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.metrics, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +186,36 @@ func (c *Client) GetMetrics(input *GetMetricsInput) (*GetMetricsOutput, error) {
 	return &GetMetricsOutput{Metrics: metrics}, nil
 }
 
+// GetMetricsPages iterates over all pages of a GetMetrics operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetMetricsPages(input *GetMetricsInput, fn func(page *GetMetricsOutput) bool, options ...PaginateOption) error {
+	return c.GetMetricsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetMetricsPagesWithContext is the same as GetMetricsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetMetricsPagesWithContext(ctx context.Context, input *GetMetricsInput, fn func(page *GetMetricsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetMetricsInput{}
+	}
+	maxPageSize := 100
+	if len(input.Include) > 0 {
+		maxPageSize = 25
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*Metric, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetMetricsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Metrics, nil
+	}
+	return Paginate(ctx, fetch, func(page []*Metric) bool {
+		return fn(&GetMetricsOutput{Metrics: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, maxPageSize, options)...)
+}
+
 // GetMetricInput represents the input of a GetMetric operation.
 type GetMetricInput struct {
 	_        struct{}
@@ -189,6 +230,11 @@ type GetMetricOutput struct {
 
 // GetMetric gets a metric by id. https://api.ilert.com/api-docs/#tag/Metrics/paths/~1metrics~1{id}/get
 func (c *Client) GetMetric(input *GetMetricInput) (*GetMetricOutput, error) {
+	return c.GetMetricWithContext(context.Background(), input)
+}
+
+// GetMetricWithContext is the same as GetMetric with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetMetricWithContext(ctx context.Context, input *GetMetricInput) (*GetMetricOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -198,7 +244,7 @@ func (c *Client) GetMetric(input *GetMetricInput) (*GetMetricOutput, error) {
 
 	var url = fmt.Sprintf("%s/%d", apiRoutes.metrics, *input.MetricID)
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -229,6 +275,11 @@ type SearchMetricOutput struct {
 
 // SearchMetric gets the metric with specified name.
 func (c *Client) SearchMetric(input *SearchMetricInput) (*SearchMetricOutput, error) {
+	return c.SearchMetricWithContext(context.Background(), input)
+}
+
+// SearchMetricWithContext is the same as SearchMetric with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchMetricWithContext(ctx context.Context, input *SearchMetricInput) (*SearchMetricOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -236,7 +287,7 @@ func (c *Client) SearchMetric(input *SearchMetricInput) (*SearchMetricOutput, er
 		return nil, errors.New("metric name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.metrics, *input.MetricName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.metrics, *input.MetricName))
 	if err != nil {
 		return nil, err
 	}
@@ -268,6 +319,11 @@ type UpdateMetricOutput struct {
 
 // UpdateMetric updates the specific metric. https://api.ilert.com/api-docs/#tag/Metrics/paths/~1metrics~1{id}/put
 func (c *Client) UpdateMetric(input *UpdateMetricInput) (*UpdateMetricOutput, error) {
+	return c.UpdateMetricWithContext(context.Background(), input)
+}
+
+// UpdateMetricWithContext is the same as UpdateMetric with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateMetricWithContext(ctx context.Context, input *UpdateMetricInput) (*UpdateMetricOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -280,7 +336,7 @@ func (c *Client) UpdateMetric(input *UpdateMetricInput) (*UpdateMetricOutput, er
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.metrics, *input.MetricID)
 
-	resp, err := c.httpClient.R().SetBody(input.Metric).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Metric).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -310,6 +366,11 @@ type DeleteMetricOutput struct {
 
 // DeleteMetric deletes the specified metric. https://api.ilert.com/api-docs/#tag/Metrics/paths/~1metrics~1{id}/delete
 func (c *Client) DeleteMetric(input *DeleteMetricInput) (*DeleteMetricOutput, error) {
+	return c.DeleteMetricWithContext(context.Background(), input)
+}
+
+// DeleteMetricWithContext is the same as DeleteMetric with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteMetricWithContext(ctx context.Context, input *DeleteMetricInput) (*DeleteMetricOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -319,7 +380,7 @@ func (c *Client) DeleteMetric(input *DeleteMetricInput) (*DeleteMetricOutput, er
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.metrics, *input.MetricID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -77,6 +78,11 @@ type CreateMetricDataSourceOutput struct {
 
 // CreateMetricDataSource creates a new metric data source. https://api.ilert.com/api-docs/#tag/Metric-Data-Sources/paths/~1metric-data-sources/post
 func (c *Client) CreateMetricDataSource(input *CreateMetricDataSourceInput) (*CreateMetricDataSourceOutput, error) {
+	return c.CreateMetricDataSourceWithContext(context.Background(), input)
+}
+
+// CreateMetricDataSourceWithContext is the same as CreateMetricDataSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateMetricDataSourceWithContext(ctx context.Context, input *CreateMetricDataSourceInput) (*CreateMetricDataSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -84,7 +90,7 @@ func (c *Client) CreateMetricDataSource(input *CreateMetricDataSourceInput) (*Cr
 		return nil, errors.New("metric data source input is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.MetricDataSource).Post(apiRoutes.metricDataSources)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.MetricDataSource).Post(apiRoutes.metricDataSources)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +127,11 @@ type GetMetricDataSourcesOutput struct {
 
 // GetMetricDataSources lists existing metric data sources. https://api.ilert.com/api-docs/#tag/Metric-Data-Sources/paths/~1metric-data-sources/get
 func (c *Client) GetMetricDataSources(input *GetMetricDataSourcesInput) (*GetMetricDataSourcesOutput, error) {
+	return c.GetMetricDataSourcesWithContext(context.Background(), input)
+}
+
+// GetMetricDataSourcesWithContext is the same as GetMetricDataSources with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetMetricDataSourcesWithContext(ctx context.Context, input *GetMetricDataSourcesInput) (*GetMetricDataSourcesOutput, error) {
 	if input == nil {
 		input = &GetMetricDataSourcesInput{}
 	}
@@ -137,7 +148,7 @@ func (c *Client) GetMetricDataSources(input *GetMetricDataSourcesInput) (*GetMet
 		q.Add("max-results", "10")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.metricDataSources, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.metricDataSources, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +165,32 @@ func (c *Client) GetMetricDataSources(input *GetMetricDataSourcesInput) (*GetMet
 	return &GetMetricDataSourcesOutput{MetricDataSources: metricDataSource}, nil
 }
 
+// GetMetricDataSourcesPages iterates over all pages of a GetMetricDataSources operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetMetricDataSourcesPages(input *GetMetricDataSourcesInput, fn func(page *GetMetricDataSourcesOutput) bool, options ...PaginateOption) error {
+	return c.GetMetricDataSourcesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetMetricDataSourcesPagesWithContext is the same as GetMetricDataSourcesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetMetricDataSourcesPagesWithContext(ctx context.Context, input *GetMetricDataSourcesInput, fn func(page *GetMetricDataSourcesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetMetricDataSourcesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*MetricDataSource, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetMetricDataSourcesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.MetricDataSources, nil
+	}
+	return Paginate(ctx, fetch, func(page []*MetricDataSource) bool {
+		return fn(&GetMetricDataSourcesOutput{MetricDataSources: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // GetMetricDataSourceInput represents the input of a GetMetricDataSource operation.
 type GetMetricDataSourceInput struct {
 	_                  struct{}
@@ -168,6 +205,11 @@ type GetMetricDataSourceOutput struct {
 
 // GetMetricDataSource gets a metric data source by ID. https://api.ilert.com/api-docs/#tag/Metric-Data-Sources/paths/~1metric-data-sources~1{id}/get
 func (c *Client) GetMetricDataSource(input *GetMetricDataSourceInput) (*GetMetricDataSourceOutput, error) {
+	return c.GetMetricDataSourceWithContext(context.Background(), input)
+}
+
+// GetMetricDataSourceWithContext is the same as GetMetricDataSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetMetricDataSourceWithContext(ctx context.Context, input *GetMetricDataSourceInput) (*GetMetricDataSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -177,7 +219,7 @@ func (c *Client) GetMetricDataSource(input *GetMetricDataSourceInput) (*GetMetri
 
 	var url = fmt.Sprintf("%s/%d", apiRoutes.metricDataSources, *input.MetricDataSourceID)
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +250,11 @@ type SearchMetricDataSourceOutput struct {
 
 // SearchMetricDataSource gets the metric data source with specified name.
 func (c *Client) SearchMetricDataSource(input *SearchMetricDataSourceInput) (*SearchMetricDataSourceOutput, error) {
+	return c.SearchMetricDataSourceWithContext(context.Background(), input)
+}
+
+// SearchMetricDataSourceWithContext is the same as SearchMetricDataSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchMetricDataSourceWithContext(ctx context.Context, input *SearchMetricDataSourceInput) (*SearchMetricDataSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -215,7 +262,7 @@ func (c *Client) SearchMetricDataSource(input *SearchMetricDataSourceInput) (*Se
 		return nil, errors.New("metric data source name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.metricDataSources, *input.MetricDataSourceName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.metricDataSources, *input.MetricDataSourceName))
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +294,11 @@ type UpdateMetricDataSourceOutput struct {
 
 // UpdateMetricDataSource updates the specific metric data source. https://api.ilert.com/api-docs/#tag/Metric-Data-Sources/paths/~1metric-data-sources~1{id}/put
 func (c *Client) UpdateMetricDataSource(input *UpdateMetricDataSourceInput) (*UpdateMetricDataSourceOutput, error) {
+	return c.UpdateMetricDataSourceWithContext(context.Background(), input)
+}
+
+// UpdateMetricDataSourceWithContext is the same as UpdateMetricDataSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateMetricDataSourceWithContext(ctx context.Context, input *UpdateMetricDataSourceInput) (*UpdateMetricDataSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -259,7 +311,7 @@ func (c *Client) UpdateMetricDataSource(input *UpdateMetricDataSourceInput) (*Up
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.metricDataSources, *input.MetricDataSourceID)
 
-	resp, err := c.httpClient.R().SetBody(input.MetricDataSource).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.MetricDataSource).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -289,6 +341,11 @@ type DeleteMetricDataSourceOutput struct {
 
 // DeleteMetricDataSource deletes the specified metric data source. https://api.ilert.com/api-docs/#tag/Metric-Data-Sources/paths/~1metric-data-sources~1{id}/delete
 func (c *Client) DeleteMetricDataSource(input *DeleteMetricDataSourceInput) (*DeleteMetricDataSourceOutput, error) {
+	return c.DeleteMetricDataSourceWithContext(context.Background(), input)
+}
+
+// DeleteMetricDataSourceWithContext is the same as DeleteMetricDataSource with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteMetricDataSourceWithContext(ctx context.Context, input *DeleteMetricDataSourceInput) (*DeleteMetricDataSourceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -298,7 +355,7 @@ func (c *Client) DeleteMetricDataSource(input *DeleteMetricDataSourceInput) (*De
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.metricDataSources, *input.MetricDataSourceID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
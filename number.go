@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 )
 
@@ -34,7 +35,12 @@ type GetNumbersOutput struct {
 
 // GetNumbers gets list available ilert phone numbers. https://api.ilert.com/api-docs/#tag/Numbers/paths/~1numbers/get
 func (c *Client) GetNumbers(input *GetNumbersInput) (*GetNumbersOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.numbers)
+	return c.GetNumbersWithContext(context.Background(), input)
+}
+
+// GetNumbersWithContext is the same as GetNumbers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetNumbersWithContext(ctx context.Context, input *GetNumbersInput) (*GetNumbersOutput, error) {
+	resp, err := c.httpClient.R().SetContext(ctx).Get(apiRoutes.numbers)
 	if err != nil {
 		return nil, err
 	}
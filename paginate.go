@@ -0,0 +1,167 @@
+package ilert
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// PaginateOptions describes how list endpoints are walked by Paginate
+type PaginateOptions struct {
+	// the index of the first entity to fetch
+	// Default: 0
+	StartIndex int
+
+	// the number of entities requested per page, capped by the maximum of the list endpoint
+	// Default: maximum of the list endpoint
+	PageSize int
+
+	// the number of pages that are fetched in parallel
+	// Default: 1
+	Concurrency int
+}
+
+// PaginateOption allows for options to be passed into Paginate for customization
+type PaginateOption func(*PaginateOptions)
+
+// WithStartIndex sets the index of the first entity to fetch, it overrides the StartIndex of list inputs
+func WithStartIndex(startIndex int) PaginateOption {
+	return func(o *PaginateOptions) {
+		o.StartIndex = startIndex
+	}
+}
+
+// WithPageSize sets the number of entities requested per page
+func WithPageSize(pageSize int) PaginateOption {
+	return func(o *PaginateOptions) {
+		o.PageSize = pageSize
+	}
+}
+
+// WithPageConcurrency sets the number of pages that are fetched in parallel.
+// Pages are still passed to the page callback in order.
+func WithPageConcurrency(concurrency int) PaginateOption {
+	return func(o *PaginateOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// PageFetcher fetches a single page of entities starting at startIndex with at most maxResults entities
+type PageFetcher[T any] func(ctx context.Context, startIndex int, maxResults int) ([]T, error)
+
+// Paginate walks through the pages of a list endpoint until a page with less than the requested page size is returned.
+// The page callback is invoked for every non-empty page in order, returning false stops the pagination early.
+// Requests of pages fetched in parallel are canceled once a page fails or the pagination stops.
+func Paginate[T any](ctx context.Context, fetch PageFetcher[T], fn func(page []T) bool, options ...PaginateOption) error {
+	if fetch == nil {
+		return errors.New("page fetcher is required")
+	}
+	if fn == nil {
+		return errors.New("page callback is required")
+	}
+	opts := &PaginateOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	if opts.PageSize <= 0 {
+		return errors.New("page size must be greater than zero")
+	}
+	if opts.StartIndex < 0 {
+		opts.StartIndex = 0
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	type result struct {
+		items []T
+		err   error
+	}
+
+	// cancels pages fetched in parallel once a page failed and pending requests on return
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	startIndex := opts.StartIndex
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		results := make([]result, opts.Concurrency)
+		var failed error
+		if opts.Concurrency == 1 {
+			items, err := fetch(ctx, startIndex, opts.PageSize)
+			results[0] = result{items: items, err: err}
+		} else {
+			wg := sync.WaitGroup{}
+			once := sync.Once{}
+			for i := range results {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					items, err := fetch(ctx, startIndex+i*opts.PageSize, opts.PageSize)
+					results[i] = result{items: items, err: err}
+					if err != nil {
+						once.Do(func() {
+							failed = err
+							cancel()
+						})
+					}
+				}(i)
+			}
+			wg.Wait()
+		}
+
+		for _, r := range results {
+			if r.err != nil {
+				if failed != nil {
+					// pages before the failed page may have been canceled
+					return failed
+				}
+				return r.err
+			}
+			if len(r.items) > 0 && !fn(r.items) {
+				return nil
+			}
+			if len(r.items) < opts.PageSize {
+				return nil
+			}
+			startIndex += opts.PageSize
+		}
+	}
+}
+
+// PaginateAll walks through all pages of a list endpoint and returns the collected entities
+func PaginateAll[T any](ctx context.Context, fetch PageFetcher[T], options ...PaginateOption) ([]T, error) {
+	all := make([]T, 0)
+	err := Paginate(ctx, fetch, func(page []T) bool {
+		all = append(all, page...)
+		return true
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// paginateOptions prepends the defaults of a list endpoint to the given options and caps the page size
+func paginateOptions(startIndex *int, maxResults *int, maxPageSize int, options []PaginateOption) []PaginateOption {
+	opts := make([]PaginateOption, 0, len(options)+2)
+	opts = append(opts, func(o *PaginateOptions) {
+		o.PageSize = maxPageSize
+		if startIndex != nil {
+			o.StartIndex = *startIndex
+		}
+		if maxResults != nil {
+			o.PageSize = *maxResults
+		}
+	})
+	opts = append(opts, options...)
+	opts = append(opts, func(o *PaginateOptions) {
+		if o.PageSize <= 0 || o.PageSize > maxPageSize {
+			o.PageSize = maxPageSize
+		}
+	})
+	return opts
+}
@@ -0,0 +1,238 @@
+package ilert_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+func TestPaginateAll(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]int, error) {
+		if startIndex >= len(items) {
+			return []int{}, nil
+		}
+		end := startIndex + maxResults
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[startIndex:end], nil
+	}
+	tests := []struct {
+		name    string
+		options []ilert.PaginateOption
+		want    []int
+	}{
+		{"all pages", []ilert.PaginateOption{ilert.WithPageSize(3)}, items},
+		{"start index", []ilert.PaginateOption{ilert.WithPageSize(3), ilert.WithStartIndex(2)}, []int{2, 3, 4, 5, 6}},
+		{"start index after the end", []ilert.PaginateOption{ilert.WithPageSize(3), ilert.WithStartIndex(10)}, []int{}},
+		{"concurrent pages", []ilert.PaginateOption{ilert.WithPageSize(2), ilert.WithStartIndex(1), ilert.WithPageConcurrency(3)}, []int{1, 2, 3, 4, 5, 6}},
+	}
+	for _, test := range tests {
+		got, err := ilert.PaginateAll(context.Background(), fetch, test.options...)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestPaginateStopsEarly(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		mu := sync.Mutex{}
+		fetches := 0
+		contexts := []context.Context{}
+		fetch := func(ctx context.Context, startIndex int, maxResults int) ([]int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			fetches++
+			contexts = append(contexts, ctx)
+			return []int{startIndex, startIndex + 1}, nil
+		}
+		pages := 0
+
+		err := ilert.Paginate(context.Background(), fetch, func(page []int) bool {
+			pages++
+			return false
+		}, ilert.WithPageSize(2), ilert.WithPageConcurrency(concurrency))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pages != 1 || fetches != concurrency {
+			t.Errorf("concurrency %d: expected 1 page of %d fetched pages, got %d of %d", concurrency, concurrency, pages, fetches)
+		}
+		for _, ctx := range contexts {
+			if ctx.Err() == nil {
+				t.Errorf("concurrency %d: expected the requests to be canceled after the pagination stopped", concurrency)
+			}
+		}
+	}
+}
+
+func TestPaginateConcurrency(t *testing.T) {
+	mu := sync.Mutex{}
+	inFlight, maxInFlight := 0, 0
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]int, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if startIndex >= 7 {
+			return []int{}, nil
+		}
+		return []int{startIndex}, nil
+	}
+
+	got, err := ilert.PaginateAll(context.Background(), fetch, ilert.WithPageSize(1), ilert.WithPageConcurrency(3))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4, 5, 6}) {
+		t.Errorf("expected the pages in order, got %v", got)
+	}
+	if maxInFlight != 3 {
+		t.Errorf("expected 3 pages to be fetched in parallel, got %d", maxInFlight)
+	}
+}
+
+func TestPaginateCancelsPagesOnError(t *testing.T) {
+	errPage := errors.New("page failed")
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]int, error) {
+		if startIndex == 2 {
+			return nil, errPage
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("page not canceled")
+		}
+	}
+	pages := 0
+
+	err := ilert.Paginate(context.Background(), fetch, func(page []int) bool {
+		pages++
+		return true
+	}, ilert.WithPageSize(2), ilert.WithPageConcurrency(3))
+
+	if !errors.Is(err, errPage) {
+		t.Errorf("expected the error of the failed page, got %v", err)
+	}
+	if pages != 0 {
+		t.Errorf("expected no page, got %d", pages)
+	}
+}
+
+// pagesServer serves ids 1 to n from any list endpoint, honoring start-index and max-results
+func pagesServer(n int, queries *[]string) *httptest.Server {
+	mu := sync.Mutex{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*queries = append(*queries, r.URL.RawQuery)
+		mu.Unlock()
+		startIndex, _ := strconv.Atoi(r.URL.Query().Get("start-index"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("max-results"))
+		items := []map[string]int{}
+		for id := startIndex + 1; id <= n && len(items) < maxResults; id++ {
+			items = append(items, map[string]int{"id": id})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(items)
+	}))
+}
+
+func TestGetPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		pages   func(client *ilert.Client, fn func(ids ...int64) bool) error
+		want    [][]int64
+		queries []string
+	}{
+		{
+			name: "start index and max results",
+			pages: func(client *ilert.Client, fn func(ids ...int64) bool) error {
+				return client.GetTeamsPages(&ilert.GetTeamsInput{StartIndex: ilert.Int(1), MaxResults: ilert.Int(2)}, func(page *ilert.GetTeamsOutput) bool {
+					ids := []int64{}
+					for _, team := range page.Teams {
+						ids = append(ids, team.ID)
+					}
+					return fn(ids...)
+				})
+			},
+			want:    [][]int64{{2, 3}, {4, 5}},
+			queries: []string{"max-results=2&start-index=1", "max-results=2&start-index=3", "max-results=2&start-index=5"},
+		},
+		{
+			name: "maximum page size",
+			pages: func(client *ilert.Client, fn func(ids ...int64) bool) error {
+				return client.GetUsersPages(nil, func(page *ilert.GetUsersOutput) bool {
+					ids := []int64{}
+					for _, user := range page.Users {
+						ids = append(ids, user.ID)
+					}
+					return fn(ids...)
+				})
+			},
+			want:    [][]int64{{1, 2, 3, 4, 5}},
+			queries: []string{"max-results=100&start-index=0"},
+		},
+		{
+			name: "max results capped",
+			pages: func(client *ilert.Client, fn func(ids ...int64) bool) error {
+				return client.GetSchedulesPages(&ilert.GetSchedulesInput{MaxResults: ilert.Int(50)}, func(page *ilert.GetSchedulesOutput) bool {
+					ids := []int64{}
+					for _, schedule := range page.Schedules {
+						ids = append(ids, schedule.ID)
+					}
+					return fn(ids...)
+				}, ilert.WithPageSize(3))
+			},
+			want:    [][]int64{{1, 2, 3}, {4, 5}},
+			queries: []string{"max-results=3&start-index=0", "max-results=3&start-index=3"},
+		},
+	}
+	for _, test := range tests {
+		queries := []string{}
+		ts := pagesServer(5, &queries)
+		client := ilert.NewClient(ilert.WithAPIEndpoint(ts.URL), ilert.WithRetry(0, 0, 0))
+		got := [][]int64{}
+
+		err := test.pages(client, func(ids ...int64) bool {
+			got = append(got, ids)
+			return true
+		})
+		ts.Close()
+
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: expected pages %v, got %v", test.name, test.want, got)
+		}
+		if fmt.Sprint(queries) != fmt.Sprint(test.queries) {
+			t.Errorf("%s: expected queries %v, got %v", test.name, test.queries, queries)
+		}
+	}
+}
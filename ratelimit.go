@@ -0,0 +1,324 @@
+package ilert
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RateLimitStatus describes the current request budget of a route group
+type RateLimitStatus struct {
+	// route group, i.e. the first path segment below /api e.g. alerts, users or events
+	Group string
+
+	// tokens currently available in the client-side token bucket
+	Tokens float64
+
+	// request limit reported by the X-RateLimit-Limit header of the last response, -1 if unknown
+	Limit int
+
+	// remaining requests reported by the X-RateLimit-Remaining header of the last response, -1 if unknown
+	Remaining int
+
+	// reset time reported by the X-RateLimit-Reset header of the last response, zero if unknown
+	Reset time.Time
+
+	// requests of the group are held back until this time due to Retry-After or exhausted rate limit headers
+	BlockedUntil time.Time
+}
+
+// rateLimit describes the token bucket settings of a route group
+type rateLimit struct {
+	requestsPerSecond float64
+	burst             int
+}
+
+// rateLimiter applies client-side token buckets per route group and tracks the API rate limit headers
+type rateLimiter struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	defaults rateLimit
+	limits   map[string]rateLimit
+	buckets  map[string]*tokenBucket
+}
+
+// tokenBucket holds the state of a single route group
+type tokenBucket struct {
+	limit        rateLimit
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	headerLimit  int
+	remaining    int
+	reset        time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		now:     time.Now,
+		limits:  make(map[string]rateLimit),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// WithRateLimit enables a client-side token bucket per route group e.g. alerts, users or events.
+// Each group may send requestsPerSecond requests with bursts of up to burst requests, a requestsPerSecond <= 0
+// disables the token bucket while still honoring the rate limit headers of the API.
+//
+// Requests of a group are held back after a 429 Too Many Requests response until the Retry-After duration passed
+// or after the X-RateLimit-Remaining header reported an exhausted budget until the X-RateLimit-Reset time.
+// Retries wait for the same durations, even beyond the max wait time of WithRetry.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOptions {
+	return func(c *Client) {
+		c.enableRateLimit()
+		c.rateLimiter.mu.Lock()
+		defer c.rateLimiter.mu.Unlock()
+		c.rateLimiter.defaults = rateLimit{requestsPerSecond: requestsPerSecond, burst: burst}
+	}
+}
+
+// WithRouteRateLimit overrides the token bucket of a single route group e.g. WithRouteRateLimit("events", 10, 20)
+func WithRouteRateLimit(group string, requestsPerSecond float64, burst int) ClientOptions {
+	return func(c *Client) {
+		c.enableRateLimit()
+		c.rateLimiter.mu.Lock()
+		defer c.rateLimiter.mu.Unlock()
+		c.rateLimiter.limits[group] = rateLimit{requestsPerSecond: requestsPerSecond, burst: burst}
+	}
+}
+
+// RateLimitStatus returns the current request budget per route group, it is empty unless WithRateLimit is used
+func (c *Client) RateLimitStatus() map[string]RateLimitStatus {
+	status := make(map[string]RateLimitStatus)
+	if c.rateLimiter == nil {
+		return status
+	}
+	l := c.rateLimiter
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for group, b := range l.buckets {
+		b.refill(now)
+		status[group] = RateLimitStatus{
+			Group:        group,
+			Tokens:       b.tokens,
+			Limit:        b.headerLimit,
+			Remaining:    b.remaining,
+			Reset:        b.reset,
+			BlockedUntil: b.blockedUntil,
+		}
+	}
+	return status
+}
+
+func (c *Client) enableRateLimit() {
+	if c.rateLimiter != nil {
+		return
+	}
+	l := newRateLimiter()
+	c.rateLimiter = l
+	c.httpClient.OnBeforeRequest(func(_ *resty.Client, r *resty.Request) error {
+		if c.cache != nil && c.cache.fresh(r) {
+			return nil // served from the cache
+		}
+		return l.wait(r)
+	})
+	c.httpClient.OnAfterResponse(func(_ *resty.Client, r *resty.Response) error {
+		l.update(r)
+		return nil
+	})
+	c.httpClient.SetRetryAfter(func(_ *resty.Client, r *resty.Response) (time.Duration, error) {
+		return l.retryAfter(r), nil
+	})
+}
+
+// routeGroup returns the route group of a request url e.g. alerts for /api/alerts/123/accept
+func routeGroup(requestURL string) string {
+	path := requestURL
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+		if j := strings.Index(path, "/"); j >= 0 {
+			path = path[j:]
+		} else {
+			path = "/"
+		}
+	}
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if segment == "api" {
+			segments = segments[i+1:]
+			break
+		}
+	}
+	if len(segments) > 1 && segments[0] == "v1" {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 || segments[0] == "" {
+		return "api"
+	}
+	return segments[0]
+}
+
+// bucket returns the token bucket of a route group, the limiter must be locked by the caller
+func (l *rateLimiter) bucket(group string, now time.Time) *tokenBucket {
+	b, ok := l.buckets[group]
+	if !ok {
+		limit, ok := l.limits[group]
+		if !ok {
+			limit = l.defaults
+		}
+		if limit.burst < 1 {
+			limit.burst = 1
+		}
+		b = &tokenBucket{
+			limit:       limit,
+			tokens:      float64(limit.burst),
+			last:        now,
+			headerLimit: -1,
+			remaining:   -1,
+		}
+		l.buckets[group] = b
+	}
+	return b
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if b.limit.requestsPerSecond <= 0 {
+		b.tokens = float64(b.limit.burst)
+		b.last = now
+		return
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.burst), b.tokens+elapsed.Seconds()*b.limit.requestsPerSecond)
+		b.last = now
+	}
+}
+
+// reserve takes a token from the bucket and returns the duration to wait before the request may be sent
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	wait := time.Duration(0)
+	if now.Before(b.blockedUntil) {
+		wait = b.blockedUntil.Sub(now)
+	}
+	if b.limit.requestsPerSecond <= 0 {
+		return wait
+	}
+	b.tokens--
+	if b.tokens < 0 {
+		if w := time.Duration(-b.tokens / b.limit.requestsPerSecond * float64(time.Second)); w > wait {
+			wait = w
+		}
+	}
+	return wait
+}
+
+// cancel returns a reserved token to the bucket
+func (b *tokenBucket) cancel() {
+	if b.limit.requestsPerSecond > 0 {
+		b.tokens = math.Min(float64(b.limit.burst), b.tokens+1)
+	}
+}
+
+// wait blocks until the route group of the request has budget left or the request context is done
+func (l *rateLimiter) wait(r *resty.Request) error {
+	group := routeGroup(r.URL)
+	l.mu.Lock()
+	b := l.bucket(group, l.now())
+	wait := b.reserve(l.now())
+	l.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	ctx := r.Context()
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.cancel()
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// update tracks the rate limit headers of a response and blocks the route group if required
+func (l *rateLimiter) update(r *resty.Response) {
+	if r == nil || r.Request == nil {
+		return
+	}
+	group := routeGroup(r.Request.URL)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	b := l.bucket(group, now)
+
+	header := r.Header()
+	if v, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		b.headerLimit = v
+	}
+	if v, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		b.remaining = v
+	}
+	if reset := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now); !reset.IsZero() {
+		b.reset = reset
+	}
+
+	until := time.Time{}
+	if r.StatusCode() == http.StatusTooManyRequests {
+		if d := parseRetryAfter(header.Get("Retry-After")); d > 0 {
+			until = now.Add(d)
+		} else if b.reset.After(now) {
+			until = b.reset
+		}
+	} else if b.remaining == 0 && b.reset.After(now) {
+		until = b.reset
+	}
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// retryAfter returns the wait duration before retrying a request, zero falls back to the exponential backoff
+func (l *rateLimiter) retryAfter(r *resty.Response) time.Duration {
+	if r == nil || r.Request == nil {
+		return 0
+	}
+	if d := parseRetryAfter(r.Header().Get("Retry-After")); d > 0 {
+		return d
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if b, ok := l.buckets[routeGroup(r.Request.URL)]; ok && b.blockedUntil.After(now) {
+		return b.blockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// parseRateLimitReset parses a X-RateLimit-Reset header value given as unix timestamp or as seconds until reset
+func parseRateLimitReset(value string, now time.Time) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v < 0 {
+		return time.Time{}
+	}
+	// values beyond one year in seconds can only be unix timestamps
+	if v > 365*24*60*60 {
+		return time.Unix(v, 0)
+	}
+	return now.Add(time.Duration(v) * time.Second)
+}
@@ -0,0 +1,221 @@
+package ilert_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+)
+
+func TestRateLimitTokenBucket(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	clock := newFakeClock()
+	client := srv.Client(ilert.WithRateLimit(1, 2))
+	ilert.SetRateLimitClock(client, clock.Now)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTeams(&ilert.GetTeamsInput{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tokens := client.RateLimitStatus()["teams"].Tokens; tokens != 0 {
+		t.Fatalf("expected the burst to be used up, got %v tokens", tokens)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetTeamsWithContext(ctx, &ilert.GetTeamsInput{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the request to wait for a token, got %v", err)
+	}
+	if tokens := client.RateLimitStatus()["teams"].Tokens; tokens != 0 {
+		t.Errorf("expected the token of the canceled request to be returned, got %v tokens", tokens)
+	}
+
+	clock.Advance(1500 * time.Millisecond)
+	if tokens := client.RateLimitStatus()["teams"].Tokens; tokens != 1.5 {
+		t.Errorf("expected 1.5 tokens after 1.5 seconds, got %v", tokens)
+	}
+	clock.Advance(time.Minute)
+	if tokens := client.RateLimitStatus()["teams"].Tokens; tokens != 2 {
+		t.Errorf("expected the tokens to be capped by the burst, got %v", tokens)
+	}
+	if _, err := client.GetTeams(&ilert.GetTeamsInput{}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(srv, http.MethodGet, "/api/teams"); n != 3 {
+		t.Errorf("expected 3 requests to reach the server, got %d", n)
+	}
+}
+
+func TestRouteRateLimit(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	clock := newFakeClock()
+	client := srv.Client(ilert.WithRateLimit(1, 1), ilert.WithRouteRateLimit("events", 10, 5))
+	ilert.SetRateLimitClock(client, clock.Now)
+
+	if _, err := client.CreateEvent(&ilert.CreateEventInput{Event: alertEvent("db-1")}); err != nil {
+		t.Fatal(err)
+	}
+	alertID := srv.SeedAlert(ilert.Alert{Summary: "db down"})
+	if _, err := client.AcceptAlert(&ilert.AcceptAlertInput{AlertID: &alertID}); err != nil {
+		t.Fatal(err)
+	}
+
+	status := client.RateLimitStatus()
+	if len(status) != 2 {
+		t.Fatalf("expected the events and alerts groups, got %+v", status)
+	}
+	if tokens := status["events"].Tokens; tokens != 4 {
+		t.Errorf("expected the events group to use its own burst, got %v tokens", tokens)
+	}
+	if tokens := status["alerts"].Tokens; tokens != 0 {
+		t.Errorf("expected the alerts group to use the default burst, got %v tokens", tokens)
+	}
+	clock.Advance(100 * time.Millisecond)
+	if tokens := client.RateLimitStatus()["events"].Tokens; tokens != 5 {
+		t.Errorf("expected the events group to refill 10 tokens per second, got %v", tokens)
+	}
+}
+
+func TestRateLimitRetryAfter(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	clock := newFakeClock()
+	client := srv.Client(ilert.WithRateLimit(0, 0))
+	ilert.SetRateLimitClock(client, clock.Now)
+	srv.InjectRateLimit("/api/teams", 2*time.Second, 1)
+
+	if _, err := client.GetTeams(&ilert.GetTeamsInput{}); !errors.Is(err, ilert.ErrRateLimited) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	if blocked := client.RateLimitStatus()["teams"].BlockedUntil; !blocked.Equal(clock.Now().Add(2 * time.Second)) {
+		t.Errorf("expected the teams group to be blocked for the Retry-After duration, got %s", blocked)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetTeamsWithContext(ctx, &ilert.GetTeamsInput{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the request to be held back, got %v", err)
+	}
+	if _, err := client.GetUsers(&ilert.GetUsersInput{}); err != nil {
+		t.Errorf("expected other groups not to be held back, got %v", err)
+	}
+
+	clock.Advance(2 * time.Second)
+	start := time.Now()
+	if _, err := client.GetTeams(&ilert.GetTeamsInput{}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected no wait after the Retry-After duration passed, waited %s", elapsed)
+	}
+	if n := countRequests(srv, http.MethodGet, "/api/teams"); n != 2 {
+		t.Errorf("expected the held back request not to reach the server, got %d requests", n)
+	}
+}
+
+func TestRateLimitRetryWaitsForRetryAfter(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client(ilert.WithRateLimit(0, 0), ilert.WithRetry(1, time.Millisecond, 100*time.Millisecond))
+	srv.InjectRateLimit("/api/teams", time.Second, 1)
+
+	start := time.Now()
+	if _, err := client.GetTeams(&ilert.GetTeamsInput{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected the retry to wait for the Retry-After duration beyond the max wait time, waited %s", elapsed)
+	}
+	if n := countRequests(srv, http.MethodGet, "/api/teams"); n != 2 {
+		t.Errorf("expected the request to be retried once, got %d requests", n)
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	clock := newFakeClock()
+	tests := []struct {
+		name      string
+		status    int
+		header    map[string]string
+		limit     int
+		remaining int
+		reset     time.Time
+		blocked   time.Time
+	}{
+		{
+			name:      "budget left",
+			status:    http.StatusOK,
+			header:    map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "42", "X-RateLimit-Reset": "30"},
+			limit:     100,
+			remaining: 42,
+			reset:     clock.Now().Add(30 * time.Second),
+		},
+		{
+			name:      "exhausted",
+			status:    http.StatusOK,
+			header:    map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"},
+			limit:     100,
+			remaining: 0,
+			reset:     clock.Now().Add(30 * time.Second),
+			blocked:   clock.Now().Add(30 * time.Second),
+		},
+		{
+			name:      "unix timestamp",
+			status:    http.StatusOK,
+			header:    map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(clock.Now().Add(time.Minute).Unix(), 10)},
+			limit:     -1,
+			remaining: 0,
+			reset:     clock.Now().Add(time.Minute),
+			blocked:   clock.Now().Add(time.Minute),
+		},
+		{
+			name:      "too many requests without Retry-After",
+			status:    http.StatusTooManyRequests,
+			header:    map[string]string{"X-RateLimit-Reset": "10"},
+			limit:     -1,
+			remaining: -1,
+			reset:     clock.Now().Add(10 * time.Second),
+			blocked:   clock.Now().Add(10 * time.Second),
+		},
+		{
+			name:      "invalid",
+			status:    http.StatusOK,
+			header:    map[string]string{"X-RateLimit-Limit": "many", "X-RateLimit-Remaining": "", "X-RateLimit-Reset": "-1"},
+			limit:     -1,
+			remaining: -1,
+		},
+	}
+	for _, test := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for key, value := range test.header {
+				w.Header().Set(key, value)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(test.status)
+			w.Write([]byte("[]"))
+		}))
+		client := ilert.NewClient(ilert.WithAPIEndpoint(ts.URL), ilert.WithRetry(0, 0, 0), ilert.WithRateLimit(0, 0))
+		ilert.SetRateLimitClock(client, clock.Now)
+
+		client.GetTeams(&ilert.GetTeamsInput{})
+		ts.Close()
+
+		status := client.RateLimitStatus()["teams"]
+		if status.Limit != test.limit || status.Remaining != test.remaining {
+			t.Errorf("%s: expected limit %d and remaining %d, got %d and %d", test.name, test.limit, test.remaining, status.Limit, status.Remaining)
+		}
+		if !status.Reset.Equal(test.reset) || !status.BlockedUntil.Equal(test.blocked) {
+			t.Errorf("%s: expected reset %s and blocked until %s, got %s and %s", test.name, test.reset, test.blocked, status.Reset, status.BlockedUntil)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Package reconcile applies a declarative desired state of iLert resources.
+//
+// A Document lists teams, users, schedules, escalation policies, alert sources and alert actions in the
+// JSON representation of the API. The Reconciler compares it with the current state, computes a Plan of
+// creates, updates and deletes and applies it in dependency order:
+//
+//	doc, err := reconcile.ParseFile("ilert.yaml")
+//	...
+//	r := reconcile.NewReconciler(client)
+//	plan, err := r.Plan(ctx, doc)
+//	...
+//	fmt.Print(plan)
+//	err = r.Apply(ctx, plan)
+package reconcile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/iLert/ilert-go/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Document describes the desired state of iLert resources.
+//
+// Resources are identified by name, users by email or username if no email is given. References to other
+// resources e.g. the users of a schedule layer or the escalation policy of an alert source are given by
+// name, email or username and are resolved to ids when the plan is applied, a reference with an id is used as is.
+//
+// Only the fields given in the document are compared and updated, other fields keep their current value.
+// Resource kinds that are not part of the document are left untouched.
+type Document struct {
+	Teams              []*ilert.Team             `json:"teams,omitempty"`
+	Users              []*ilert.User             `json:"users,omitempty"`
+	Schedules          []*ilert.Schedule         `json:"schedules,omitempty"`
+	EscalationPolicies []*ilert.EscalationPolicy `json:"escalationPolicies,omitempty"`
+	AlertSources       []*ilert.AlertSource      `json:"alertSources,omitempty"`
+	AlertActions       []*ilert.AlertAction      `json:"alertActions,omitempty"`
+
+	// resources as given in the parsed document, keyed by document key
+	raw map[string][]map[string]interface{}
+}
+
+// Parse parses a YAML or JSON document
+func Parse(data []byte) (*Document, error) {
+	var content interface{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	if content == nil {
+		content = map[string]interface{}{}
+	}
+	jsonContent, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("document is not representable as JSON: %w", err)
+	}
+
+	doc := &Document{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonContent))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(doc); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	raw := make(map[string][]map[string]interface{})
+	if err := decodeJSON(jsonContent, &raw); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	doc.raw = raw
+
+	if err := doc.validate(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// ParseFile reads and parses a YAML or JSON document
+func ParseFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// resources returns the resources of a kind as JSON objects and whether the kind is part of the document.
+// Documents that were not parsed are converted from their typed resources, zero values are treated as not given.
+func (d *Document) resources(k *kind) ([]map[string]interface{}, bool, error) {
+	if d.raw != nil {
+		resources, ok := d.raw[k.documentKey]
+		return resources, ok, nil
+	}
+
+	value := reflect.ValueOf(d).Elem().FieldByName(k.documentField)
+	if value.IsNil() {
+		return nil, false, nil
+	}
+	content, err := json.Marshal(value.Interface())
+	if err != nil {
+		return nil, false, err
+	}
+	resources := make([]map[string]interface{}, 0)
+	if err := decodeJSON(content, &resources); err != nil {
+		return nil, false, err
+	}
+	for i, resource := range resources {
+		resources[i], _ = stripZeroValues(resource).(map[string]interface{})
+		if resources[i] == nil {
+			resources[i] = map[string]interface{}{}
+		}
+	}
+	return resources, true, nil
+}
+
+// validate checks that every resource has an identity which is unique within its kind
+func (d *Document) validate() error {
+	for _, k := range kinds {
+		resources, _, err := d.resources(k)
+		if err != nil {
+			return err
+		}
+		seen := make(map[string]bool)
+		for i, resource := range resources {
+			if resource == nil {
+				return fmt.Errorf("%s #%d is empty", k.name, i+1)
+			}
+			key := k.identity(resource)
+			if key == "" {
+				return fmt.Errorf("%s #%d has no %s", k.name, i+1, k.identityField)
+			}
+			if seen[key] {
+				return fmt.Errorf("%s %q is declared more than once", k.name, key)
+			}
+			seen[key] = true
+		}
+	}
+	return nil
+}
+
+// decodeJSON decodes JSON keeping numbers as json.Number so that ids and values compare exactly
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// toJSONObject converts a value into its generic JSON representation
+func toJSONObject(v interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj := make(map[string]interface{})
+	if err := decodeJSON(content, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// stripZeroValues removes empty strings, zero numbers, false, nulls and empty collections
+func stripZeroValues(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if stripped := stripZeroValues(item); stripped == nil {
+				delete(value, key)
+			} else {
+				value[key] = stripped
+			}
+		}
+		if len(value) == 0 {
+			return nil
+		}
+		return value
+	case []interface{}:
+		if len(value) == 0 {
+			return nil
+		}
+		for i, item := range value {
+			if obj, ok := item.(map[string]interface{}); ok {
+				if stripped := stripZeroValues(obj); stripped != nil {
+					value[i] = stripped
+				} else {
+					value[i] = map[string]interface{}{}
+				}
+			}
+		}
+		return value
+	case string:
+		if value == "" {
+			return nil
+		}
+	case json.Number:
+		if f, err := value.Float64(); err == nil && f == 0 {
+			return nil
+		}
+	case bool:
+		if !value {
+			return nil
+		}
+	case nil:
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,428 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/internal/objects"
+)
+
+// Kinds defines the resource kinds of a document
+var Kinds = struct {
+	Team             string
+	User             string
+	Schedule         string
+	EscalationPolicy string
+	AlertSource      string
+	AlertAction      string
+}{
+	Team:             "team",
+	User:             "user",
+	Schedule:         "schedule",
+	EscalationPolicy: "escalation policy",
+	AlertSource:      "alert source",
+	AlertAction:      "alert action",
+}
+
+// KindsAll defines the resource kinds in the order they are created and updated, deletes happen in reverse order
+var KindsAll = []string{
+	Kinds.Team,
+	Kinds.User,
+	Kinds.Schedule,
+	Kinds.EscalationPolicy,
+	Kinds.AlertSource,
+	Kinds.AlertAction,
+}
+
+// kind describes how resources of a kind are identified, fetched and changed
+type kind struct {
+	name          string
+	documentKey   string
+	documentField string
+	identityField string
+
+	// returns the identity of a resource, references use the same identity
+	identity func(obj map[string]interface{}) string
+
+	// fields compared case insensitively like the identity, e.g. the email of users
+	foldFields []string
+
+	// fills in the ids of references to other resources
+	resolve func(r *resolver, obj map[string]interface{}) error
+
+	// optionally aligns the current state with the document representation
+	normalize func(p *Plan, obj map[string]interface{})
+
+	list   func(ctx context.Context, client *ilert.Client) ([]interface{}, error)
+	create func(ctx context.Context, client *ilert.Client, body []byte) (interface{}, error)
+	update func(ctx context.Context, client *ilert.Client, id string, body []byte) error
+	delete func(ctx context.Context, client *ilert.Client, id string) error
+}
+
+var kinds = []*kind{
+	{
+		name:          Kinds.Team,
+		documentKey:   "teams",
+		documentField: "Teams",
+		identityField: "name",
+		identity:      objects.NameIdentity,
+		resolve: func(r *resolver, obj map[string]interface{}) error {
+			return r.refList(obj, "members", func(member map[string]interface{}) error {
+				return r.ref(member, "user", Kinds.User)
+			})
+		},
+		list: func(ctx context.Context, client *ilert.Client) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetTeamsPagesWithContext(ctx, &ilert.GetTeamsInput{}, func(page *ilert.GetTeamsOutput) bool {
+				for _, item := range page.Teams {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, body []byte) (interface{}, error) {
+			team := &ilert.Team{}
+			if err := json.Unmarshal(body, team); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateTeamWithContext(ctx, &ilert.CreateTeamInput{Team: team})
+			if err != nil {
+				return nil, err
+			}
+			return output.Team, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			team := &ilert.Team{}
+			if err := json.Unmarshal(body, team); err != nil {
+				return err
+			}
+			teamID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateTeamWithContext(ctx, &ilert.UpdateTeamInput{TeamID: ilert.Int64(teamID), Team: team})
+			return err
+		},
+		delete: func(ctx context.Context, client *ilert.Client, id string) error {
+			teamID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteTeamWithContext(ctx, &ilert.DeleteTeamInput{TeamID: ilert.Int64(teamID)})
+			return err
+		},
+	},
+	{
+		name:          Kinds.User,
+		documentKey:   "users",
+		documentField: "Users",
+		identityField: "email or username",
+		identity:      objects.UserIdentity,
+		foldFields:    []string{"email"},
+		resolve: func(r *resolver, obj map[string]interface{}) error {
+			return nil
+		},
+		list: func(ctx context.Context, client *ilert.Client) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetUsersPagesWithContext(ctx, &ilert.GetUsersInput{}, func(page *ilert.GetUsersOutput) bool {
+				for _, item := range page.Users {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, body []byte) (interface{}, error) {
+			user := &ilert.User{}
+			if err := json.Unmarshal(body, user); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateUserWithContext(ctx, &ilert.CreateUserInput{User: user})
+			if err != nil {
+				return nil, err
+			}
+			return output.User, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			user := &ilert.User{}
+			if err := json.Unmarshal(body, user); err != nil {
+				return err
+			}
+			userID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateUserWithContext(ctx, &ilert.UpdateUserInput{UserID: ilert.Int64(userID), User: user})
+			return err
+		},
+		delete: func(ctx context.Context, client *ilert.Client, id string) error {
+			userID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteUserWithContext(ctx, &ilert.DeleteUserInput{UserID: ilert.Int64(userID)})
+			return err
+		},
+	},
+	{
+		name:          Kinds.Schedule,
+		documentKey:   "schedules",
+		documentField: "Schedules",
+		identityField: "name",
+		identity:      objects.NameIdentity,
+		resolve: func(r *resolver, obj map[string]interface{}) error {
+			err := r.refList(obj, "scheduleLayers", func(layer map[string]interface{}) error {
+				return r.refs(layer, "users", Kinds.User)
+			})
+			if err != nil {
+				return err
+			}
+			err = r.refList(obj, "shifts", func(shift map[string]interface{}) error {
+				return r.ref(shift, "user", Kinds.User)
+			})
+			if err != nil {
+				return err
+			}
+			return r.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetSchedulesPagesWithContext(ctx, &ilert.GetSchedulesInput{}, func(page *ilert.GetSchedulesOutput) bool {
+				for _, item := range page.Schedules {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, body []byte) (interface{}, error) {
+			schedule := &ilert.Schedule{}
+			if err := json.Unmarshal(body, schedule); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateScheduleWithContext(ctx, &ilert.CreateScheduleInput{Schedule: schedule})
+			if err != nil {
+				return nil, err
+			}
+			return output.Schedule, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			schedule := &ilert.Schedule{}
+			if err := json.Unmarshal(body, schedule); err != nil {
+				return err
+			}
+			scheduleID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateScheduleWithContext(ctx, &ilert.UpdateScheduleInput{ScheduleID: ilert.Int64(scheduleID), Schedule: schedule})
+			return err
+		},
+		delete: func(ctx context.Context, client *ilert.Client, id string) error {
+			scheduleID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteScheduleWithContext(ctx, &ilert.DeleteScheduleInput{ScheduleID: ilert.Int64(scheduleID)})
+			return err
+		},
+	},
+	{
+		name:          Kinds.EscalationPolicy,
+		documentKey:   "escalationPolicies",
+		documentField: "EscalationPolicies",
+		identityField: "name",
+		identity:      objects.NameIdentity,
+		resolve: func(r *resolver, obj map[string]interface{}) error {
+			err := r.refList(obj, "escalationRules", func(rule map[string]interface{}) error {
+				if err := r.ref(rule, "user", Kinds.User); err != nil {
+					return err
+				}
+				if err := r.refs(rule, "users", Kinds.User); err != nil {
+					return err
+				}
+				if err := r.ref(rule, "schedule", Kinds.Schedule); err != nil {
+					return err
+				}
+				if err := r.refs(rule, "schedules", Kinds.Schedule); err != nil {
+					return err
+				}
+				return r.refs(rule, "teams", Kinds.Team)
+			})
+			if err != nil {
+				return err
+			}
+			return r.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetEscalationPoliciesPagesWithContext(ctx, &ilert.GetEscalationPoliciesInput{}, func(page *ilert.GetEscalationPoliciesOutput) bool {
+				for _, item := range page.EscalationPolicies {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, body []byte) (interface{}, error) {
+			policy := &ilert.EscalationPolicy{}
+			if err := json.Unmarshal(body, policy); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateEscalationPolicyWithContext(ctx, &ilert.CreateEscalationPolicyInput{EscalationPolicy: policy})
+			if err != nil {
+				return nil, err
+			}
+			return output.EscalationPolicy, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			policy := &ilert.EscalationPolicy{}
+			if err := json.Unmarshal(body, policy); err != nil {
+				return err
+			}
+			policyID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateEscalationPolicyWithContext(ctx, &ilert.UpdateEscalationPolicyInput{EscalationPolicyID: ilert.Int64(policyID), EscalationPolicy: policy})
+			return err
+		},
+		delete: func(ctx context.Context, client *ilert.Client, id string) error {
+			policyID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteEscalationPolicyWithContext(ctx, &ilert.DeleteEscalationPolicyInput{EscalationPolicyID: ilert.Int64(policyID)})
+			return err
+		},
+	},
+	{
+		name:          Kinds.AlertSource,
+		documentKey:   "alertSources",
+		documentField: "AlertSources",
+		identityField: "name",
+		identity:      objects.NameIdentity,
+		resolve: func(r *resolver, obj map[string]interface{}) error {
+			if err := r.ref(obj, "escalationPolicy", Kinds.EscalationPolicy); err != nil {
+				return err
+			}
+			return r.refs(obj, "teams", Kinds.Team)
+		},
+		list: func(ctx context.Context, client *ilert.Client) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetAlertSourcesPagesWithContext(ctx, &ilert.GetAlertSourcesInput{}, func(page *ilert.GetAlertSourcesOutput) bool {
+				for _, item := range page.AlertSources {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, body []byte) (interface{}, error) {
+			source := &ilert.AlertSource{}
+			if err := json.Unmarshal(body, source); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateAlertSourceWithContext(ctx, &ilert.CreateAlertSourceInput{AlertSource: source})
+			if err != nil {
+				return nil, err
+			}
+			return output.AlertSource, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			source := &ilert.AlertSource{}
+			if err := json.Unmarshal(body, source); err != nil {
+				return err
+			}
+			sourceID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateAlertSourceWithContext(ctx, &ilert.UpdateAlertSourceInput{AlertSourceID: ilert.Int64(sourceID), AlertSource: source})
+			return err
+		},
+		delete: func(ctx context.Context, client *ilert.Client, id string) error {
+			sourceID, err := objects.ParseID(id)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteAlertSourceWithContext(ctx, &ilert.DeleteAlertSourceInput{AlertSourceID: ilert.Int64(sourceID)})
+			return err
+		},
+	},
+	{
+		name:          Kinds.AlertAction,
+		documentKey:   "alertActions",
+		documentField: "AlertActions",
+		identityField: "name",
+		identity:      objects.NameIdentity,
+		resolve: func(r *resolver, obj map[string]interface{}) error {
+			if err := r.refs(obj, "alertSources", Kinds.AlertSource); err != nil {
+				return err
+			}
+			return r.refs(obj, "teams", Kinds.Team)
+		},
+		normalize: func(p *Plan, obj map[string]interface{}) {
+			// alert actions with a single alert source may be returned with the deprecated alertSourceIds only
+			ids, ok := obj["alertSourceIds"].([]interface{})
+			if !ok || obj["alertSources"] != nil {
+				return
+			}
+			sources := make([]interface{}, 0, len(ids))
+			for _, id := range ids {
+				source := map[string]interface{}{"id": id}
+				if name := p.identityOf(Kinds.AlertSource, fmt.Sprint(id)); name != "" {
+					source["name"] = name
+				}
+				sources = append(sources, source)
+			}
+			obj["alertSources"] = sources
+		},
+		list: func(ctx context.Context, client *ilert.Client) ([]interface{}, error) {
+			result := make([]interface{}, 0)
+			err := client.GetAlertActionsPagesWithContext(ctx, &ilert.GetAlertActionsInput{}, func(page *ilert.GetAlertActionsOutput) bool {
+				for _, item := range page.AlertActions {
+					result = append(result, item)
+				}
+				return true
+			})
+			return result, err
+		},
+		create: func(ctx context.Context, client *ilert.Client, body []byte) (interface{}, error) {
+			action := &ilert.AlertAction{}
+			if err := json.Unmarshal(body, action); err != nil {
+				return nil, err
+			}
+			output, err := client.CreateAlertActionWithContext(ctx, &ilert.CreateAlertActionInput{AlertAction: action})
+			if err != nil {
+				return nil, err
+			}
+			return output.AlertAction, nil
+		},
+		update: func(ctx context.Context, client *ilert.Client, id string, body []byte) error {
+			action := &ilert.AlertAction{}
+			if err := json.Unmarshal(body, action); err != nil {
+				return err
+			}
+			_, err := client.UpdateAlertActionWithContext(ctx, &ilert.UpdateAlertActionInput{AlertActionID: ilert.String(id), AlertAction: action})
+			return err
+		},
+		delete: func(ctx context.Context, client *ilert.Client, id string) error {
+			_, err := client.DeleteAlertActionWithContext(ctx, &ilert.DeleteAlertActionInput{AlertActionID: ilert.String(id)})
+			return err
+		},
+	},
+}
+
+// kindByName returns the kind with the given name
+func kindByName(name string) *kind {
+	for _, k := range kinds {
+		if k.name == name {
+			return k
+		}
+	}
+	return nil
+}
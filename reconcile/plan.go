@@ -0,0 +1,235 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/iLert/ilert-go/v3/internal/objects"
+)
+
+// Actions defines the actions of a plan change
+var Actions = struct {
+	Create string
+	Update string
+	Delete string
+}{
+	Create: "create",
+	Update: "update",
+	Delete: "delete",
+}
+
+// Diff describes a field whose current value differs from the desired value
+type Diff struct {
+	// path of the field e.g. escalationRules or escalationPolicy.name
+	Path string
+
+	// current value, nil for creates
+	Old interface{}
+
+	// desired value, nil for deletes
+	New interface{}
+}
+
+// Change describes a create, update or delete of a single resource
+type Change struct {
+	Kind   string
+	Action string
+
+	// name, email or username identifying the resource
+	Name string
+
+	// id of the resource, set for created resources once the plan is applied
+	ID string
+
+	Diffs []*Diff
+
+	// request body of creates and updates before references are resolved
+	body map[string]interface{}
+}
+
+// String describes the change e.g. update escalation policy "Default"
+func (c *Change) String() string {
+	return fmt.Sprintf("%s %s %q", c.Action, c.Kind, c.Name)
+}
+
+// Plan describes the changes required to reach the desired state in the order they are applied
+type Plan struct {
+	Changes []*Change
+
+	// ids of the current resources per kind and identity
+	index map[string]map[string]string
+}
+
+// Empty reports whether the current state matches the desired state
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Print writes a human readable description of the plan
+func (p *Plan) Print(w io.Writer) error {
+	_, err := io.WriteString(w, p.String())
+	return err
+}
+
+// String returns a human readable description of the plan
+func (p *Plan) String() string {
+	b := &strings.Builder{}
+	counts := make(map[string]int)
+	for _, change := range p.Changes {
+		counts[change.Action]++
+		switch change.Action {
+		case Actions.Create:
+			fmt.Fprintf(b, "+ %s %q\n", change.Kind, change.Name)
+		case Actions.Update:
+			fmt.Fprintf(b, "~ %s %q (id %s)\n", change.Kind, change.Name, change.ID)
+		case Actions.Delete:
+			fmt.Fprintf(b, "- %s %q (id %s)\n", change.Kind, change.Name, change.ID)
+		}
+		for _, diff := range change.Diffs {
+			if change.Action == Actions.Create {
+				fmt.Fprintf(b, "    %s: %s\n", diff.Path, formatValue(diff.New))
+			} else {
+				fmt.Fprintf(b, "    %s: %s -> %s\n", diff.Path, formatValue(diff.Old), formatValue(diff.New))
+			}
+		}
+	}
+	if p.Empty() {
+		b.WriteString("No changes.\n")
+	} else {
+		fmt.Fprintf(b, "Plan: %d to create, %d to update, %d to delete.\n", counts[Actions.Create], counts[Actions.Update], counts[Actions.Delete])
+	}
+	return b.String()
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(content)
+}
+
+// diff compares the fields given in desired with current, objects are compared field by field
+// while arrays and scalars are reported as a whole
+func diff(path string, current interface{}, desired interface{}) []*Diff {
+	desiredObj, ok := desired.(map[string]interface{})
+	if !ok {
+		if matches(current, desired) {
+			return nil
+		}
+		return []*Diff{{Path: path, Old: current, New: desired}}
+	}
+	currentObj, _ := current.(map[string]interface{})
+	diffs := make([]*Diff, 0)
+	for _, key := range sortedKeys(desiredObj) {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		diffs = append(diffs, diff(fieldPath, currentObj[key], desiredObj[key])...)
+	}
+	return diffs
+}
+
+// withoutFoldedDiffs drops the diffs of fields the kind compares case insensitively that only differ in case
+func withoutFoldedDiffs(k *kind, diffs []*Diff) []*Diff {
+	result := make([]*Diff, 0, len(diffs))
+	for _, d := range diffs {
+		old, oldOk := d.Old.(string)
+		desired, desiredOk := d.New.(string)
+		if oldOk && desiredOk && strings.EqualFold(old, desired) && contains(k.foldFields, d.Path) {
+			continue
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+// matches reports whether current contains every field given in desired
+func matches(current interface{}, desired interface{}) bool {
+	switch d := desired.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		c, ok := current.(map[string]interface{})
+		if !ok {
+			return len(d) == 0 && current == nil
+		}
+		for key, value := range d {
+			if !matches(c[key], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		c, ok := current.([]interface{})
+		if !ok {
+			return len(d) == 0 && current == nil
+		}
+		if len(c) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !matches(c[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		c, ok := current.(json.Number)
+		if !ok {
+			return false
+		}
+		if c == d {
+			return true
+		}
+		cf, cerr := c.Float64()
+		df, derr := d.Float64()
+		return cerr == nil && derr == nil && cf == df
+	default:
+		return reflect.DeepEqual(current, desired)
+	}
+}
+
+// merge overlays the fields given in desired onto a copy of current, arrays are replaced as a whole
+func merge(current map[string]interface{}, desired map[string]interface{}) map[string]interface{} {
+	result, _ := objects.DeepCopy(current).(map[string]interface{})
+	if result == nil {
+		result = make(map[string]interface{})
+	}
+	for key, value := range desired {
+		desiredObj, ok := value.(map[string]interface{})
+		currentObj, currentOk := result[key].(map[string]interface{})
+		if ok && currentOk {
+			result[key] = merge(currentObj, desiredObj)
+		} else {
+			result[key] = objects.DeepCopy(value)
+		}
+	}
+	return result
+}
+
+func sortedKeys[V any](obj map[string]V) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
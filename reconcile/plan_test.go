@@ -0,0 +1,77 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func object(t *testing.T, content string) map[string]interface{} {
+	t.Helper()
+	obj := make(map[string]interface{})
+	if err := decodeJSON([]byte(content), &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		desired string
+		paths   []string
+	}{
+		{"equal", `{"name":"ops","visibility":"PUBLIC"}`, `{"name":"ops","visibility":"PUBLIC"}`, nil},
+		{"fields not given are ignored", `{"name":"ops","visibility":"PUBLIC"}`, `{"name":"ops"}`, nil},
+		{"changed scalar", `{"name":"ops","visibility":"PUBLIC"}`, `{"visibility":"PRIVATE"}`, []string{"visibility"}},
+		{"numbers compared by value", `{"timeout":15}`, `{"timeout":15.0}`, nil},
+		{"nested objects field by field", `{"policy":{"name":"a","id":1}}`, `{"policy":{"name":"b"}}`, []string{"policy.name"}},
+		{"arrays as a whole", `{"rules":[{"timeout":1,"id":3},{"timeout":2}]}`, `{"rules":[{"timeout":1}]}`, []string{"rules"}},
+		{"array items compare given fields", `{"rules":[{"timeout":1,"id":3}]}`, `{"rules":[{"timeout":1}]}`, nil},
+		{"missing field", `{"name":"ops"}`, `{"members":[]}`, nil},
+		{"missing object", `{"name":"ops"}`, `{"owner":{"name":"jane"}}`, []string{"owner.name"}},
+		{"null is not compared", `{"name":"ops"}`, `{"name":null}`, nil},
+	}
+	for _, test := range tests {
+		diffs := diff("", object(t, test.current), object(t, test.desired))
+		paths := make([]string, 0)
+		for _, d := range diffs {
+			paths = append(paths, d.Path)
+		}
+		if len(paths) != len(test.paths) || (len(paths) > 0 && !reflect.DeepEqual(paths, test.paths)) {
+			t.Errorf("%s: expected diffs %q, got %q", test.name, test.paths, paths)
+		}
+	}
+}
+
+func TestWithoutFoldedDiffs(t *testing.T) {
+	user := kindByName(Kinds.User)
+	diffs := diff("", object(t, `{"email":"jane@example.com","username":"jane"}`), object(t, `{"email":"Jane@Example.com","username":"Jane"}`))
+
+	got := withoutFoldedDiffs(user, diffs)
+
+	if len(got) != 1 || got[0].Path != "username" {
+		t.Fatalf("expected only the username diff, got %+v", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	current := object(t, `{"id":1,"name":"ops","owner":{"id":2,"name":"jane"},"rules":[{"id":3,"timeout":1}]}`)
+	desired := object(t, `{"owner":{"name":"john"},"rules":[{"timeout":5}],"visibility":"PRIVATE"}`)
+
+	merged := merge(current, desired)
+
+	expected := object(t, `{"id":1,"name":"ops","owner":{"id":2,"name":"john"},"rules":[{"timeout":5}],"visibility":"PRIVATE"}`)
+	if !reflect.DeepEqual(merged, expected) {
+		got, _ := json.Marshal(merged)
+		t.Fatalf("unexpected merge result %s", got)
+	}
+	if current["owner"].(map[string]interface{})["name"] != "jane" {
+		t.Fatal("expected current to be left unchanged")
+	}
+	merged["rules"].([]interface{})[0].(map[string]interface{})["timeout"] = json.Number("9")
+	if desired["rules"].([]interface{})[0].(map[string]interface{})["timeout"] != json.Number("5") {
+		t.Fatal("expected desired to be copied")
+	}
+}
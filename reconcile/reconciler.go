@@ -0,0 +1,311 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/internal/objects"
+)
+
+// ErrUnresolvedReference is returned when a reference names a resource that neither exists nor is created by the plan
+var ErrUnresolvedReference = errors.New("unresolved reference")
+
+// Reconciler computes and applies plans against the resources of an iLert account
+type Reconciler struct {
+	client *ilert.Client
+	prune  bool
+}
+
+// ReconcilerOptions allows for options to be passed into the Reconciler for customization
+type ReconcilerOptions func(*Reconciler)
+
+// WithPrune deletes resources that are not part of the document, only for kinds that are part of the document
+// Default: false
+func WithPrune(prune bool) ReconcilerOptions {
+	return func(r *Reconciler) {
+		r.prune = prune
+	}
+}
+
+// NewReconciler creates a reconciler using the given client
+func NewReconciler(client *ilert.Client, options ...ReconcilerOptions) *Reconciler {
+	r := &Reconciler{client: client}
+	for _, opt := range options {
+		opt(r)
+	}
+	return r
+}
+
+// Plan fetches the current state and computes the changes required to reach the desired state of the document
+func (r *Reconciler) Plan(ctx context.Context, doc *Document) (*Plan, error) {
+	if r.client == nil {
+		return nil, errors.New("client is required")
+	}
+	if doc == nil {
+		return nil, errors.New("document is required")
+	}
+	if err := doc.validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Changes: make([]*Change, 0), index: make(map[string]map[string]string)}
+	deletes := make([][]*Change, len(kinds))
+	for i, k := range kinds {
+		items, err := k.list(ctx, r.client)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch %s resources: %w", k.name, err)
+		}
+		current := make(map[string]map[string]interface{})
+		plan.index[k.name] = make(map[string]string)
+		for _, item := range items {
+			obj, err := toJSONObject(item)
+			if err != nil {
+				return nil, err
+			}
+			if k.normalize != nil {
+				k.normalize(plan, obj)
+			}
+			key := k.identity(obj)
+			if key == "" {
+				continue
+			}
+			current[key] = obj
+			plan.indexResource(k, obj, objects.ObjectID(obj))
+		}
+
+		desired, ok, err := doc.resources(k)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		desiredKeys := make(map[string]bool)
+		sorted := append([]map[string]interface{}(nil), desired...)
+		sort.SliceStable(sorted, func(a, b int) bool {
+			return k.identity(sorted[a]) < k.identity(sorted[b])
+		})
+		for _, obj := range sorted {
+			key := k.identity(obj)
+			desiredKeys[key] = true
+			currentObj, exists := current[key]
+			if !exists {
+				plan.Changes = append(plan.Changes, &Change{
+					Kind:   k.name,
+					Action: Actions.Create,
+					Name:   key,
+					Diffs:  diff("", nil, obj),
+					body:   objects.DeepCopy(obj).(map[string]interface{}),
+				})
+				continue
+			}
+			if diffs := withoutFoldedDiffs(k, diff("", currentObj, obj)); len(diffs) > 0 {
+				plan.Changes = append(plan.Changes, &Change{
+					Kind:   k.name,
+					Action: Actions.Update,
+					Name:   key,
+					ID:     objects.ObjectID(currentObj),
+					Diffs:  diffs,
+					body:   merge(currentObj, obj),
+				})
+			}
+		}
+
+		if r.prune {
+			for _, key := range sortedKeys(current) {
+				if !desiredKeys[key] {
+					deletes[i] = append(deletes[i], &Change{
+						Kind:   k.name,
+						Action: Actions.Delete,
+						Name:   key,
+						ID:     objects.ObjectID(current[key]),
+					})
+				}
+			}
+		}
+	}
+	for i := len(deletes) - 1; i >= 0; i-- {
+		plan.Changes = append(plan.Changes, deletes[i]...)
+	}
+	return plan, nil
+}
+
+// Apply applies the changes of a plan in order and stops at the first failing change.
+// Team members referencing users that are created later in the plan are added once the users exist.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	if r.client == nil {
+		return errors.New("client is required")
+	}
+	if plan == nil {
+		return errors.New("plan is required")
+	}
+	if plan.index == nil {
+		plan.index = make(map[string]map[string]string)
+	}
+
+	res := &resolver{plan: plan}
+	deferred := make([]*Change, 0)
+	for _, change := range plan.Changes {
+		k := kindByName(change.Kind)
+		if k == nil {
+			return fmt.Errorf("%s: unknown kind", change)
+		}
+		switch change.Action {
+		case Actions.Create, Actions.Update:
+			res.lenient = change.Kind == Kinds.Team
+			res.skipped = false
+			body, err := r.resolveBody(res, k, change)
+			if err != nil {
+				return err
+			}
+			if change.Action == Actions.Create {
+				created, err := k.create(ctx, r.client, body)
+				if err != nil {
+					return fmt.Errorf("%s: %w", change, err)
+				}
+				obj, err := toJSONObject(created)
+				if err != nil {
+					return fmt.Errorf("%s: %w", change, err)
+				}
+				change.ID = objects.ObjectID(obj)
+				plan.indexResource(k, merge(obj, change.body), change.ID)
+			} else if err := k.update(ctx, r.client, change.ID, body); err != nil {
+				return fmt.Errorf("%s: %w", change, err)
+			}
+			if res.skipped {
+				deferred = append(deferred, change)
+			}
+		case Actions.Delete:
+			if err := k.delete(ctx, r.client, change.ID); err != nil {
+				return fmt.Errorf("%s: %w", change, err)
+			}
+		default:
+			return fmt.Errorf("%s: unknown action", change)
+		}
+	}
+
+	res.lenient = false
+	for _, change := range deferred {
+		k := kindByName(change.Kind)
+		body, err := r.resolveBody(res, k, change)
+		if err != nil {
+			return err
+		}
+		if err := k.update(ctx, r.client, change.ID, body); err != nil {
+			return fmt.Errorf("%s: %w", change, err)
+		}
+	}
+	return nil
+}
+
+// resolveBody returns the request body of a change with the ids of all references filled in
+func (r *Reconciler) resolveBody(res *resolver, k *kind, change *Change) ([]byte, error) {
+	body := objects.DeepCopy(change.body).(map[string]interface{})
+	if err := k.resolve(res, body); err != nil {
+		return nil, fmt.Errorf("%s: %w", change, err)
+	}
+	return json.Marshal(body)
+}
+
+// indexResource remembers the id of a resource for reference resolution
+func (p *Plan) indexResource(k *kind, obj map[string]interface{}, id string) {
+	if id == "" {
+		return
+	}
+	if p.index[k.name] == nil {
+		p.index[k.name] = make(map[string]string)
+	}
+	p.index[k.name][k.identity(obj)] = id
+	if k.name == Kinds.User {
+		if username, _ := obj["username"].(string); username != "" {
+			p.index[k.name][username] = id
+		}
+	}
+}
+
+// identityOf returns the identity of the resource with the given id, empty if it is unknown
+func (p *Plan) identityOf(kindName string, id string) string {
+	for key, resourceID := range p.index[kindName] {
+		if resourceID == id {
+			return key
+		}
+	}
+	return ""
+}
+
+// resolver fills in the ids of references using the ids known to the plan
+type resolver struct {
+	plan *Plan
+
+	// skip unresolved references instead of failing, skipped is set once a reference was skipped
+	lenient bool
+	skipped bool
+}
+
+// ref resolves the reference in the given field of obj
+func (r *resolver) ref(obj map[string]interface{}, field string, kindName string) error {
+	target, ok := obj[field].(map[string]interface{})
+	if !ok || objects.ObjectID(target) != "" {
+		return nil
+	}
+	identity, identityField := objects.NameIdentity, "name"
+	if kindName == Kinds.User {
+		identity, identityField = objects.UserIdentity, "email or username"
+	}
+	key := identity(target)
+	if key == "" {
+		return fmt.Errorf("%s reference in %s has neither id nor %s", kindName, field, identityField)
+	}
+	id, ok := r.plan.index[kindName][key]
+	if !ok {
+		return fmt.Errorf("%w: %s %q", ErrUnresolvedReference, kindName, key)
+	}
+	target["id"] = json.Number(id)
+	return nil
+}
+
+// refs resolves the references in the given array field of obj
+func (r *resolver) refs(obj map[string]interface{}, field string, kindName string) error {
+	items, ok := obj[field].([]interface{})
+	if !ok {
+		return nil
+	}
+	for i := range items {
+		if err := r.ref(map[string]interface{}{field: items[i]}, field, kindName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refList calls resolve for every object in the given array field of obj. In lenient mode objects
+// with unresolved references are removed from the array.
+func (r *resolver) refList(obj map[string]interface{}, field string, resolve func(item map[string]interface{}) error) error {
+	items, ok := obj[field].([]interface{})
+	if !ok {
+		return nil
+	}
+	resolved := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		itemObj, ok := item.(map[string]interface{})
+		if !ok {
+			resolved = append(resolved, item)
+			continue
+		}
+		err := resolve(itemObj)
+		if r.lenient && errors.Is(err, ErrUnresolvedReference) {
+			r.skipped = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		resolved = append(resolved, itemObj)
+	}
+	obj[field] = resolved
+	return nil
+}
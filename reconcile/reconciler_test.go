@@ -0,0 +1,195 @@
+package reconcile_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/ilerttest"
+	"github.com/iLert/ilert-go/v3/reconcile"
+)
+
+const document = `
+teams:
+  - name: ops
+    members:
+      - user: {email: jane@example.com}
+        role: ADMIN
+users:
+  - email: jane@example.com
+    username: jane
+    firstName: Jane
+escalationPolicies:
+  - name: Default
+    escalationRules:
+      - escalationTimeout: 15
+        user: {email: jane@example.com}
+`
+
+func parse(t *testing.T, content string) *reconcile.Document {
+	t.Helper()
+	doc, err := reconcile.Parse([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func changes(plan *reconcile.Plan) []string {
+	result := make([]string, 0, len(plan.Changes))
+	for _, change := range plan.Changes {
+		result = append(result, change.String())
+	}
+	return result
+}
+
+func assertChanges(t *testing.T, plan *reconcile.Plan, expected ...string) {
+	t.Helper()
+	got := changes(plan)
+	if len(got) != len(expected) {
+		t.Fatalf("expected changes %q, got %q", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected changes %q, got %q", expected, got)
+		}
+	}
+}
+
+func TestPlanAndApplyConverges(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	r := reconcile.NewReconciler(srv.Client())
+	ctx := context.Background()
+	doc := parse(t, document)
+
+	plan, err := r.Plan(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, plan, `create team "ops"`, `create user "jane@example.com"`, `create escalation policy "Default"`)
+	if err := r.Apply(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	policies, err := srv.Client().GetEscalationPolicies(&ilert.GetEscalationPoliciesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := srv.Client().GetUsers(&ilert.GetUsersInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies.EscalationPolicies) != 1 || len(users.Users) != 1 ||
+		policies.EscalationPolicies[0].EscalationRules[0].User.ID != users.Users[0].ID {
+		t.Fatalf("expected the escalation rule to reference the created user")
+	}
+	teams, err := srv.Client().GetTeams(&ilert.GetTeamsInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams.Teams) != 1 || len(teams.Teams[0].Members) != 1 || teams.Teams[0].Members[0].User.ID != users.Users[0].ID {
+		t.Fatalf("expected the team member created after the team to be added, got %+v", teams.Teams)
+	}
+
+	plan, err = r.Plan(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("expected an empty plan after apply, got\n%s", plan)
+	}
+}
+
+func TestPlanMatchesEmailsCaseInsensitively(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	if _, err := client.CreateUser(&ilert.CreateUserInput{User: &ilert.User{Email: "jane@example.com", Username: "jane"}}); err != nil {
+		t.Fatal(err)
+	}
+	r := reconcile.NewReconciler(client)
+
+	plan, err := r.Plan(context.Background(), parse(t, "users:\n  - email: Jane@Example.com\n    username: jane\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("expected an empty plan, got\n%s", plan)
+	}
+}
+
+func TestPlanUpdatesOnlyGivenFields(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	created, err := client.CreateTeam(&ilert.CreateTeamInput{Team: &ilert.Team{Name: "ops", Visibility: "PUBLIC"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := reconcile.NewReconciler(client)
+	ctx := context.Background()
+
+	plan, err := r.Plan(ctx, parse(t, "teams:\n  - name: ops\n    visibility: PRIVATE\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, plan, `update team "ops"`)
+	diffs := plan.Changes[0].Diffs
+	if len(diffs) != 1 || diffs[0].Path != "visibility" || diffs[0].Old != "PUBLIC" || diffs[0].New != "PRIVATE" {
+		t.Fatalf("unexpected diffs %+v", diffs)
+	}
+	if err := r.Apply(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	team, err := client.GetTeam(&ilert.GetTeamInput{TeamID: &created.Team.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Team.Visibility != "PRIVATE" || team.Team.Name != "ops" {
+		t.Fatalf("unexpected team %+v", team.Team)
+	}
+}
+
+func TestPlanPruneDeletesInReverseOrder(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	r := reconcile.NewReconciler(srv.Client(), reconcile.WithPrune(true))
+	plan, err := r.Plan(ctx, parse(t, document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Apply(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err = r.Plan(ctx, parse(t, "teams: []\nusers: []\nescalationPolicies: []\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, plan, `delete escalation policy "Default"`, `delete user "jane@example.com"`, `delete team "ops"`)
+
+	plan, err = r.Plan(ctx, parse(t, "users: []\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(t, plan, `delete user "jane@example.com"`)
+}
+
+func TestApplyFailsOnUnresolvedReference(t *testing.T) {
+	srv := ilerttest.NewServer()
+	defer srv.Close()
+	r := reconcile.NewReconciler(srv.Client())
+	ctx := context.Background()
+
+	plan, err := r.Plan(ctx, parse(t, "escalationPolicies:\n  - name: Default\n    escalationRules:\n      - escalationTimeout: 15\n        user: {email: nobody@example.com}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Apply(ctx, plan); !errors.Is(err, reconcile.ErrUnresolvedReference) {
+		t.Fatalf("expected an unresolved reference error, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -121,6 +122,11 @@ type CreateScheduleOutput struct {
 
 // CreateSchedule creates a new schedule. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules/post
 func (c *Client) CreateSchedule(input *CreateScheduleInput) (*CreateScheduleOutput, error) {
+	return c.CreateScheduleWithContext(context.Background(), input)
+}
+
+// CreateScheduleWithContext is the same as CreateSchedule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateScheduleWithContext(ctx context.Context, input *CreateScheduleInput) (*CreateScheduleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -140,7 +146,7 @@ func (c *Client) CreateSchedule(input *CreateScheduleInput) (*CreateScheduleOutp
 		q.Add("abort-on-gaps", strconv.FormatBool(*input.AbortOnGaps))
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Schedule).Post(fmt.Sprintf("%s?%s", apiRoutes.schedules, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Schedule).Post(fmt.Sprintf("%s?%s", apiRoutes.schedules, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +180,11 @@ type GetScheduleOutput struct {
 
 // GetSchedule gets the on-call schedule with the specified id. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules~1{id}/get
 func (c *Client) GetSchedule(input *GetScheduleInput) (*GetScheduleOutput, error) {
+	return c.GetScheduleWithContext(context.Background(), input)
+}
+
+// GetScheduleWithContext is the same as GetSchedule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetScheduleWithContext(ctx context.Context, input *GetScheduleInput) (*GetScheduleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -187,7 +198,7 @@ func (c *Client) GetSchedule(input *GetScheduleInput) (*GetScheduleOutput, error
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d?%s", apiRoutes.schedules, *input.ScheduleID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d?%s", apiRoutes.schedules, *input.ScheduleID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +239,11 @@ type GetSchedulesOutput struct {
 
 // GetSchedules lists existing on-call schedules. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules/get
 func (c *Client) GetSchedules(input *GetSchedulesInput) (*GetSchedulesOutput, error) {
+	return c.GetSchedulesWithContext(context.Background(), input)
+}
+
+// GetSchedulesWithContext is the same as GetSchedules with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetSchedulesWithContext(ctx context.Context, input *GetSchedulesInput) (*GetSchedulesOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -244,7 +260,7 @@ func (c *Client) GetSchedules(input *GetSchedulesInput) (*GetSchedulesOutput, er
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.schedules, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.schedules, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -261,6 +277,32 @@ func (c *Client) GetSchedules(input *GetSchedulesInput) (*GetSchedulesOutput, er
 	return &GetSchedulesOutput{Schedules: schedules}, nil
 }
 
+// GetSchedulesPages iterates over all pages of a GetSchedules operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetSchedulesPages(input *GetSchedulesInput, fn func(page *GetSchedulesOutput) bool, options ...PaginateOption) error {
+	return c.GetSchedulesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetSchedulesPagesWithContext is the same as GetSchedulesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetSchedulesPagesWithContext(ctx context.Context, input *GetSchedulesInput, fn func(page *GetSchedulesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetSchedulesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*Schedule, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetSchedulesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Schedules, nil
+	}
+	return Paginate(ctx, fetch, func(page []*Schedule) bool {
+		return fn(&GetSchedulesOutput{Schedules: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 20, options)...)
+}
+
 // GetScheduleShiftsInput represents the input of a GetScheduleShifts operation.
 type GetScheduleShiftsInput struct {
 	_                struct{}
@@ -278,6 +320,11 @@ type GetScheduleShiftsOutput struct {
 
 // GetScheduleShifts lists shifts for the specified schedule and date range. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules~1{id}~1shifts/get
 func (c *Client) GetScheduleShifts(input *GetScheduleShiftsInput) (*GetScheduleShiftsOutput, error) {
+	return c.GetScheduleShiftsWithContext(context.Background(), input)
+}
+
+// GetScheduleShiftsWithContext is the same as GetScheduleShifts with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetScheduleShiftsWithContext(ctx context.Context, input *GetScheduleShiftsInput) (*GetScheduleShiftsOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -296,7 +343,7 @@ func (c *Client) GetScheduleShifts(input *GetScheduleShiftsInput) (*GetScheduleS
 		q.Add("exclude-overrides", strconv.FormatBool(*input.ExcludeOverrides))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/shifts?%s", apiRoutes.schedules, *input.ScheduleID, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d/shifts?%s", apiRoutes.schedules, *input.ScheduleID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -327,6 +374,11 @@ type GetScheduleOverridesOutput struct {
 
 // GetScheduleOverrides lists overrides for the specified schedule. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules~1{id}~1overrides/get
 func (c *Client) GetScheduleOverrides(input *GetScheduleOverridesInput) (*GetScheduleOverridesOutput, error) {
+	return c.GetScheduleOverridesWithContext(context.Background(), input)
+}
+
+// GetScheduleOverridesWithContext is the same as GetScheduleOverrides with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetScheduleOverridesWithContext(ctx context.Context, input *GetScheduleOverridesInput) (*GetScheduleOverridesOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -334,7 +386,7 @@ func (c *Client) GetScheduleOverrides(input *GetScheduleOverridesInput) (*GetSch
 		return nil, errors.New("schedule id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/overrides", apiRoutes.schedules, *input.ScheduleID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d/overrides", apiRoutes.schedules, *input.ScheduleID))
 	if err != nil {
 		return nil, err
 	}
@@ -365,6 +417,11 @@ type GetScheduleUserOnCallOutput struct {
 
 // GetScheduleUserOnCall gets the current user on call for specified schedule. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules~1{id}~1user-on-call/get
 func (c *Client) GetScheduleUserOnCall(input *GetScheduleUserOnCallInput) (*GetScheduleUserOnCallOutput, error) {
+	return c.GetScheduleUserOnCallWithContext(context.Background(), input)
+}
+
+// GetScheduleUserOnCallWithContext is the same as GetScheduleUserOnCall with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetScheduleUserOnCallWithContext(ctx context.Context, input *GetScheduleUserOnCallInput) (*GetScheduleUserOnCallOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -372,7 +429,7 @@ func (c *Client) GetScheduleUserOnCall(input *GetScheduleUserOnCallInput) (*GetS
 		return nil, errors.New("schedule id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/user-on-call", apiRoutes.schedules, *input.ScheduleID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d/user-on-call", apiRoutes.schedules, *input.ScheduleID))
 	if err != nil {
 		return nil, err
 	}
@@ -407,6 +464,11 @@ type SearchScheduleOutput struct {
 
 // SearchSchedule gets the schedule with specified name.
 func (c *Client) SearchSchedule(input *SearchScheduleInput) (*SearchScheduleOutput, error) {
+	return c.SearchScheduleWithContext(context.Background(), input)
+}
+
+// SearchScheduleWithContext is the same as SearchSchedule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchScheduleWithContext(ctx context.Context, input *SearchScheduleInput) (*SearchScheduleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -414,7 +476,7 @@ func (c *Client) SearchSchedule(input *SearchScheduleInput) (*SearchScheduleOutp
 		return nil, errors.New("schedule name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.schedules, *input.ScheduleName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.schedules, *input.ScheduleName))
 	if err != nil {
 		return nil, err
 	}
@@ -447,6 +509,11 @@ type UpdateScheduleOutput struct {
 
 // UpdateSchedule updates the specific schedule. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules~1{id}/put
 func (c *Client) UpdateSchedule(input *UpdateScheduleInput) (*UpdateScheduleOutput, error) {
+	return c.UpdateScheduleWithContext(context.Background(), input)
+}
+
+// UpdateScheduleWithContext is the same as UpdateSchedule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateScheduleWithContext(ctx context.Context, input *UpdateScheduleInput) (*UpdateScheduleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -465,7 +532,7 @@ func (c *Client) UpdateSchedule(input *UpdateScheduleInput) (*UpdateScheduleOutp
 
 	url := fmt.Sprintf("%s/%d?%s", apiRoutes.schedules, *input.ScheduleID, q.Encode())
 
-	resp, err := c.httpClient.R().SetBody(input.Schedule).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Schedule).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -497,6 +564,11 @@ type AddScheduleShiftOverrideOutput struct {
 
 // AddScheduleShiftOverride adds an override to a shift on the schedule. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules~1{id}~1overrides/put
 func (c *Client) AddScheduleShiftOverride(input *AddScheduleShiftOverrideInput) (*AddScheduleShiftOverrideOutput, error) {
+	return c.AddScheduleShiftOverrideWithContext(context.Background(), input)
+}
+
+// AddScheduleShiftOverrideWithContext is the same as AddScheduleShiftOverride with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) AddScheduleShiftOverrideWithContext(ctx context.Context, input *AddScheduleShiftOverrideInput) (*AddScheduleShiftOverrideOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -509,7 +581,7 @@ func (c *Client) AddScheduleShiftOverride(input *AddScheduleShiftOverrideInput)
 
 	url := fmt.Sprintf("%s/%d/overrides", apiRoutes.schedules, *input.ScheduleID)
 
-	resp, err := c.httpClient.R().SetBody(input.Shift).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Shift).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +611,11 @@ type DeleteScheduleOutput struct {
 
 // DeleteSchedule deletes the specified schedule. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules~1{id}/delete
 func (c *Client) DeleteSchedule(input *DeleteScheduleInput) (*DeleteScheduleOutput, error) {
+	return c.DeleteScheduleWithContext(context.Background(), input)
+}
+
+// DeleteScheduleWithContext is the same as DeleteSchedule with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteScheduleWithContext(ctx context.Context, input *DeleteScheduleInput) (*DeleteScheduleOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -548,7 +625,7 @@ func (c *Client) DeleteSchedule(input *DeleteScheduleInput) (*DeleteScheduleOutp
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.schedules, *input.ScheduleID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,332 @@
+package ilert
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ScheduleCoverage describes how well a set of shifts covers a window
+type ScheduleCoverage struct {
+	From  time.Time
+	Until time.Time
+
+	// time within the window covered by at least one shift
+	Covered time.Duration
+
+	// fraction of the window covered by at least one shift, between 0 and 1
+	Coverage float64
+
+	// uncovered intervals
+	Gaps []ScheduleInterval
+
+	// intervals covered by more than one shift
+	Overlaps []ScheduleOverlap
+
+	// on-call load per user, sorted by total time descending, overlapping shifts of a user count once
+	Users []ScheduleUserLoad
+
+	// consecutive shifts of the same user with less rest than the min rest in between,
+	// adjacent and overlapping shifts of a user are merged first
+	BackToBack []ScheduleBackToBack
+}
+
+// ScheduleInterval is a time range
+type ScheduleInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ScheduleOverlap is a time range covered by more than one shift
+type ScheduleOverlap struct {
+	Start  time.Time
+	End    time.Time
+	Shifts []Shift
+}
+
+// ScheduleUserLoad describes the on-call time of a user within the window
+type ScheduleUserLoad struct {
+	User    User
+	Shifts  int
+	Total   time.Duration
+	Night   time.Duration
+	Weekend time.Duration
+}
+
+// ScheduleBackToBack describes two consecutive shifts of a user with too little rest in between
+type ScheduleBackToBack struct {
+	User User
+
+	// the shift ending before and the shift starting after the rest
+	First  Shift
+	Second Shift
+
+	Rest time.Duration
+}
+
+// ScheduleCoverageOptions describes how night and weekend load and back-to-back shifts are determined
+type ScheduleCoverageOptions struct {
+	// location used for night hours and weekend days
+	// Default: UTC
+	Location *time.Location
+
+	// start and end of the night as time of day e.g. 22:00 and 06:00
+	NightStart string
+	NightEnd   string
+
+	// days counted as weekend
+	// Default: Saturday, Sunday
+	WeekendDays []time.Weekday
+
+	// shifts of the same user with less rest in between are reported as back-to-back
+	// Default: 8 hours
+	MinRest time.Duration
+}
+
+// ScheduleCoverageOption allows for options to be passed into AnalyzeScheduleCoverage for customization
+type ScheduleCoverageOption func(*ScheduleCoverageOptions)
+
+// WithCoverageLocation sets the location used for night hours and weekend days, usually the schedule's timezone
+func WithCoverageLocation(loc *time.Location) ScheduleCoverageOption {
+	return func(o *ScheduleCoverageOptions) {
+		o.Location = loc
+	}
+}
+
+// WithNightHours sets the start and end of the night as time of day e.g. WithNightHours("22:00", "06:00")
+// Default: 22:00 to 06:00
+func WithNightHours(start string, end string) ScheduleCoverageOption {
+	return func(o *ScheduleCoverageOptions) {
+		o.NightStart = start
+		o.NightEnd = end
+	}
+}
+
+// WithWeekendDays sets the days counted as weekend
+func WithWeekendDays(days ...time.Weekday) ScheduleCoverageOption {
+	return func(o *ScheduleCoverageOptions) {
+		o.WeekendDays = days
+	}
+}
+
+// WithMinRest sets the min rest between two shifts of the same user
+func WithMinRest(minRest time.Duration) ScheduleCoverageOption {
+	return func(o *ScheduleCoverageOptions) {
+		o.MinRest = minRest
+	}
+}
+
+// AnalyzeScheduleCoverage reports gaps, overlaps, per-user load and back-to-back shifts of the given shifts
+// between from and until, e.g. of the output of GetScheduleShifts or RenderSchedule.
+func AnalyzeScheduleCoverage(shifts []Shift, from time.Time, until time.Time, options ...ScheduleCoverageOption) (*ScheduleCoverage, error) {
+	if !until.After(from) {
+		return nil, errors.New("until must be after from")
+	}
+	opts := &ScheduleCoverageOptions{
+		Location:    time.UTC,
+		NightStart:  "22:00",
+		NightEnd:    "06:00",
+		WeekendDays: []time.Weekday{time.Saturday, time.Sunday},
+		MinRest:     8 * time.Hour,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	nightStart, err := parseTimeOfDay(opts.NightStart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid night start: %w", err)
+	}
+	nightEnd, err := parseTimeOfDay(opts.NightEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid night end: %w", err)
+	}
+
+	// shifts overlapping the window, clipped to it
+	segments := make([]scheduleSegment, 0, len(shifts))
+	originals := make([]Shift, 0, len(shifts))
+	for i, shift := range shifts {
+		segment, err := shiftSegment(shift, opts.Location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shift #%d: %w", i+1, err)
+		}
+		if !segment.end.After(from) || !segment.start.Before(until) || !segment.end.After(segment.start) {
+			continue
+		}
+		segment.start, segment.end = maxTime(segment.start, from), minTime(segment.end, until)
+		segments = append(segments, segment)
+		originals = append(originals, shift)
+	}
+
+	coverage := &ScheduleCoverage{
+		From:       from,
+		Until:      until,
+		Gaps:       make([]ScheduleInterval, 0),
+		Overlaps:   make([]ScheduleOverlap, 0),
+		Users:      make([]ScheduleUserLoad, 0),
+		BackToBack: make([]ScheduleBackToBack, 0),
+	}
+
+	// sweep over the elementary intervals between all shift bounds
+	bounds := []time.Time{from, until}
+	for _, segment := range segments {
+		bounds = append(bounds, segment.start, segment.end)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+	for i := 0; i+1 < len(bounds); i++ {
+		start, end := bounds[i], bounds[i+1]
+		if !end.After(start) {
+			continue
+		}
+		active := make([]int, 0)
+		for j, segment := range segments {
+			if !segment.start.After(start) && !segment.end.Before(end) {
+				active = append(active, j)
+			}
+		}
+		switch {
+		case len(active) == 0:
+			if n := len(coverage.Gaps); n > 0 && coverage.Gaps[n-1].End.Equal(start) {
+				coverage.Gaps[n-1].End = end
+			} else {
+				coverage.Gaps = append(coverage.Gaps, ScheduleInterval{Start: start, End: end})
+			}
+		case len(active) > 1:
+			overlapping := make([]Shift, 0, len(active))
+			for _, j := range active {
+				overlapping = append(overlapping, originals[j])
+			}
+			if n := len(coverage.Overlaps); n > 0 && coverage.Overlaps[n-1].End.Equal(start) && sameShifts(coverage.Overlaps[n-1].Shifts, overlapping) {
+				coverage.Overlaps[n-1].End = end
+			} else {
+				coverage.Overlaps = append(coverage.Overlaps, ScheduleOverlap{Start: start, End: end, Shifts: overlapping})
+			}
+		}
+		if len(active) > 0 {
+			coverage.Covered += end.Sub(start)
+		}
+	}
+	coverage.Coverage = float64(coverage.Covered) / float64(until.Sub(from))
+
+	// per-user load and back-to-back shifts
+	byUser := make(map[int][]int)
+	userIndex := make([]User, 0)
+	for j, segment := range segments {
+		index := -1
+		for u, user := range userIndex {
+			if SameUser(user, segment.user) {
+				index = u
+				break
+			}
+		}
+		if index < 0 {
+			index = len(userIndex)
+			userIndex = append(userIndex, segment.user)
+		}
+		byUser[index] = append(byUser[index], j)
+	}
+	for u, user := range userIndex {
+		load := ScheduleUserLoad{User: user}
+		userSegments := byUser[u]
+		sort.Slice(userSegments, func(a, b int) bool {
+			return segments[userSegments[a]].start.Before(segments[userSegments[b]].start)
+		})
+		// adjacent and overlapping shifts of the user are merged, e.g. a rotation handing over to the same user,
+		// first and last are the indexes of the shifts starting and ending the merged shift
+		type mergedShift struct {
+			start, end  time.Time
+			first, last int
+		}
+		merged := make([]mergedShift, 0, len(userSegments))
+		for _, j := range userSegments {
+			segment := segments[j]
+			load.Shifts++
+			if n := len(merged); n > 0 && !segment.start.After(merged[n-1].end) {
+				if segment.end.After(merged[n-1].end) {
+					merged[n-1].end, merged[n-1].last = segment.end, j
+				}
+				continue
+			}
+			merged = append(merged, mergedShift{start: segment.start, end: segment.end, first: j, last: j})
+		}
+		for n, shift := range merged {
+			load.Total += shift.end.Sub(shift.start)
+			load.Night += nightOverlap(shift.start, shift.end, nightStart, nightEnd, opts.Location)
+			load.Weekend += weekendOverlap(shift.start, shift.end, opts.WeekendDays, opts.Location)
+
+			if n == 0 {
+				continue
+			}
+			previous := merged[n-1]
+			if rest := shift.start.Sub(previous.end); rest < opts.MinRest {
+				coverage.BackToBack = append(coverage.BackToBack, ScheduleBackToBack{
+					User:   user,
+					First:  originals[previous.last],
+					Second: originals[shift.first],
+					Rest:   rest,
+				})
+			}
+		}
+		coverage.Users = append(coverage.Users, load)
+	}
+	sort.SliceStable(coverage.Users, func(a, b int) bool {
+		return coverage.Users[a].Total > coverage.Users[b].Total
+	})
+
+	return coverage, nil
+}
+
+// nightOverlap returns the time between start and end that falls into the nightly window
+func nightOverlap(start time.Time, end time.Time, nightStart int, nightEnd int, loc *time.Location) time.Duration {
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, loc)
+	total := time.Duration(0)
+	for day.Before(end) {
+		from := atMinute(day, 0, nightStart, loc)
+		to := atMinute(day, 0, nightEnd, loc)
+		if !to.After(from) {
+			to = atMinute(day, 1, nightEnd, loc)
+		}
+		if overlapFrom, overlapTo := maxTime(from, start), minTime(to, end); overlapTo.After(overlapFrom) {
+			total += overlapTo.Sub(overlapFrom)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total
+}
+
+// weekendOverlap returns the time between start and end that falls on weekend days
+func weekendOverlap(start time.Time, end time.Time, weekendDays []time.Weekday, loc *time.Location) time.Duration {
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	total := time.Duration(0)
+	for day.Before(end) {
+		next := day.AddDate(0, 0, 1)
+		for _, weekendDay := range weekendDays {
+			if day.Weekday() != weekendDay {
+				continue
+			}
+			if overlapFrom, overlapTo := maxTime(day, start), minTime(next, end); overlapTo.After(overlapFrom) {
+				total += overlapTo.Sub(overlapFrom)
+			}
+			break
+		}
+		day = next
+	}
+	return total
+}
+
+func sameShifts(a []Shift, b []Shift) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Start != b[i].Start || a[i].End != b[i].End || !SameUser(a[i].User, b[i].User) {
+			return false
+		}
+	}
+	return true
+}
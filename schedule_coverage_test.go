@@ -0,0 +1,142 @@
+package ilert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyzeScheduleCoverage(t *testing.T) {
+	jane := User{ID: 1, Username: "jane"}
+	john := User{ID: 2, Username: "john"}
+	alex := User{ID: 3, Username: "alex"}
+	at := func(day int, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+	shifts := []Shift{
+		{User: jane, Start: "2024-02-29T18:00:00Z", End: "2024-03-01T12:00:00Z"},
+		{User: jane, Start: "2024-03-01T12:00:00Z", End: "2024-03-02T00:00:00Z"},
+		{User: john, Start: "2024-03-02T00:00:00Z", End: "2024-03-02T12:00:00Z"},
+		{User: jane, Start: "2024-03-02T18:00:00Z", End: "2024-03-03T06:00:00Z"},
+		{User: john, Start: "2024-03-02T18:00:00Z", End: "2024-03-03T00:00:00Z"},
+		{User: jane, Start: "2024-03-02T20:00:00Z", End: "2024-03-02T22:00:00Z"},
+		{User: alex, Start: "2024-03-03T00:00:00Z", End: "2024-03-04T00:00:00Z"},
+		{User: alex, Start: "2024-03-05T00:00:00Z", End: "2024-03-06T00:00:00Z"},
+	}
+
+	// Friday to Monday, March 2 and 3 are the weekend
+	coverage, err := AnalyzeScheduleCoverage(shifts, at(1, 0), at(4, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if coverage.Covered != 66*time.Hour || coverage.Coverage != 66.0/72.0 {
+		t.Errorf("expected 66 of 72 hours to be covered, got %s and %v", coverage.Covered, coverage.Coverage)
+	}
+	if len(coverage.Gaps) != 1 || !coverage.Gaps[0].Start.Equal(at(2, 12)) || !coverage.Gaps[0].End.Equal(at(2, 18)) {
+		t.Errorf("expected a gap on March 2 from 12:00 to 18:00, got %+v", coverage.Gaps)
+	}
+
+	overlaps := []struct {
+		start, end time.Time
+		shifts     int
+	}{
+		{at(2, 18), at(2, 20), 2},
+		{at(2, 20), at(2, 22), 3},
+		{at(2, 22), at(3, 0), 2},
+		{at(3, 0), at(3, 6), 2},
+	}
+	if len(coverage.Overlaps) != len(overlaps) {
+		t.Fatalf("expected %d overlaps, got %+v", len(overlaps), coverage.Overlaps)
+	}
+	for i, overlap := range overlaps {
+		got := coverage.Overlaps[i]
+		if !got.Start.Equal(overlap.start) || !got.End.Equal(overlap.end) || len(got.Shifts) != overlap.shifts {
+			t.Errorf("expected an overlap of %d shifts from %s to %s, got %d shifts from %s to %s", overlap.shifts, overlap.start, overlap.end, len(got.Shifts), got.Start, got.End)
+		}
+	}
+
+	// overlapping shifts of jane count once
+	loads := []ScheduleUserLoad{
+		{User: jane, Shifts: 4, Total: 36 * time.Hour, Night: 16 * time.Hour, Weekend: 12 * time.Hour},
+		{User: alex, Shifts: 1, Total: 24 * time.Hour, Night: 8 * time.Hour, Weekend: 24 * time.Hour},
+		{User: john, Shifts: 2, Total: 18 * time.Hour, Night: 8 * time.Hour, Weekend: 18 * time.Hour},
+	}
+	if len(coverage.Users) != len(loads) {
+		t.Fatalf("expected the load of %d users, got %+v", len(loads), coverage.Users)
+	}
+	for i, load := range loads {
+		if got := coverage.Users[i]; got != load {
+			t.Errorf("expected load %+v, got %+v", load, got)
+		}
+	}
+
+	// the adjacent shifts of jane on March 1 are merged and her shift on March 2 is contained in another one
+	if len(coverage.BackToBack) != 1 {
+		t.Fatalf("expected john's shifts to be back-to-back, got %+v", coverage.BackToBack)
+	}
+	backToBack := coverage.BackToBack[0]
+	if backToBack.User != john || backToBack.First != shifts[2] || backToBack.Second != shifts[4] || backToBack.Rest != 6*time.Hour {
+		t.Errorf("unexpected back-to-back shifts %+v", backToBack)
+	}
+}
+
+func TestAnalyzeScheduleCoverageMergesHandoversToTheSameUser(t *testing.T) {
+	jane := User{ID: 1, Username: "jane"}
+	shifts := []Shift{
+		{User: jane, Start: "2024-03-04T08:00:00Z", End: "2024-03-04T20:00:00Z"},
+		{User: jane, Start: "2024-03-04T20:00:00Z", End: "2024-03-05T08:00:00Z"},
+		{User: jane, Start: "2024-03-05T08:00:00Z", End: "2024-03-05T20:00:00Z"},
+		{User: jane, Start: "2024-03-06T02:00:00Z", End: "2024-03-06T08:00:00Z"},
+	}
+
+	coverage, err := AnalyzeScheduleCoverage(shifts, time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC), time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(coverage.BackToBack) != 1 {
+		t.Fatalf("expected one back-to-back after the merged shifts, got %+v", coverage.BackToBack)
+	}
+	if backToBack := coverage.BackToBack[0]; backToBack.First != shifts[2] || backToBack.Second != shifts[3] || backToBack.Rest != 6*time.Hour {
+		t.Errorf("expected the rest after the last merged shift, got %+v", backToBack)
+	}
+}
+
+func TestAnalyzeScheduleCoverageOptions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alex := User{ID: 3, Username: "alex"}
+	shifts := []Shift{{User: alex, Start: "2024-03-03T00:00:00Z", End: "2024-03-04T00:00:00Z"}}
+
+	coverage, err := AnalyzeScheduleCoverage(shifts, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		WithCoverageLocation(berlin), WithNightHours("23:00", "07:00"), WithWeekendDays(time.Sunday))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 23:00 to 07:00 and Sunday in Berlin are 22:00 to 06:00 and Saturday 23:00 to Sunday 23:00 in UTC
+	load := coverage.Users[0]
+	if load.Night != 8*time.Hour || load.Weekend != 23*time.Hour {
+		t.Errorf("expected 8 night and 23 weekend hours, got %s and %s", load.Night, load.Weekend)
+	}
+}
+
+func TestAnalyzeScheduleCoverageErrors(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := from.Add(24 * time.Hour)
+	tests := []struct {
+		name    string
+		shifts  []Shift
+		until   time.Time
+		options []ScheduleCoverageOption
+	}{
+		{name: "empty window", until: from},
+		{name: "invalid night hours", until: until, options: []ScheduleCoverageOption{WithNightHours("10pm", "06:00")}},
+		{name: "invalid shift", shifts: []Shift{{Start: "01.03.2024", End: "2024-03-02"}}, until: until},
+	}
+	for _, test := range tests {
+		if _, err := AnalyzeScheduleCoverage(test.shifts, from, test.until, test.options...); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+package ilert
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RenderSchedule renders the shifts of a schedule between from and until without calling the API,
+// e.g. to preview a rotation before calling CreateSchedule or UpdateSchedule.
+//
+// Recurring schedules are rendered from their layers: every layer hands over to the next of its users after
+// each rotation starting at StartsOn until EndsOn and is only active within its restrictions. Layers later in
+// the list take precedence over earlier ones. Static schedules use their shifts. Overrides take precedence over
+// both, later overrides over earlier ones.
+//
+// Handoffs and restrictions are computed on the wall clock of the schedule's timezone, a daily rotation
+// starting at 09:00 hands over at 09:00 local time before and after a daylight saving time change.
+// Adjacent shifts of the same user are merged, shifts are clipped to the window.
+func RenderSchedule(schedule *Schedule, from time.Time, until time.Time, overrides ...Shift) ([]Shift, error) {
+	if schedule == nil {
+		return nil, errors.New("schedule is required")
+	}
+	if !until.After(from) {
+		return nil, errors.New("until must be after from")
+	}
+	loc := time.UTC
+	if schedule.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule timezone %q: %w", schedule.Timezone, err)
+		}
+	}
+
+	// segments are ordered by precedence, later segments win
+	segments := make([]scheduleSegment, 0)
+	if schedule.Type == ScheduleType.Static || len(schedule.ScheduleLayers) == 0 {
+		for i, shift := range schedule.Shifts {
+			segment, err := shiftSegment(shift, loc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid shift #%d: %w", i+1, err)
+			}
+			segments = append(segments, segment)
+		}
+	} else {
+		for i, layer := range schedule.ScheduleLayers {
+			layerSegments, err := renderLayer(layer, loc, from, until)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schedule layer #%d %q: %w", i+1, layer.Name, err)
+			}
+			segments = append(segments, layerSegments...)
+		}
+	}
+	for i, override := range overrides {
+		segment, err := shiftSegment(override, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid override #%d: %w", i+1, err)
+		}
+		segments = append(segments, segment)
+	}
+
+	return composeSegments(segments, from, until, loc), nil
+}
+
+// scheduleSegment is a time range in which a user is on call
+type scheduleSegment struct {
+	user  User
+	start time.Time
+	end   time.Time
+}
+
+func shiftSegment(shift Shift, loc *time.Location) (scheduleSegment, error) {
+	start, err := ParseScheduleTime(shift.Start, loc)
+	if err != nil {
+		return scheduleSegment{}, err
+	}
+	end, err := ParseScheduleTime(shift.End, loc)
+	if err != nil {
+		return scheduleSegment{}, err
+	}
+	return scheduleSegment{user: shift.User, start: start, end: end}, nil
+}
+
+// renderLayer returns the segments of a layer overlapping the window
+func renderLayer(layer ScheduleLayer, loc *time.Location, from time.Time, until time.Time) ([]scheduleSegment, error) {
+	if len(layer.Users) == 0 {
+		return nil, nil
+	}
+	rotation, err := parseScheduleDuration(layer.Rotation)
+	if err != nil {
+		return nil, err
+	}
+	startsOn, err := ParseScheduleTime(layer.StartsOn, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid startsOn: %w", err)
+	}
+	startsOn = startsOn.In(loc)
+	if layer.EndsOn != "" {
+		endsOn, err := ParseScheduleTime(layer.EndsOn, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endsOn: %w", err)
+		}
+		if endsOn.Before(until) {
+			until = endsOn
+		}
+	}
+	if from.Before(startsOn) {
+		from = startsOn
+	}
+	if !until.After(from) {
+		return nil, nil
+	}
+	restrictions, err := parseRestrictions(layer)
+	if err != nil {
+		return nil, err
+	}
+
+	// estimate the first rotation overlapping the window and correct the estimate on the wall clock
+	k := int64(from.Sub(startsOn) / rotation.approximate())
+	if k < 0 {
+		k = 0
+	}
+	for k > 0 && rotation.add(startsOn, k).After(from) {
+		k--
+	}
+	for !rotation.add(startsOn, k+1).After(from) {
+		k++
+	}
+
+	segments := make([]scheduleSegment, 0)
+	for {
+		start := rotation.add(startsOn, k)
+		if !start.Before(until) {
+			break
+		}
+		end := rotation.add(startsOn, k+1)
+		user := layer.Users[int(k%int64(len(layer.Users)))]
+		periodStart, periodEnd := maxTime(start, from), minTime(end, until)
+		if restrictions == nil {
+			segments = append(segments, scheduleSegment{user: user, start: periodStart, end: periodEnd})
+		} else {
+			for _, window := range restrictions.ranges(periodStart, periodEnd, loc) {
+				segments = append(segments, scheduleSegment{user: user, start: window[0], end: window[1]})
+			}
+		}
+		k++
+	}
+	return segments, nil
+}
+
+// composeSegments resolves overlapping segments by precedence and merges adjacent segments of the same user
+func composeSegments(segments []scheduleSegment, from time.Time, until time.Time, loc *time.Location) []Shift {
+	bounds := []time.Time{from, until}
+	for _, segment := range segments {
+		if segment.end.After(from) && segment.start.Before(until) {
+			bounds = append(bounds, maxTime(segment.start, from), minTime(segment.end, until))
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	shifts := make([]Shift, 0)
+	var current *scheduleSegment
+	flush := func() {
+		if current != nil {
+			shifts = append(shifts, Shift{
+				User:  current.user,
+				Start: current.start.In(loc).Format(time.RFC3339),
+				End:   current.end.In(loc).Format(time.RFC3339),
+			})
+			current = nil
+		}
+	}
+	for i := 0; i+1 < len(bounds); i++ {
+		start, end := bounds[i], bounds[i+1]
+		if !end.After(start) {
+			continue
+		}
+		var winner *scheduleSegment
+		for j := len(segments) - 1; j >= 0; j-- {
+			if !segments[j].start.After(start) && !segments[j].end.Before(end) {
+				winner = &segments[j]
+				break
+			}
+		}
+		if winner == nil {
+			flush()
+			continue
+		}
+		if current != nil && current.end.Equal(start) && SameUser(current.user, winner.user) {
+			current.end = end
+			continue
+		}
+		flush()
+		current = &scheduleSegment{user: winner.user, start: start, end: end}
+	}
+	flush()
+	return shifts
+}
+
+// SameUser compares users by id, falling back to username and the case insensitive email if neither user has an id
+func SameUser(a User, b User) bool {
+	if a.ID != 0 || b.ID != 0 {
+		return a.ID == b.ID
+	}
+	if a.Username != "" || b.Username != "" {
+		return a.Username == b.Username
+	}
+	return strings.EqualFold(a.Email, b.Email)
+}
+
+// UserDisplayName returns the full name of the user, falling back to the username, email and id
+func UserDisplayName(user User) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	if user.Username != "" {
+		return user.Username
+	}
+	if user.Email != "" {
+		return user.Email
+	}
+	return fmt.Sprint(user.ID)
+}
+
+// scheduleDuration is an ISO 8601 duration split into its calendar and clock parts
+type scheduleDuration struct {
+	years  int
+	months int
+	days   int
+	clock  time.Duration
+}
+
+var scheduleDurationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseScheduleDuration parses ISO 8601 durations like P7D, P1W or PT12H
+func parseScheduleDuration(value string) (scheduleDuration, error) {
+	m := scheduleDurationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return scheduleDuration{}, fmt.Errorf("invalid rotation %q", value)
+	}
+	n := make([]int, len(m))
+	for i := 1; i < len(m); i++ {
+		if m[i] != "" {
+			v, err := strconv.Atoi(m[i])
+			if err != nil {
+				return scheduleDuration{}, fmt.Errorf("invalid rotation %q", value)
+			}
+			n[i] = v
+		}
+	}
+	d := scheduleDuration{
+		years:  n[1],
+		months: n[2],
+		days:   n[3]*7 + n[4],
+		clock:  time.Duration(n[5])*time.Hour + time.Duration(n[6])*time.Minute + time.Duration(n[7])*time.Second,
+	}
+	if d.approximate() <= 0 {
+		return scheduleDuration{}, fmt.Errorf("invalid rotation %q", value)
+	}
+	return d, nil
+}
+
+// add returns t plus n times the duration, calendar parts are added on the wall clock of t's location.
+// Months are clamped to their last day, e.g. January 31 plus one month is the last day of February.
+func (d scheduleDuration) add(t time.Time, n int64) time.Time {
+	if d.years != 0 || d.months != 0 {
+		months := t.Month() + time.Month((d.years*12+d.months)*int(n))
+		day := t.Day()
+		if last := time.Date(t.Year(), months+1, 0, 0, 0, 0, 0, t.Location()).Day(); day > last {
+			day = last
+		}
+		t = time.Date(t.Year(), months, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	return t.AddDate(0, 0, d.days*int(n)).Add(d.clock * time.Duration(n))
+}
+
+// approximate returns the duration assuming days of 24 hours and months of 30.44 days
+func (d scheduleDuration) approximate() time.Duration {
+	days := float64(d.years)*365.25 + float64(d.months)*30.44 + float64(d.days)
+	return time.Duration(days*float64(24*time.Hour)) + d.clock
+}
+
+// layerRestrictions describes the windows in which a layer is active
+type layerRestrictions struct {
+	restrictionType string
+	windows         []restrictionWindow
+}
+
+// restrictionWindow is a daily or weekly window, days are 0 (Monday) to 6 (Sunday) and times are minutes of the day
+type restrictionWindow struct {
+	fromDay, fromMinute int
+	toDay, toMinute     int
+}
+
+func parseRestrictions(layer ScheduleLayer) (*layerRestrictions, error) {
+	if len(layer.Restrictions) == 0 {
+		return nil, nil
+	}
+	restrictionType := layer.RestrictionType
+	if restrictionType == "" {
+		restrictionType = RestrictionType.TimeOfWeek
+	}
+	if restrictionType != RestrictionType.TimeOfDay && restrictionType != RestrictionType.TimeOfWeek {
+		return nil, fmt.Errorf("invalid restriction type %q", restrictionType)
+	}
+	r := &layerRestrictions{restrictionType: restrictionType}
+	for i, restriction := range layer.Restrictions {
+		if restriction.From == nil || restriction.To == nil {
+			return nil, fmt.Errorf("restriction #%d requires from and to", i+1)
+		}
+		window := restrictionWindow{}
+		var err error
+		if window.fromMinute, err = parseTimeOfDay(restriction.From.Time); err != nil {
+			return nil, fmt.Errorf("restriction #%d: %w", i+1, err)
+		}
+		if window.toMinute, err = parseTimeOfDay(restriction.To.Time); err != nil {
+			return nil, fmt.Errorf("restriction #%d: %w", i+1, err)
+		}
+		if restrictionType == RestrictionType.TimeOfWeek {
+			if window.fromDay, err = parseDayOfWeek(restriction.From.DayOfWeek); err != nil {
+				return nil, fmt.Errorf("restriction #%d: %w", i+1, err)
+			}
+			if window.toDay, err = parseDayOfWeek(restriction.To.DayOfWeek); err != nil {
+				return nil, fmt.Errorf("restriction #%d: %w", i+1, err)
+			}
+		}
+		r.windows = append(r.windows, window)
+	}
+	return r, nil
+}
+
+// ranges returns the active time ranges within start and end, sorted and without overlaps
+func (r *layerRestrictions) ranges(start time.Time, end time.Time, loc *time.Location) [][2]time.Time {
+	local := start.In(loc)
+	// begin one week early to catch windows that started before and wrap into the range
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, -7)
+	if r.restrictionType == RestrictionType.TimeOfWeek {
+		day = day.AddDate(0, 0, -weekdayIndex(day.Weekday()))
+	}
+
+	ranges := make([][2]time.Time, 0)
+	for !day.After(end) {
+		for _, w := range r.windows {
+			var from, to time.Time
+			if r.restrictionType == RestrictionType.TimeOfDay {
+				from = atMinute(day, 0, w.fromMinute, loc)
+				to = atMinute(day, 0, w.toMinute, loc)
+				if !to.After(from) {
+					to = atMinute(day, 1, w.toMinute, loc)
+				}
+			} else {
+				from = atMinute(day, w.fromDay, w.fromMinute, loc)
+				to = atMinute(day, w.toDay, w.toMinute, loc)
+				if !to.After(from) {
+					to = atMinute(day, w.toDay+7, w.toMinute, loc)
+				}
+			}
+			from, to = maxTime(from, start), minTime(to, end)
+			if to.After(from) {
+				ranges = append(ranges, [2]time.Time{from, to})
+			}
+		}
+		if r.restrictionType == RestrictionType.TimeOfDay {
+			day = day.AddDate(0, 0, 1)
+		} else {
+			day = day.AddDate(0, 0, 7)
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0].Before(ranges[j][0]) })
+	merged := make([][2]time.Time, 0, len(ranges))
+	for _, rng := range ranges {
+		if n := len(merged); n > 0 && !rng[0].After(merged[n-1][1]) {
+			merged[n-1][1] = maxTime(merged[n-1][1], rng[1])
+			continue
+		}
+		merged = append(merged, rng)
+	}
+	return merged
+}
+
+// atMinute returns the given minute of the day that is offset days after day on the wall clock
+func atMinute(day time.Time, offset int, minute int, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+offset, minute/60, minute%60, 0, 0, loc)
+}
+
+// parseTimeOfDay parses a time like 15:00 into minutes of the day
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		t, err = time.Parse("15:04:05", value)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseDayOfWeek returns the index of a DayOfWeek value, 0 is Monday
+func parseDayOfWeek(value string) (int, error) {
+	for i, day := range DayOfWeekAll {
+		if strings.EqualFold(day, value) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day of week %q", value)
+}
+
+func weekdayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// ParseScheduleTime parses an ISO date time of a schedule, shift or exception, values without offset
+// are interpreted in the given location
+func ParseScheduleTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05.999999999", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date time %q", value)
+}
+
+func minTime(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,180 @@
+package ilert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSameUser(t *testing.T) {
+	tests := []struct {
+		a, b User
+		want bool
+	}{
+		{User{ID: 1, Username: "jane"}, User{ID: 1, Username: "john"}, true},
+		{User{ID: 1, Username: "jane"}, User{ID: 2, Username: "jane"}, false},
+		{User{ID: 1, Email: "jane@example.com"}, User{Email: "jane@example.com"}, false},
+		{User{Username: "jane", Email: "a@example.com"}, User{Username: "jane", Email: "b@example.com"}, true},
+		{User{Email: "Jane@Example.com"}, User{Email: "jane@example.com"}, true},
+		{User{}, User{}, true},
+	}
+	for _, test := range tests {
+		if got := SameUser(test.a, test.b); got != test.want {
+			t.Errorf("%+v, %+v: expected %v, got %v", test.a, test.b, test.want, got)
+		}
+	}
+}
+
+func TestUserDisplayName(t *testing.T) {
+	tests := []struct {
+		user User
+		want string
+	}{
+		{User{ID: 1, FirstName: "Jane", LastName: "Doe", Username: "jane"}, "Jane Doe"},
+		{User{ID: 1, FirstName: "Jane", Username: "jane"}, "Jane"},
+		{User{ID: 1, Username: "jane", Email: "jane@example.com"}, "jane"},
+		{User{ID: 1, Email: "jane@example.com"}, "jane@example.com"},
+		{User{ID: 1}, "1"},
+	}
+	for _, test := range tests {
+		if got := UserDisplayName(test.user); got != test.want {
+			t.Errorf("%+v: expected %q, got %q", test.user, test.want, got)
+		}
+	}
+}
+
+func TestParseScheduleTime(t *testing.T) {
+	loc := time.FixedZone("CET", 3600)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-03-01T08:00:00Z", time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)},
+		{"2024-03-01T08:00:00+02:00", time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC)},
+		{"2024-03-01T08:00:00", time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)},
+		{"2024-03-01T08:00", time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)},
+		{"2024-03-01", time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		got, err := ParseScheduleTime(test.value, loc)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.value, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("%s: expected %s, got %s", test.value, test.want, got)
+		}
+	}
+	if _, err := ParseScheduleTime("01.03.2024", loc); err == nil {
+		t.Error("expected an invalid date time to fail")
+	}
+}
+
+func TestRenderSchedule(t *testing.T) {
+	jane := User{ID: 1, Username: "jane"}
+	john := User{ID: 2, Username: "john"}
+	alex := User{ID: 3, Username: "alex"}
+	tests := []struct {
+		name      string
+		schedule  *Schedule
+		overrides []Shift
+		from      string
+		until     string
+		want      []Shift
+	}{
+		{
+			name: "daily rotation across daylight saving time",
+			schedule: &Schedule{
+				Timezone: "Europe/Berlin",
+				Type:     ScheduleType.Recurring,
+				ScheduleLayers: []ScheduleLayer{
+					{StartsOn: "2024-03-29T09:00", Users: []User{jane, john, alex}, Rotation: "P1D"},
+				},
+			},
+			from:  "2024-03-30T08:00:00Z",
+			until: "2024-04-01T07:00:00Z",
+			want: []Shift{
+				{User: john, Start: "2024-03-30T09:00:00+01:00", End: "2024-03-31T09:00:00+02:00"},
+				{User: alex, Start: "2024-03-31T09:00:00+02:00", End: "2024-04-01T09:00:00+02:00"},
+			},
+		},
+		{
+			name: "time of day restriction past midnight",
+			schedule: &Schedule{
+				Timezone: "UTC",
+				Type:     ScheduleType.Recurring,
+				ScheduleLayers: []ScheduleLayer{
+					{
+						StartsOn:        "2024-03-04T00:00:00Z",
+						Users:           []User{jane},
+						Rotation:        "P7D",
+						RestrictionType: RestrictionType.TimeOfDay,
+						Restrictions:    []LayerRestriction{{From: &TimeOfWeek{Time: "22:00"}, To: &TimeOfWeek{Time: "06:00"}}},
+					},
+				},
+			},
+			from:  "2024-03-04T00:00:00Z",
+			until: "2024-03-06T00:00:00Z",
+			want: []Shift{
+				{User: jane, Start: "2024-03-04T00:00:00Z", End: "2024-03-04T06:00:00Z"},
+				{User: jane, Start: "2024-03-04T22:00:00Z", End: "2024-03-05T06:00:00Z"},
+				{User: jane, Start: "2024-03-05T22:00:00Z", End: "2024-03-06T00:00:00Z"},
+			},
+		},
+		{
+			name: "override splitting a shift",
+			schedule: &Schedule{
+				Timezone: "UTC",
+				Type:     ScheduleType.Recurring,
+				ScheduleLayers: []ScheduleLayer{
+					{StartsOn: "2024-03-04T00:00:00Z", Users: []User{jane, john}, Rotation: "P7D"},
+				},
+			},
+			overrides: []Shift{{User: alex, Start: "2024-03-05T08:00:00Z", End: "2024-03-05T20:00:00Z"}},
+			from:      "2024-03-04T00:00:00Z",
+			until:     "2024-03-07T00:00:00Z",
+			want: []Shift{
+				{User: jane, Start: "2024-03-04T00:00:00Z", End: "2024-03-05T08:00:00Z"},
+				{User: alex, Start: "2024-03-05T08:00:00Z", End: "2024-03-05T20:00:00Z"},
+				{User: jane, Start: "2024-03-05T20:00:00Z", End: "2024-03-07T00:00:00Z"},
+			},
+		},
+		{
+			name: "later layer takes precedence",
+			schedule: &Schedule{
+				Timezone: "UTC",
+				Type:     ScheduleType.Recurring,
+				ScheduleLayers: []ScheduleLayer{
+					{StartsOn: "2024-03-04T00:00:00Z", Users: []User{jane}, Rotation: "P7D"},
+					{StartsOn: "2024-03-05T00:00:00Z", EndsOn: "2024-03-06T00:00:00Z", Users: []User{john}, Rotation: "P1D"},
+				},
+			},
+			from:  "2024-03-04T00:00:00Z",
+			until: "2024-03-07T00:00:00Z",
+			want: []Shift{
+				{User: jane, Start: "2024-03-04T00:00:00Z", End: "2024-03-05T00:00:00Z"},
+				{User: john, Start: "2024-03-05T00:00:00Z", End: "2024-03-06T00:00:00Z"},
+				{User: jane, Start: "2024-03-06T00:00:00Z", End: "2024-03-07T00:00:00Z"},
+			},
+		},
+	}
+	for _, test := range tests {
+		from, _ := time.Parse(time.RFC3339, test.from)
+		until, _ := time.Parse(time.RFC3339, test.until)
+		got, err := RenderSchedule(test.schedule, from, until, test.overrides...)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: expected %d shifts, got %+v", test.name, len(test.want), got)
+			continue
+		}
+		for i, shift := range got {
+			want := test.want[i]
+			if shift.User.ID != want.User.ID || shift.Start != want.Start || shift.End != want.End {
+				t.Errorf("%s: expected shift #%d %s %s to %s, got %s %s to %s",
+					test.name, i+1, want.User.Username, want.Start, want.End, shift.User.Username, shift.Start, shift.End)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
@@ -25,6 +26,11 @@ type CreateSingleSeriesInput struct {
 
 // CreateSingleSeries ingests a series for a metric. https://api.ilert.com/api-docs/#tag/Series/paths/~1series~1{key}/post
 func (c *Client) CreateSingleSeries(input *CreateSingleSeriesInput) error {
+	return c.CreateSingleSeriesWithContext(context.Background(), input)
+}
+
+// CreateSingleSeriesWithContext is the same as CreateSingleSeries with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateSingleSeriesWithContext(ctx context.Context, input *CreateSingleSeriesInput) error {
 	if input == nil {
 		return errors.New("input is required")
 	}
@@ -32,7 +38,7 @@ func (c *Client) CreateSingleSeries(input *CreateSingleSeriesInput) error {
 		return errors.New("metric integration key is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Series).Post(fmt.Sprintf("%s/%s", apiRoutes.series, *input.MetricKey))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Series).Post(fmt.Sprintf("%s/%s", apiRoutes.series, *input.MetricKey))
 	if err != nil {
 		return err
 	}
@@ -52,6 +58,11 @@ type CreateMultipleSeriesInput struct {
 
 // CreateMultipleSeries ingests multiple series for a metric. https://api.ilert.com/api-docs/#tag/Series/paths/~1series~1{key}/post
 func (c *Client) CreateMultipleSeries(input *CreateMultipleSeriesInput) error {
+	return c.CreateMultipleSeriesWithContext(context.Background(), input)
+}
+
+// CreateMultipleSeriesWithContext is the same as CreateMultipleSeries with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateMultipleSeriesWithContext(ctx context.Context, input *CreateMultipleSeriesInput) error {
 	if input == nil {
 		return errors.New("input is required")
 	}
@@ -59,7 +70,7 @@ func (c *Client) CreateMultipleSeries(input *CreateMultipleSeriesInput) error {
 		return errors.New("metric integration key is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Series).Post(fmt.Sprintf("%s/%s", apiRoutes.series, *input.MetricKey))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Series).Post(fmt.Sprintf("%s/%s", apiRoutes.series, *input.MetricKey))
 	if err != nil {
 		return err
 	}
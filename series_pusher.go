@@ -0,0 +1,395 @@
+package ilert
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrSeriesPusherClosed is returned when observations are recorded on a closed SeriesPusher
+var ErrSeriesPusherClosed = errors.New("series pusher is closed")
+
+// SeriesPusherStats describes the counters of a SeriesPusher
+type SeriesPusherStats struct {
+	// gauge observations and counter increments recorded
+	Observations int64
+
+	// aggregated series values accepted by the series API
+	Pushed int64
+
+	// aggregated series values dropped because the pending buffer of their metric was full
+	// or the series API rejected them with a non-retryable error
+	Dropped int64
+
+	// failed CreateMultipleSeries calls
+	Failures int64
+
+	// aggregated series values waiting to be pushed
+	Pending int
+}
+
+// seriesBucket aggregates the observations of a metric within one flush interval
+type seriesBucket struct {
+	count   int64
+	sum     float64
+	min     float64
+	max     float64
+	last    float64
+	counter bool
+}
+
+func (b *seriesBucket) observe(value float64) {
+	if b.count == 0 || value < b.min {
+		b.min = value
+	}
+	if b.count == 0 || value > b.max {
+		b.max = value
+	}
+	b.count++
+	b.sum += value
+	b.last = value
+}
+
+// value returns the aggregated value of the bucket, counter increments are always summed up
+func (b *seriesBucket) value(aggregationType string) float64 {
+	if b.counter {
+		return b.sum
+	}
+	switch aggregationType {
+	case MetricAggregationType.Sum:
+		return b.sum
+	case MetricAggregationType.Minimum:
+		return b.min
+	case MetricAggregationType.Maximum:
+		return b.max
+	case MetricAggregationType.Last:
+		return b.last
+	default:
+		return b.sum / float64(b.count)
+	}
+}
+
+// seriesState holds the current bucket and the aggregated values not yet pushed of a metric
+type seriesState struct {
+	aggregationType string
+	bucket          *seriesBucket
+	pending         []SingleSeries
+}
+
+// SeriesPusher accumulates gauge observations and counter increments per metric integration key,
+// aggregates them in-process according to the metric's aggregation type and pushes one value per metric
+// and interval using CreateMultipleSeries.
+//
+// Values that could not be pushed due to network errors, 5xx or 429 responses are kept and pushed
+// with the next flush. The number of pending values per metric is bounded, the oldest values are dropped
+// once the bound is reached so that an unavailable API never blocks or exhausts the application.
+type SeriesPusher struct {
+	client             *Client
+	interval           time.Duration
+	defaultAggregation string
+	maxPending         int
+	batchSize          int
+	onError            func(metricKey string, err error)
+	now                func() time.Time
+
+	mu     sync.Mutex
+	series map[string]*seriesState
+	stats  SeriesPusherStats
+	closed bool
+
+	flushMu sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// SeriesPusherOptions allows for options to be passed into the SeriesPusher for customization
+type SeriesPusherOptions func(*SeriesPusher)
+
+// WithSeriesPusherInterval sets the interval in which observations are aggregated and pushed
+// Default: 10 seconds
+func WithSeriesPusherInterval(interval time.Duration) SeriesPusherOptions {
+	return func(p *SeriesPusher) {
+		p.interval = interval
+	}
+}
+
+// WithSeriesPusherAggregation sets the aggregation type of metrics without a registered aggregation type, see MetricAggregationType
+// Default: AVG
+func WithSeriesPusherAggregation(aggregationType string) SeriesPusherOptions {
+	return func(p *SeriesPusher) {
+		p.defaultAggregation = aggregationType
+	}
+}
+
+// WithSeriesPusherMaxPending sets the number of aggregated values kept per metric while the series API is unavailable
+// Default: 1000
+func WithSeriesPusherMaxPending(maxPending int) SeriesPusherOptions {
+	return func(p *SeriesPusher) {
+		p.maxPending = maxPending
+	}
+}
+
+// WithSeriesPusherBatchSize sets the max number of values sent per CreateMultipleSeries call
+// Default: 100
+func WithSeriesPusherBatchSize(batchSize int) SeriesPusherOptions {
+	return func(p *SeriesPusher) {
+		p.batchSize = batchSize
+	}
+}
+
+// WithSeriesPusherErrorCallback sets a callback that is invoked for every failed CreateMultipleSeries call
+func WithSeriesPusherErrorCallback(callback func(metricKey string, err error)) SeriesPusherOptions {
+	return func(p *SeriesPusher) {
+		p.onError = callback
+	}
+}
+
+// NewSeriesPusher creates and starts a SeriesPusher that flushes on every interval until it is closed
+func NewSeriesPusher(client *Client, options ...SeriesPusherOptions) (*SeriesPusher, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	p := &SeriesPusher{
+		client:             client,
+		interval:           10 * time.Second,
+		defaultAggregation: MetricAggregationType.Average,
+		maxPending:         1000,
+		batchSize:          100,
+		now:                time.Now,
+		series:             make(map[string]*seriesState),
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	if p.interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	if !isMetricAggregationType(p.defaultAggregation) {
+		return nil, errors.New("invalid aggregation type")
+	}
+	if p.maxPending < 1 {
+		p.maxPending = 1
+	}
+	if p.batchSize < 1 {
+		p.batchSize = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.run(ctx)
+
+	return p, nil
+}
+
+// SetAggregation sets the aggregation type of a metric integration key, see MetricAggregationType
+func (p *SeriesPusher) SetAggregation(metricKey string, aggregationType string) error {
+	if metricKey == "" {
+		return errors.New("metric integration key is required")
+	}
+	if !isMetricAggregationType(aggregationType) {
+		return errors.New("invalid aggregation type")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state(metricKey).aggregationType = aggregationType
+	return nil
+}
+
+// RegisterMetric sets the aggregation type of a metric's integration key to the metric's aggregation type
+func (p *SeriesPusher) RegisterMetric(metric *Metric) error {
+	if metric == nil {
+		return errors.New("metric is required")
+	}
+	aggregationType := metric.AggregationType
+	if aggregationType == "" {
+		aggregationType = p.defaultAggregation
+	}
+	return p.SetAggregation(metric.IntegrationKey, aggregationType)
+}
+
+// Observe records a gauge observation, observations within an interval are aggregated by the metric's aggregation type
+func (p *SeriesPusher) Observe(metricKey string, value float64) error {
+	return p.record(metricKey, value, false)
+}
+
+// Add records a counter increment, increments within an interval are summed up regardless of the aggregation type
+func (p *SeriesPusher) Add(metricKey string, delta float64) error {
+	return p.record(metricKey, delta, true)
+}
+
+// Flush aggregates the current interval and pushes all pending values
+func (p *SeriesPusher) Flush(ctx context.Context) error {
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+
+	p.mu.Lock()
+	p.aggregate()
+	keys := make([]string, 0, len(p.series))
+	for key, state := range p.series {
+		if len(state.pending) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, key := range keys {
+		if err := p.push(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the interval flushes and pushes the remaining values
+func (p *SeriesPusher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.cancel()
+	<-p.done
+	return p.Flush(ctx)
+}
+
+// Stats returns the current counters
+func (p *SeriesPusher) Stats() SeriesPusherStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	for _, state := range p.series {
+		stats.Pending += len(state.pending)
+	}
+	return stats
+}
+
+func (p *SeriesPusher) run(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Flush(ctx)
+		}
+	}
+}
+
+func (p *SeriesPusher) record(metricKey string, value float64, counter bool) error {
+	if metricKey == "" {
+		return errors.New("metric integration key is required")
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return errors.New("value must be a finite number")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrSeriesPusherClosed
+	}
+	state := p.state(metricKey)
+	if state.bucket == nil {
+		state.bucket = &seriesBucket{}
+	}
+	state.bucket.counter = state.bucket.counter || counter
+	state.bucket.observe(value)
+	p.stats.Observations++
+	return nil
+}
+
+// state returns the state of a metric integration key, the pusher must be locked by the caller
+func (p *SeriesPusher) state(metricKey string) *seriesState {
+	state, ok := p.series[metricKey]
+	if !ok {
+		state = &seriesState{}
+		p.series[metricKey] = state
+	}
+	return state
+}
+
+// aggregate closes the current bucket of every metric, the pusher must be locked by the caller
+func (p *SeriesPusher) aggregate() {
+	timestamp := p.now().Unix()
+	for _, state := range p.series {
+		if state.bucket == nil {
+			continue
+		}
+		aggregationType := state.aggregationType
+		if aggregationType == "" {
+			aggregationType = p.defaultAggregation
+		}
+		state.pending = append(state.pending, SingleSeries{Timestamp: timestamp, Value: state.bucket.value(aggregationType)})
+		state.bucket = nil
+		p.trim(state)
+	}
+}
+
+// trim drops the oldest pending values beyond the max pending bound, the pusher must be locked by the caller
+func (p *SeriesPusher) trim(state *seriesState) {
+	if overflow := len(state.pending) - p.maxPending; overflow > 0 {
+		state.pending = append([]SingleSeries(nil), state.pending[overflow:]...)
+		p.stats.Dropped += int64(overflow)
+	}
+}
+
+// push sends the pending values of a metric in batches, values of retryable failures are put back
+func (p *SeriesPusher) push(ctx context.Context, metricKey string) error {
+	p.mu.Lock()
+	state := p.series[metricKey]
+	pending := state.pending
+	state.pending = nil
+	p.mu.Unlock()
+
+	for len(pending) > 0 {
+		n := p.batchSize
+		if n > len(pending) {
+			n = len(pending)
+		}
+		batch := pending[:n]
+		err := p.client.CreateMultipleSeriesWithContext(ctx, &CreateMultipleSeriesInput{
+			MetricKey: String(metricKey),
+			Series:    &MultipleSeries{Series: batch},
+		})
+
+		p.mu.Lock()
+		if err == nil {
+			p.stats.Pushed += int64(n)
+			pending = pending[n:]
+			p.mu.Unlock()
+			continue
+		}
+		p.stats.Failures++
+		if isRetryableError(err) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			// values recorded in the meantime are newer and go behind the ones that failed
+			state.pending = append(append(make([]SingleSeries, 0, len(pending)+len(state.pending)), pending...), state.pending...)
+			p.trim(state)
+		} else {
+			p.stats.Dropped += int64(len(pending))
+		}
+		p.mu.Unlock()
+
+		if p.onError != nil {
+			p.onError(metricKey, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func isMetricAggregationType(aggregationType string) bool {
+	for _, t := range MetricAggregationTypeAll {
+		if t == aggregationType {
+			return true
+		}
+	}
+	return false
+}
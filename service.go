@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -100,13 +101,18 @@ type CreateServiceOutput struct {
 
 // CreateService creates a new service. https://api.ilert.com/api-docs/#tag/Services/paths/~1services/post
 func (c *Client) CreateService(input *CreateServiceInput) (*CreateServiceOutput, error) {
+	return c.CreateServiceWithContext(context.Background(), input)
+}
+
+// CreateServiceWithContext is the same as CreateService with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateServiceWithContext(ctx context.Context, input *CreateServiceInput) (*CreateServiceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.Service == nil {
 		return nil, errors.New("service input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Service).Post(apiRoutes.services)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Service).Post(apiRoutes.services)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +152,11 @@ type GetServicesOutput struct {
 
 // GetServices lists existing services. https://api.ilert.com/api-docs/#tag/Services/paths/~1services/get
 func (c *Client) GetServices(input *GetServicesInput) (*GetServicesOutput, error) {
+	return c.GetServicesWithContext(context.Background(), input)
+}
+
+// GetServicesWithContext is the same as GetServices with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetServicesWithContext(ctx context.Context, input *GetServicesInput) (*GetServicesOutput, error) {
 	if input == nil {
 		input = &GetServicesInput{}
 	}
@@ -166,7 +177,7 @@ func (c *Client) GetServices(input *GetServicesInput) (*GetServicesOutput, error
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.services, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.services, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +194,36 @@ func (c *Client) GetServices(input *GetServicesInput) (*GetServicesOutput, error
 	return &GetServicesOutput{Services: services}, nil
 }
 
+// GetServicesPages iterates over all pages of a GetServices operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetServicesPages(input *GetServicesInput, fn func(page *GetServicesOutput) bool, options ...PaginateOption) error {
+	return c.GetServicesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetServicesPagesWithContext is the same as GetServicesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetServicesPagesWithContext(ctx context.Context, input *GetServicesInput, fn func(page *GetServicesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetServicesInput{}
+	}
+	maxPageSize := 100
+	if len(input.Include) > 0 {
+		maxPageSize = 25
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*Service, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetServicesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Services, nil
+	}
+	return Paginate(ctx, fetch, func(page []*Service) bool {
+		return fn(&GetServicesOutput{Services: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, maxPageSize, options)...)
+}
+
 // GetServiceInput represents the input of a GetService operation.
 type GetServiceInput struct {
 	_         struct{}
@@ -200,6 +241,11 @@ type GetServiceOutput struct {
 
 // GetService gets a service by id. https://api.ilert.com/api-docs/#tag/Services/paths/~1services~1{id}/get
 func (c *Client) GetService(input *GetServiceInput) (*GetServiceOutput, error) {
+	return c.GetServiceWithContext(context.Background(), input)
+}
+
+// GetServiceWithContext is the same as GetService with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetServiceWithContext(ctx context.Context, input *GetServiceInput) (*GetServiceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -215,7 +261,7 @@ func (c *Client) GetService(input *GetServiceInput) (*GetServiceOutput, error) {
 
 	var url = fmt.Sprintf("%s/%d?%s", apiRoutes.services, *input.ServiceID, q.Encode())
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -246,6 +292,11 @@ type GetServiceSubscribersOutput struct {
 
 // GetServiceSubscribers gets subscribers of a service by id. https://api.ilert.com/api-docs/#tag/Services/paths/~1services~1{id}~1private-subscribers/get
 func (c *Client) GetServiceSubscribers(input *GetServiceSubscribersInput) (*GetServiceSubscribersOutput, error) {
+	return c.GetServiceSubscribersWithContext(context.Background(), input)
+}
+
+// GetServiceSubscribersWithContext is the same as GetServiceSubscribers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetServiceSubscribersWithContext(ctx context.Context, input *GetServiceSubscribersInput) (*GetServiceSubscribersOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -255,7 +306,7 @@ func (c *Client) GetServiceSubscribers(input *GetServiceSubscribersInput) (*GetS
 
 	var url = fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.services, *input.ServiceID)
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -286,6 +337,11 @@ type SearchServiceOutput struct {
 
 // SearchService gets the service with specified name.
 func (c *Client) SearchService(input *SearchServiceInput) (*SearchServiceOutput, error) {
+	return c.SearchServiceWithContext(context.Background(), input)
+}
+
+// SearchServiceWithContext is the same as SearchService with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchServiceWithContext(ctx context.Context, input *SearchServiceInput) (*SearchServiceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -293,7 +349,7 @@ func (c *Client) SearchService(input *SearchServiceInput) (*SearchServiceOutput,
 		return nil, errors.New("service name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.services, *input.ServiceName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.services, *input.ServiceName))
 	if err != nil {
 		return nil, err
 	}
@@ -325,6 +381,11 @@ type UpdateServiceOutput struct {
 
 // UpdateService updates the specific service. https://api.ilert.com/api-docs/#tag/Services/paths/~1services~1{id}/put
 func (c *Client) UpdateService(input *UpdateServiceInput) (*UpdateServiceOutput, error) {
+	return c.UpdateServiceWithContext(context.Background(), input)
+}
+
+// UpdateServiceWithContext is the same as UpdateService with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateServiceWithContext(ctx context.Context, input *UpdateServiceInput) (*UpdateServiceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -337,7 +398,7 @@ func (c *Client) UpdateService(input *UpdateServiceInput) (*UpdateServiceOutput,
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.services, *input.ServiceID)
 
-	resp, err := c.httpClient.R().SetBody(input.Service).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Service).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -368,6 +429,11 @@ type AddServiceSubscribersOutput struct {
 
 // AddServiceSubscribers adds a new subscriber to a service. https://api.ilert.com/api-docs/#tag/Services/paths/~1services~1{id}~1private-subscribers/post
 func (c *Client) AddServiceSubscribers(input *AddServiceSubscribersInput) (*AddServiceSubscribersOutput, error) {
+	return c.AddServiceSubscribersWithContext(context.Background(), input)
+}
+
+// AddServiceSubscribersWithContext is the same as AddServiceSubscribers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) AddServiceSubscribersWithContext(ctx context.Context, input *AddServiceSubscribersInput) (*AddServiceSubscribersOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -380,7 +446,7 @@ func (c *Client) AddServiceSubscribers(input *AddServiceSubscribersInput) (*AddS
 
 	url := fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.services, *input.ServiceID)
 
-	resp, err := c.httpClient.R().SetBody(input.Subscribers).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Subscribers).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -410,6 +476,11 @@ type DeleteServiceOutput struct {
 
 // DeleteService deletes the specified service. https://api.ilert.com/api-docs/#tag/Services/paths/~1services~1{id}/delete
 func (c *Client) DeleteService(input *DeleteServiceInput) (*DeleteServiceOutput, error) {
+	return c.DeleteServiceWithContext(context.Background(), input)
+}
+
+// DeleteServiceWithContext is the same as DeleteService with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteServiceWithContext(ctx context.Context, input *DeleteServiceInput) (*DeleteServiceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -419,7 +490,7 @@ func (c *Client) DeleteService(input *DeleteServiceInput) (*DeleteServiceOutput,
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.services, *input.ServiceID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -156,13 +157,18 @@ type CreateStatusPageOutput struct {
 
 // CreateStatusPage creates a new status page. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages/post
 func (c *Client) CreateStatusPage(input *CreateStatusPageInput) (*CreateStatusPageOutput, error) {
+	return c.CreateStatusPageWithContext(context.Background(), input)
+}
+
+// CreateStatusPageWithContext is the same as CreateStatusPage with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateStatusPageWithContext(ctx context.Context, input *CreateStatusPageInput) (*CreateStatusPageOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.StatusPage == nil {
 		return nil, errors.New("status page input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.StatusPage).Post(apiRoutes.statusPages)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.StatusPage).Post(apiRoutes.statusPages)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +208,11 @@ type GetStatusPagesOutput struct {
 
 // GetStatusPages lists existing status page. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages/get
 func (c *Client) GetStatusPages(input *GetStatusPagesInput) (*GetStatusPagesOutput, error) {
+	return c.GetStatusPagesWithContext(context.Background(), input)
+}
+
+// GetStatusPagesWithContext is the same as GetStatusPages with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetStatusPagesWithContext(ctx context.Context, input *GetStatusPagesInput) (*GetStatusPagesOutput, error) {
 	if input == nil {
 		input = &GetStatusPagesInput{}
 	}
@@ -222,7 +233,7 @@ func (c *Client) GetStatusPages(input *GetStatusPagesInput) (*GetStatusPagesOutp
 		q.Add("include", *include)
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.statusPages, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.statusPages, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -239,6 +250,32 @@ func (c *Client) GetStatusPages(input *GetStatusPagesInput) (*GetStatusPagesOutp
 	return &GetStatusPagesOutput{StatusPages: statusPages}, nil
 }
 
+// GetStatusPagesPages iterates over all pages of a GetStatusPages operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetStatusPagesPages(input *GetStatusPagesInput, fn func(page *GetStatusPagesOutput) bool, options ...PaginateOption) error {
+	return c.GetStatusPagesPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetStatusPagesPagesWithContext is the same as GetStatusPagesPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetStatusPagesPagesWithContext(ctx context.Context, input *GetStatusPagesInput, fn func(page *GetStatusPagesOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetStatusPagesInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*StatusPage, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetStatusPagesWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.StatusPages, nil
+	}
+	return Paginate(ctx, fetch, func(page []*StatusPage) bool {
+		return fn(&GetStatusPagesOutput{StatusPages: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // GetStatusPageInput represents the input of a GetStatusPage operation.
 type GetStatusPageInput struct {
 	_            struct{}
@@ -256,6 +293,11 @@ type GetStatusPageOutput struct {
 
 // GetStatusPage gets a status page by id. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}/get
 func (c *Client) GetStatusPage(input *GetStatusPageInput) (*GetStatusPageOutput, error) {
+	return c.GetStatusPageWithContext(context.Background(), input)
+}
+
+// GetStatusPageWithContext is the same as GetStatusPage with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetStatusPageWithContext(ctx context.Context, input *GetStatusPageInput) (*GetStatusPageOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -271,7 +313,7 @@ func (c *Client) GetStatusPage(input *GetStatusPageInput) (*GetStatusPageOutput,
 
 	var url = fmt.Sprintf("%s/%d?%s", apiRoutes.statusPages, *input.StatusPageID, q.Encode())
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -302,6 +344,11 @@ type GetStatusPageSubscribersOutput struct {
 
 // GetStatusPageSubscribers gets subscribers of a status page by id. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1private-subscribers/get
 func (c *Client) GetStatusPageSubscribers(input *GetStatusPageSubscribersInput) (*GetStatusPageSubscribersOutput, error) {
+	return c.GetStatusPageSubscribersWithContext(context.Background(), input)
+}
+
+// GetStatusPageSubscribersWithContext is the same as GetStatusPageSubscribers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetStatusPageSubscribersWithContext(ctx context.Context, input *GetStatusPageSubscribersInput) (*GetStatusPageSubscribersOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -311,7 +358,7 @@ func (c *Client) GetStatusPageSubscribers(input *GetStatusPageSubscribersInput)
 
 	var url = fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.statusPages, *input.StatusPageID)
 
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -342,6 +389,11 @@ type SearchStatusPageOutput struct {
 
 // SearchStatusPage gets the status page with specified name.
 func (c *Client) SearchStatusPage(input *SearchStatusPageInput) (*SearchStatusPageOutput, error) {
+	return c.SearchStatusPageWithContext(context.Background(), input)
+}
+
+// SearchStatusPageWithContext is the same as SearchStatusPage with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchStatusPageWithContext(ctx context.Context, input *SearchStatusPageInput) (*SearchStatusPageOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -349,7 +401,7 @@ func (c *Client) SearchStatusPage(input *SearchStatusPageInput) (*SearchStatusPa
 		return nil, errors.New("status page name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.statusPages, *input.StatusPageName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.statusPages, *input.StatusPageName))
 	if err != nil {
 		return nil, err
 	}
@@ -381,6 +433,11 @@ type UpdateStatusPageOutput struct {
 
 // UpdateStatusPage updates the specific status page. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}/put
 func (c *Client) UpdateStatusPage(input *UpdateStatusPageInput) (*UpdateStatusPageOutput, error) {
+	return c.UpdateStatusPageWithContext(context.Background(), input)
+}
+
+// UpdateStatusPageWithContext is the same as UpdateStatusPage with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateStatusPageWithContext(ctx context.Context, input *UpdateStatusPageInput) (*UpdateStatusPageOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -393,7 +450,7 @@ func (c *Client) UpdateStatusPage(input *UpdateStatusPageInput) (*UpdateStatusPa
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.statusPages, *input.StatusPageID)
 
-	resp, err := c.httpClient.R().SetBody(input.StatusPage).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.StatusPage).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -424,6 +481,11 @@ type AddStatusPageSubscriberOutput struct {
 
 // AddStatusPageSubscriber adds a new subscriber to a status page. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1private-subscribers/post
 func (c *Client) AddStatusPageSubscriber(input *AddStatusPageSubscribersInput) (*AddStatusPageSubscribersOutput, error) {
+	return c.AddStatusPageSubscriberWithContext(context.Background(), input)
+}
+
+// AddStatusPageSubscriberWithContext is the same as AddStatusPageSubscriber with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) AddStatusPageSubscriberWithContext(ctx context.Context, input *AddStatusPageSubscribersInput) (*AddStatusPageSubscribersOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -436,7 +498,7 @@ func (c *Client) AddStatusPageSubscriber(input *AddStatusPageSubscribersInput) (
 
 	url := fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.statusPages, *input.StatusPageID)
 
-	resp, err := c.httpClient.R().SetBody(input.Subscribers).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Subscribers).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -467,6 +529,11 @@ type AddStatusPageSubscribersOutput struct {
 
 // AddStatusPageSubscribers adds a new subscriber to an status page. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1private-subscribers/post
 func (c *Client) AddStatusPageSubscribers(input *AddStatusPageSubscribersInput) (*AddStatusPageSubscribersOutput, error) {
+	return c.AddStatusPageSubscribersWithContext(context.Background(), input)
+}
+
+// AddStatusPageSubscribersWithContext is the same as AddStatusPageSubscribers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) AddStatusPageSubscribersWithContext(ctx context.Context, input *AddStatusPageSubscribersInput) (*AddStatusPageSubscribersOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -479,7 +546,7 @@ func (c *Client) AddStatusPageSubscribers(input *AddStatusPageSubscribersInput)
 
 	url := fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.statusPages, *input.StatusPageID)
 
-	resp, err := c.httpClient.R().SetBody(input.Subscribers).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Subscribers).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -509,6 +576,11 @@ type DeleteStatusPageOutput struct {
 
 // DeleteStatusPage deletes the specified status page. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}/delete
 func (c *Client) DeleteStatusPage(input *DeleteStatusPageInput) (*DeleteStatusPageOutput, error) {
+	return c.DeleteStatusPageWithContext(context.Background(), input)
+}
+
+// DeleteStatusPageWithContext is the same as DeleteStatusPage with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteStatusPageWithContext(ctx context.Context, input *DeleteStatusPageInput) (*DeleteStatusPageOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -518,7 +590,7 @@ func (c *Client) DeleteStatusPage(input *DeleteStatusPageInput) (*DeleteStatusPa
 
 	url := fmt.Sprintf("%s/%d", apiRoutes.statusPages, *input.StatusPageID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
@@ -544,6 +616,11 @@ type DeleteStatusPageSubscriberOutput struct {
 
 // DeleteStatusPageSubscriber deletes a subscriber of the specified status page. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}/delete
 func (c *Client) DeleteStatusSubscriberPage(input *DeleteStatusPageSubscriberInput) (*DeleteStatusPageSubscriberOutput, error) {
+	return c.DeleteStatusSubscriberPageWithContext(context.Background(), input)
+}
+
+// DeleteStatusSubscriberPageWithContext is the same as DeleteStatusSubscriberPage with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteStatusSubscriberPageWithContext(ctx context.Context, input *DeleteStatusPageSubscriberInput) (*DeleteStatusPageSubscriberOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -556,7 +633,7 @@ func (c *Client) DeleteStatusSubscriberPage(input *DeleteStatusPageSubscriberInp
 
 	url := fmt.Sprintf("%s/%d/private-subscribers", apiRoutes.statusPages, *input.StatusPageID)
 
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,11 @@ type CreateStatusPageGroupOutput struct {
 
 // CreateStatusPageGroup creates a new status page group. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1groups/post
 func (c *Client) CreateStatusPageGroup(input *CreateStatusPageGroupInput) (*CreateStatusPageGroupOutput, error) {
+	return c.CreateStatusPageGroupWithContext(context.Background(), input)
+}
+
+// CreateStatusPageGroupWithContext is the same as CreateStatusPageGroup with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateStatusPageGroupWithContext(ctx context.Context, input *CreateStatusPageGroupInput) (*CreateStatusPageGroupOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -40,7 +46,7 @@ func (c *Client) CreateStatusPageGroup(input *CreateStatusPageGroupInput) (*Crea
 	}
 
 	url := fmt.Sprintf("%s/%d/groups", apiRoutes.statusPages, *input.StatusPageID)
-	resp, err := c.httpClient.R().SetBody(input.StatusPageGroup).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.StatusPageGroup).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +78,11 @@ type GetStatusPageGroupOutput struct {
 
 // GetStatusPageGroup gets the status page group with specified id. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1groups~1{group-id}/get
 func (c *Client) GetStatusPageGroup(input *GetStatusPageGroupInput) (*GetStatusPageGroupOutput, error) {
+	return c.GetStatusPageGroupWithContext(context.Background(), input)
+}
+
+// GetStatusPageGroupWithContext is the same as GetStatusPageGroup with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetStatusPageGroupWithContext(ctx context.Context, input *GetStatusPageGroupInput) (*GetStatusPageGroupOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -83,7 +94,7 @@ func (c *Client) GetStatusPageGroup(input *GetStatusPageGroupInput) (*GetStatusP
 	}
 
 	url := fmt.Sprintf("%s/%d/groups/%d", apiRoutes.statusPages, *input.StatusPageID, *input.StatusPageGroupID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +131,11 @@ type GetStatusPageGroupsOutput struct {
 
 // GetStatusPageGroups lists existing status page groups. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1groups/get
 func (c *Client) GetStatusPageGroups(input *GetStatusPageGroupsInput) (*GetStatusPageGroupsOutput, error) {
+	return c.GetStatusPageGroupsWithContext(context.Background(), input)
+}
+
+// GetStatusPageGroupsWithContext is the same as GetStatusPageGroups with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetStatusPageGroupsWithContext(ctx context.Context, input *GetStatusPageGroupsInput) (*GetStatusPageGroupsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -129,7 +145,7 @@ func (c *Client) GetStatusPageGroups(input *GetStatusPageGroupsInput) (*GetStatu
 	}
 
 	url := fmt.Sprintf("%s/%d/groups?%s", apiRoutes.statusPages, *input.StatusPageID, q.Encode())
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +162,32 @@ func (c *Client) GetStatusPageGroups(input *GetStatusPageGroupsInput) (*GetStatu
 	return &GetStatusPageGroupsOutput{StatusPageGroups: StatusPageGroups}, nil
 }
 
+// GetStatusPageGroupsPages iterates over all pages of a GetStatusPageGroups operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetStatusPageGroupsPages(input *GetStatusPageGroupsInput, fn func(page *GetStatusPageGroupsOutput) bool, options ...PaginateOption) error {
+	return c.GetStatusPageGroupsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetStatusPageGroupsPagesWithContext is the same as GetStatusPageGroupsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetStatusPageGroupsPagesWithContext(ctx context.Context, input *GetStatusPageGroupsInput, fn func(page *GetStatusPageGroupsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetStatusPageGroupsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*StatusPageGroup, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetStatusPageGroupsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.StatusPageGroups, nil
+	}
+	return Paginate(ctx, fetch, func(page []*StatusPageGroup) bool {
+		return fn(&GetStatusPageGroupsOutput{StatusPageGroups: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchStatusPageGroupInput represents the input of a SearchStatusPageGroup operation.
 type SearchStatusPageGroupInput struct {
 	_                   struct{}
@@ -161,6 +203,11 @@ type SearchStatusPageGroupOutput struct {
 
 // SearchStatusPageGroup gets the status page group with specified name.
 func (c *Client) SearchStatusPageGroup(input *SearchStatusPageGroupInput) (*SearchStatusPageGroupOutput, error) {
+	return c.SearchStatusPageGroupWithContext(context.Background(), input)
+}
+
+// SearchStatusPageGroupWithContext is the same as SearchStatusPageGroup with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchStatusPageGroupWithContext(ctx context.Context, input *SearchStatusPageGroupInput) (*SearchStatusPageGroupOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -172,7 +219,7 @@ func (c *Client) SearchStatusPageGroup(input *SearchStatusPageGroupInput) (*Sear
 	}
 
 	url := fmt.Sprintf("%s/%d/groups/name/%s", apiRoutes.statusPages, *input.StatusPageID, *input.StatusPageGroupName)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -205,6 +252,11 @@ type UpdateStatusPageGroupOutput struct {
 
 // UpdateStatusPageGroup updates an existing status page group. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1groups~1{group-id}/put
 func (c *Client) UpdateStatusPageGroup(input *UpdateStatusPageGroupInput) (*UpdateStatusPageGroupOutput, error) {
+	return c.UpdateStatusPageGroupWithContext(context.Background(), input)
+}
+
+// UpdateStatusPageGroupWithContext is the same as UpdateStatusPageGroup with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateStatusPageGroupWithContext(ctx context.Context, input *UpdateStatusPageGroupInput) (*UpdateStatusPageGroupOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -219,7 +271,7 @@ func (c *Client) UpdateStatusPageGroup(input *UpdateStatusPageGroupInput) (*Upda
 	}
 
 	url := fmt.Sprintf("%s/%d/groups/%d", apiRoutes.statusPages, *input.StatusPageID, *input.StatusPageGroupID)
-	resp, err := c.httpClient.R().SetBody(input.StatusPageGroup).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.StatusPageGroup).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +302,11 @@ type DeleteStatusPageGroupOutput struct {
 
 // DeleteStatusPageGroup deletes the specified status page group. https://api.ilert.com/api-docs/#tag/Status-Pages/paths/~1status-pages~1{id}~1groups~1{group-id}/delete
 func (c *Client) DeleteStatusPageGroup(input *DeleteStatusPageGroupInput) (*DeleteStatusPageGroupOutput, error) {
+	return c.DeleteStatusPageGroupWithContext(context.Background(), input)
+}
+
+// DeleteStatusPageGroupWithContext is the same as DeleteStatusPageGroup with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteStatusPageGroupWithContext(ctx context.Context, input *DeleteStatusPageGroupInput) (*DeleteStatusPageGroupOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -261,7 +318,7 @@ func (c *Client) DeleteStatusPageGroup(input *DeleteStatusPageGroupInput) (*Dele
 	}
 
 	url := fmt.Sprintf("%s/%d/groups/%d", apiRoutes.statusPages, *input.StatusPageID, *input.StatusPageGroupID)
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
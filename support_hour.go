@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -55,13 +56,18 @@ type CreateSupportHourOutput struct {
 
 // CreateSupportHour creates a new support hours resource. https://api.ilert.com/api-docs/#tag/Support-Hours/paths/~1support-hours/post
 func (c *Client) CreateSupportHour(input *CreateSupportHourInput) (*CreateSupportHourOutput, error) {
+	return c.CreateSupportHourWithContext(context.Background(), input)
+}
+
+// CreateSupportHourWithContext is the same as CreateSupportHour with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateSupportHourWithContext(ctx context.Context, input *CreateSupportHourInput) (*CreateSupportHourOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.SupportHour == nil {
 		return nil, errors.New("support hour input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.SupportHour).Post(apiRoutes.supportHours)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.SupportHour).Post(apiRoutes.supportHours)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +98,11 @@ type GetSupportHourOutput struct {
 
 // GetSupportHour gets the support hours resource with specified id. https://api.ilert.com/api-docs/#tag/Support-Hours/paths/~1support-hours~1{id}/get
 func (c *Client) GetSupportHour(input *GetSupportHourInput) (*GetSupportHourOutput, error) {
+	return c.GetSupportHourWithContext(context.Background(), input)
+}
+
+// GetSupportHourWithContext is the same as GetSupportHour with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetSupportHourWithContext(ctx context.Context, input *GetSupportHourInput) (*GetSupportHourOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -99,7 +110,7 @@ func (c *Client) GetSupportHour(input *GetSupportHourInput) (*GetSupportHourOutp
 		return nil, errors.New("support hour id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.supportHours, *input.SupportHourID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d", apiRoutes.supportHours, *input.SupportHourID))
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +147,11 @@ type GetSupportHoursOutput struct {
 
 // GetSupportHours lists existing support hours resources. https://api.ilert.com/api-docs/#tag/Support-Hours/paths/~1support-hours/get
 func (c *Client) GetSupportHours(input *GetSupportHoursInput) (*GetSupportHoursOutput, error) {
+	return c.GetSupportHoursWithContext(context.Background(), input)
+}
+
+// GetSupportHoursWithContext is the same as GetSupportHours with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetSupportHoursWithContext(ctx context.Context, input *GetSupportHoursInput) (*GetSupportHoursOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -144,7 +160,7 @@ func (c *Client) GetSupportHours(input *GetSupportHoursInput) (*GetSupportHoursO
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.supportHours, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.supportHours, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +177,32 @@ func (c *Client) GetSupportHours(input *GetSupportHoursInput) (*GetSupportHoursO
 	return &GetSupportHoursOutput{SupportHours: supportHours}, nil
 }
 
+// GetSupportHoursPages iterates over all pages of a GetSupportHours operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetSupportHoursPages(input *GetSupportHoursInput, fn func(page *GetSupportHoursOutput) bool, options ...PaginateOption) error {
+	return c.GetSupportHoursPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetSupportHoursPagesWithContext is the same as GetSupportHoursPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetSupportHoursPagesWithContext(ctx context.Context, input *GetSupportHoursInput, fn func(page *GetSupportHoursOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetSupportHoursInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*SupportHour, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetSupportHoursWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.SupportHours, nil
+	}
+	return Paginate(ctx, fetch, func(page []*SupportHour) bool {
+		return fn(&GetSupportHoursOutput{SupportHours: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchSupportHourInput represents the input of a SearchSupportHour operation.
 type SearchSupportHourInput struct {
 	_               struct{}
@@ -175,6 +217,11 @@ type SearchSupportHourOutput struct {
 
 // SearchSupportHour gets the support hours resource with specified name.
 func (c *Client) SearchSupportHour(input *SearchSupportHourInput) (*SearchSupportHourOutput, error) {
+	return c.SearchSupportHourWithContext(context.Background(), input)
+}
+
+// SearchSupportHourWithContext is the same as SearchSupportHour with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchSupportHourWithContext(ctx context.Context, input *SearchSupportHourInput) (*SearchSupportHourOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -182,7 +229,7 @@ func (c *Client) SearchSupportHour(input *SearchSupportHourInput) (*SearchSuppor
 		return nil, errors.New("support hour name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.supportHours, *input.SupportHourName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.supportHours, *input.SupportHourName))
 	if err != nil {
 		return nil, err
 	}
@@ -214,6 +261,11 @@ type UpdateSupportHourOutput struct {
 
 // UpdateSupportHour updates an existing support hours resource. https://api.ilert.com/api-docs/#tag/Support-Hours/paths/~1support-hours~1{id}/put
 func (c *Client) UpdateSupportHour(input *UpdateSupportHourInput) (*UpdateSupportHourOutput, error) {
+	return c.UpdateSupportHourWithContext(context.Background(), input)
+}
+
+// UpdateSupportHourWithContext is the same as UpdateSupportHour with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateSupportHourWithContext(ctx context.Context, input *UpdateSupportHourInput) (*UpdateSupportHourOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -224,7 +276,7 @@ func (c *Client) UpdateSupportHour(input *UpdateSupportHourInput) (*UpdateSuppor
 		return nil, errors.New("support hour id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.SupportHour).Put(fmt.Sprintf("%s/%d", apiRoutes.supportHours, *input.SupportHourID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.SupportHour).Put(fmt.Sprintf("%s/%d", apiRoutes.supportHours, *input.SupportHourID))
 	if err != nil {
 		return nil, err
 	}
@@ -254,6 +306,11 @@ type DeleteSupportHourOutput struct {
 
 // DeleteSupportHour deletes the specified support hours resource. https://api.ilert.com/api-docs/#tag/Support-Hours/paths/~1support-hours~1{id}/delete
 func (c *Client) DeleteSupportHour(input *DeleteSupportHourInput) (*DeleteSupportHourOutput, error) {
+	return c.DeleteSupportHourWithContext(context.Background(), input)
+}
+
+// DeleteSupportHourWithContext is the same as DeleteSupportHour with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteSupportHourWithContext(ctx context.Context, input *DeleteSupportHourInput) (*DeleteSupportHourOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -261,7 +318,7 @@ func (c *Client) DeleteSupportHour(input *DeleteSupportHourInput) (*DeleteSuppor
 		return nil, errors.New("support hour id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.supportHours, *input.SupportHourID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.supportHours, *input.SupportHourID))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -83,13 +84,18 @@ type CreateTeamOutput struct {
 
 // CreateTeam creates a new team. https://api.ilert.com/api-docs/#tag/Teams/paths/~1teams/post
 func (c *Client) CreateTeam(input *CreateTeamInput) (*CreateTeamOutput, error) {
+	return c.CreateTeamWithContext(context.Background(), input)
+}
+
+// CreateTeamWithContext is the same as CreateTeam with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateTeamWithContext(ctx context.Context, input *CreateTeamInput) (*CreateTeamOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	if input.Team == nil {
 		return nil, errors.New("team input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Team).Post(apiRoutes.teams)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Team).Post(apiRoutes.teams)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +126,11 @@ type GetTeamOutput struct {
 
 // GetTeam gets the team with specified id. https://api.ilert.com/api-docs/#tag/Teams/paths/~1teams~1{id}/get
 func (c *Client) GetTeam(input *GetTeamInput) (*GetTeamOutput, error) {
+	return c.GetTeamWithContext(context.Background(), input)
+}
+
+// GetTeamWithContext is the same as GetTeam with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetTeamWithContext(ctx context.Context, input *GetTeamInput) (*GetTeamOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -127,7 +138,7 @@ func (c *Client) GetTeam(input *GetTeamInput) (*GetTeamOutput, error) {
 		return nil, errors.New("team id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +175,11 @@ type GetTeamsOutput struct {
 
 // GetTeams lists existing teams. https://api.ilert.com/api-docs/#tag/Teams/paths/~1teams/get
 func (c *Client) GetTeams(input *GetTeamsInput) (*GetTeamsOutput, error) {
+	return c.GetTeamsWithContext(context.Background(), input)
+}
+
+// GetTeamsWithContext is the same as GetTeams with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetTeamsWithContext(ctx context.Context, input *GetTeamsInput) (*GetTeamsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -172,7 +188,7 @@ func (c *Client) GetTeams(input *GetTeamsInput) (*GetTeamsOutput, error) {
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.teams, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.teams, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +205,32 @@ func (c *Client) GetTeams(input *GetTeamsInput) (*GetTeamsOutput, error) {
 	return &GetTeamsOutput{Teams: teams}, nil
 }
 
+// GetTeamsPages iterates over all pages of a GetTeams operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetTeamsPages(input *GetTeamsInput, fn func(page *GetTeamsOutput) bool, options ...PaginateOption) error {
+	return c.GetTeamsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetTeamsPagesWithContext is the same as GetTeamsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetTeamsPagesWithContext(ctx context.Context, input *GetTeamsInput, fn func(page *GetTeamsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetTeamsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*Team, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetTeamsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Teams, nil
+	}
+	return Paginate(ctx, fetch, func(page []*Team) bool {
+		return fn(&GetTeamsOutput{Teams: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchTeamInput represents the input of a SearchTeam operation.
 type SearchTeamInput struct {
 	_        struct{}
@@ -203,6 +245,11 @@ type SearchTeamOutput struct {
 
 // SearchTeam gets the team with specified name.
 func (c *Client) SearchTeam(input *SearchTeamInput) (*SearchTeamOutput, error) {
+	return c.SearchTeamWithContext(context.Background(), input)
+}
+
+// SearchTeamWithContext is the same as SearchTeam with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchTeamWithContext(ctx context.Context, input *SearchTeamInput) (*SearchTeamOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -210,7 +257,7 @@ func (c *Client) SearchTeam(input *SearchTeamInput) (*SearchTeamOutput, error) {
 		return nil, errors.New("team name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.teams, *input.TeamName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.teams, *input.TeamName))
 	if err != nil {
 		return nil, err
 	}
@@ -242,6 +289,11 @@ type UpdateTeamOutput struct {
 
 // UpdateTeam updates an existing team. https://api.ilert.com/api-docs/#tag/Teams/paths/~1teams~1{id}/put
 func (c *Client) UpdateTeam(input *UpdateTeamInput) (*UpdateTeamOutput, error) {
+	return c.UpdateTeamWithContext(context.Background(), input)
+}
+
+// UpdateTeamWithContext is the same as UpdateTeam with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateTeamWithContext(ctx context.Context, input *UpdateTeamInput) (*UpdateTeamOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -252,7 +304,7 @@ func (c *Client) UpdateTeam(input *UpdateTeamInput) (*UpdateTeamOutput, error) {
 		return nil, errors.New("team id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Team).Put(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.Team).Put(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +334,11 @@ type DeleteTeamOutput struct {
 
 // DeleteTeam deletes the specified team. https://api.ilert.com/api-docs/#tag/Teams/paths/~1teams~1{id}/delete
 func (c *Client) DeleteTeam(input *DeleteTeamInput) (*DeleteTeamOutput, error) {
+	return c.DeleteTeamWithContext(context.Background(), input)
+}
+
+// DeleteTeamWithContext is the same as DeleteTeam with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteTeamWithContext(ctx context.Context, input *DeleteTeamInput) (*DeleteTeamOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -289,7 +346,7 @@ func (c *Client) DeleteTeam(input *DeleteTeamInput) (*DeleteTeamOutput, error) {
 		return nil, errors.New("team id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -114,6 +115,11 @@ type CreateUptimeMonitorOutput struct {
 
 // CreateUptimeMonitor creates a new uptime monitor. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors/post
 func (c *Client) CreateUptimeMonitor(input *CreateUptimeMonitorInput) (*CreateUptimeMonitorOutput, error) {
+	return c.CreateUptimeMonitorWithContext(context.Background(), input)
+}
+
+// CreateUptimeMonitorWithContext is the same as CreateUptimeMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUptimeMonitorWithContext(ctx context.Context, input *CreateUptimeMonitorInput) (*CreateUptimeMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -125,7 +131,7 @@ func (c *Client) CreateUptimeMonitor(input *CreateUptimeMonitorInput) (*CreateUp
 		input.UptimeMonitor.CreateAlertAfterFailedChecks = input.UptimeMonitor.CreateIncidentAfterFailedChecks
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.UptimeMonitor).Post(apiRoutes.uptimeMonitors)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UptimeMonitor).Post(apiRoutes.uptimeMonitors)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +162,11 @@ type GetUptimeMonitorOutput struct {
 
 // GetUptimeMonitor gets the uptime monitor with specified id. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors~1{id}/get
 func (c *Client) GetUptimeMonitor(input *GetUptimeMonitorInput) (*GetUptimeMonitorOutput, error) {
+	return c.GetUptimeMonitorWithContext(context.Background(), input)
+}
+
+// GetUptimeMonitorWithContext is the same as GetUptimeMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUptimeMonitorWithContext(ctx context.Context, input *GetUptimeMonitorInput) (*GetUptimeMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -163,7 +174,7 @@ func (c *Client) GetUptimeMonitor(input *GetUptimeMonitorInput) (*GetUptimeMonit
 		return nil, errors.New("uptime monitor id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +211,11 @@ type GetUptimeMonitorsOutput struct {
 
 // GetUptimeMonitors lists existing uptime monitors. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors/get
 func (c *Client) GetUptimeMonitors(input *GetUptimeMonitorsInput) (*GetUptimeMonitorsOutput, error) {
+	return c.GetUptimeMonitorsWithContext(context.Background(), input)
+}
+
+// GetUptimeMonitorsWithContext is the same as GetUptimeMonitors with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUptimeMonitorsWithContext(ctx context.Context, input *GetUptimeMonitorsInput) (*GetUptimeMonitorsOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -208,7 +224,7 @@ func (c *Client) GetUptimeMonitors(input *GetUptimeMonitorsInput) (*GetUptimeMon
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.uptimeMonitors, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.uptimeMonitors, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -225,6 +241,32 @@ func (c *Client) GetUptimeMonitors(input *GetUptimeMonitorsInput) (*GetUptimeMon
 	return &GetUptimeMonitorsOutput{UptimeMonitors: uptimeMonitors}, nil
 }
 
+// GetUptimeMonitorsPages iterates over all pages of a GetUptimeMonitors operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetUptimeMonitorsPages(input *GetUptimeMonitorsInput, fn func(page *GetUptimeMonitorsOutput) bool, options ...PaginateOption) error {
+	return c.GetUptimeMonitorsPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetUptimeMonitorsPagesWithContext is the same as GetUptimeMonitorsPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetUptimeMonitorsPagesWithContext(ctx context.Context, input *GetUptimeMonitorsInput, fn func(page *GetUptimeMonitorsOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetUptimeMonitorsInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*UptimeMonitor, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetUptimeMonitorsWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.UptimeMonitors, nil
+	}
+	return Paginate(ctx, fetch, func(page []*UptimeMonitor) bool {
+		return fn(&GetUptimeMonitorsOutput{UptimeMonitors: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchUptimeMonitorInput represents the input of a SearchUptimeMonitor operation.
 type SearchUptimeMonitorInput struct {
 	_                 struct{}
@@ -239,6 +281,11 @@ type SearchUptimeMonitorOutput struct {
 
 // SearchUptimeMonitor gets the uptime monitor with specified name.
 func (c *Client) SearchUptimeMonitor(input *SearchUptimeMonitorInput) (*SearchUptimeMonitorOutput, error) {
+	return c.SearchUptimeMonitorWithContext(context.Background(), input)
+}
+
+// SearchUptimeMonitorWithContext is the same as SearchUptimeMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchUptimeMonitorWithContext(ctx context.Context, input *SearchUptimeMonitorInput) (*SearchUptimeMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -246,7 +293,7 @@ func (c *Client) SearchUptimeMonitor(input *SearchUptimeMonitorInput) (*SearchUp
 		return nil, errors.New("uptime monitor name is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/name/%s", apiRoutes.uptimeMonitors, *input.UptimeMonitorName))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/name/%s", apiRoutes.uptimeMonitors, *input.UptimeMonitorName))
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +325,11 @@ type UpdateUptimeMonitorOutput struct {
 
 // UpdateUptimeMonitor updates an existing uptime monitor. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors~1{id}/put
 func (c *Client) UpdateUptimeMonitor(input *UpdateUptimeMonitorInput) (*UpdateUptimeMonitorOutput, error) {
+	return c.UpdateUptimeMonitorWithContext(context.Background(), input)
+}
+
+// UpdateUptimeMonitorWithContext is the same as UpdateUptimeMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUptimeMonitorWithContext(ctx context.Context, input *UpdateUptimeMonitorInput) (*UpdateUptimeMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -288,7 +340,7 @@ func (c *Client) UpdateUptimeMonitor(input *UpdateUptimeMonitorInput) (*UpdateUp
 		return nil, errors.New("uptime monitor id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.UptimeMonitor).Put(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UptimeMonitor).Put(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
 	if err != nil {
 		return nil, err
 	}
@@ -318,6 +370,11 @@ type DeleteUptimeMonitorOutput struct {
 
 // DeleteUptimeMonitor deletes the specified uptime monitor. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors~1{id}/delete
 func (c *Client) DeleteUptimeMonitor(input *DeleteUptimeMonitorInput) (*DeleteUptimeMonitorOutput, error) {
+	return c.DeleteUptimeMonitorWithContext(context.Background(), input)
+}
+
+// DeleteUptimeMonitorWithContext is the same as DeleteUptimeMonitor with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUptimeMonitorWithContext(ctx context.Context, input *DeleteUptimeMonitorInput) (*DeleteUptimeMonitorOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -325,7 +382,7 @@ func (c *Client) DeleteUptimeMonitor(input *DeleteUptimeMonitorInput) (*DeleteUp
 		return nil, errors.New("uptime monitor id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
 	if err != nil {
 		return nil, err
 	}
@@ -349,7 +406,12 @@ type GetUptimeMonitorsCountOutput struct {
 
 // GetUptimeMonitorsCount gets the count of uptime monitors. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors~1count/get
 func (c *Client) GetUptimeMonitorsCount(input *GetUptimeMonitorsCountInput) (*GetUptimeMonitorsCountOutput, error) {
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/count", apiRoutes.uptimeMonitors))
+	return c.GetUptimeMonitorsCountWithContext(context.Background(), input)
+}
+
+// GetUptimeMonitorsCountWithContext is the same as GetUptimeMonitorsCount with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUptimeMonitorsCountWithContext(ctx context.Context, input *GetUptimeMonitorsCountInput) (*GetUptimeMonitorsCountOutput, error) {
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s/count", apiRoutes.uptimeMonitors))
 	if err != nil {
 		return nil, err
 	}
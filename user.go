@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -82,6 +83,11 @@ func (u *User) GetUsername() string {
 
 // CreateUser creates a new user. Requires ADMIN privileges. https://api.ilert.com/api-docs/#tag/Users/paths/~1users/post
 func (c *Client) CreateUser(input *CreateUserInput) (*CreateUserOutput, error) {
+	return c.CreateUserWithContext(context.Background(), input)
+}
+
+// CreateUserWithContext is the same as CreateUser with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUserWithContext(ctx context.Context, input *CreateUserInput) (*CreateUserOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -94,7 +100,7 @@ func (c *Client) CreateUser(input *CreateUserInput) (*CreateUserOutput, error) {
 		requestURL = fmt.Sprintf("%s?send-no-invitation=%t", apiRoutes.users, *input.SendNoInvitation)
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.User).Post(requestURL)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.User).Post(requestURL)
 	if err != nil {
 		return nil, err
 	}
@@ -126,12 +132,22 @@ type GetUserOutput struct {
 
 // GetCurrentUser gets the currently authenticated user. https://api.ilert.com/api-docs/#tag/Users/paths/~1users~1current/get
 func (c *Client) GetCurrentUser() (*GetUserOutput, error) {
+	return c.GetCurrentUserWithContext(context.Background())
+}
+
+// GetCurrentUserWithContext is the same as GetCurrentUser with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetCurrentUserWithContext(ctx context.Context) (*GetUserOutput, error) {
 	input := &GetUserInput{Username: String("current")}
-	return c.GetUser(input)
+	return c.GetUserWithContext(ctx, input)
 }
 
 // GetUser gets the user with specified id or username. https://api.ilert.com/api-docs/#tag/Users/paths/~1users~1{user-id}/get
 func (c *Client) GetUser(input *GetUserInput) (*GetUserOutput, error) {
+	return c.GetUserWithContext(context.Background(), input)
+}
+
+// GetUserWithContext is the same as GetUser with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserWithContext(ctx context.Context, input *GetUserInput) (*GetUserOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -144,7 +160,7 @@ func (c *Client) GetUser(input *GetUserInput) (*GetUserOutput, error) {
 	} else {
 		url = fmt.Sprintf("%s/%s", apiRoutes.users, *input.Username)
 	}
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +197,11 @@ type GetUsersOutput struct {
 
 // GetUsers lists existing users. https://api.ilert.com/api-docs/#tag/Users/paths/~1users/get
 func (c *Client) GetUsers(input *GetUsersInput) (*GetUsersOutput, error) {
+	return c.GetUsersWithContext(context.Background(), input)
+}
+
+// GetUsersWithContext is the same as GetUsers with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUsersWithContext(ctx context.Context, input *GetUsersInput) (*GetUsersOutput, error) {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -189,7 +210,7 @@ func (c *Client) GetUsers(input *GetUsersInput) (*GetUsersOutput, error) {
 		q.Add("max-results", strconv.Itoa(*input.MaxResults))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.users, q.Encode()))
+	resp, err := c.httpClient.R().SetContext(ctx).Get(fmt.Sprintf("%s?%s", apiRoutes.users, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -206,6 +227,32 @@ func (c *Client) GetUsers(input *GetUsersInput) (*GetUsersOutput, error) {
 	return &GetUsersOutput{Users: users}, nil
 }
 
+// GetUsersPages iterates over all pages of a GetUsers operation, calling fn for every page until it returns false.
+// The input's StartIndex and MaxResults are used as the first index and the page size.
+func (c *Client) GetUsersPages(input *GetUsersInput, fn func(page *GetUsersOutput) bool, options ...PaginateOption) error {
+	return c.GetUsersPagesWithContext(context.Background(), input, fn, options...)
+}
+
+// GetUsersPagesWithContext is the same as GetUsersPages with the addition of a context, which is used to cancel the requests and their retries.
+func (c *Client) GetUsersPagesWithContext(ctx context.Context, input *GetUsersInput, fn func(page *GetUsersOutput) bool, options ...PaginateOption) error {
+	if input == nil {
+		input = &GetUsersInput{}
+	}
+	fetch := func(ctx context.Context, startIndex int, maxResults int) ([]*User, error) {
+		pageInput := *input
+		pageInput.StartIndex = Int(startIndex)
+		pageInput.MaxResults = Int(maxResults)
+		output, err := c.GetUsersWithContext(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		return output.Users, nil
+	}
+	return Paginate(ctx, fetch, func(page []*User) bool {
+		return fn(&GetUsersOutput{Users: page})
+	}, paginateOptions(input.StartIndex, input.MaxResults, 100, options)...)
+}
+
 // SearchUserInput represents the input of a SearchUser operation.
 type SearchUserInput struct {
 	_         struct{}
@@ -220,6 +267,11 @@ type SearchUserOutput struct {
 
 // SearchUser gets the user with specified name.
 func (c *Client) SearchUser(input *SearchUserInput) (*SearchUserOutput, error) {
+	return c.SearchUserWithContext(context.Background(), input)
+}
+
+// SearchUserWithContext is the same as SearchUser with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchUserWithContext(ctx context.Context, input *SearchUserInput) (*SearchUserOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -227,7 +279,7 @@ func (c *Client) SearchUser(input *SearchUserInput) (*SearchUserOutput, error) {
 		return nil, errors.New("user email is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(User{Email: *input.UserEmail}).Post(fmt.Sprintf("%s/search-email", apiRoutes.users))
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(User{Email: *input.UserEmail}).Post(fmt.Sprintf("%s/search-email", apiRoutes.users))
 	if err != nil {
 		return nil, err
 	}
@@ -260,15 +312,25 @@ type UpdateUserOutput struct {
 
 // UpdateCurrentUser updates the currently authenticated user. https://api.ilert.com/api-docs/#tag/Users/paths/~1users~1current/put
 func (c *Client) UpdateCurrentUser(input *UpdateUserInput) (*UpdateUserOutput, error) {
+	return c.UpdateCurrentUserWithContext(context.Background(), input)
+}
+
+// UpdateCurrentUserWithContext is the same as UpdateCurrentUser with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateCurrentUserWithContext(ctx context.Context, input *UpdateUserInput) (*UpdateUserOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
 	input.Username = String("current")
-	return c.UpdateUser(input)
+	return c.UpdateUserWithContext(ctx, input)
 }
 
 // UpdateUser updates an existing user. https://api.ilert.com/api-docs/#tag/Users/paths/~1users~1{user-id}/put
 func (c *Client) UpdateUser(input *UpdateUserInput) (*UpdateUserOutput, error) {
+	return c.UpdateUserWithContext(context.Background(), input)
+}
+
+// UpdateUserWithContext is the same as UpdateUser with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUserWithContext(ctx context.Context, input *UpdateUserInput) (*UpdateUserOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -285,7 +347,7 @@ func (c *Client) UpdateUser(input *UpdateUserInput) (*UpdateUserOutput, error) {
 	} else {
 		url = fmt.Sprintf("%s/%s", apiRoutes.users, *input.Username)
 	}
-	resp, err := c.httpClient.R().SetBody(input.User).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.User).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -316,6 +378,11 @@ type DeleteUserOutput struct {
 
 // DeleteUser deletes the specified user. https://api.ilert.com/api-docs/#tag/Users/paths/~1users~1{user-id}/delete
 func (c *Client) DeleteUser(input *DeleteUserInput) (*DeleteUserOutput, error) {
+	return c.DeleteUserWithContext(context.Background(), input)
+}
+
+// DeleteUserWithContext is the same as DeleteUser with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUserWithContext(ctx context.Context, input *DeleteUserInput) (*DeleteUserOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -328,7 +395,7 @@ func (c *Client) DeleteUser(input *DeleteUserInput) (*DeleteUserOutput, error) {
 	} else {
 		url = fmt.Sprintf("%s/%s", apiRoutes.users, *input.Username)
 	}
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -76,6 +77,11 @@ type CreateUserAlertPreferenceOutput struct {
 
 // CreateUserAlertPreference creates a new alert notification preference for a user. Requires ADMIN privileges or user id equals your current user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1alerts/post
 func (c *Client) CreateUserAlertPreference(input *CreateUserAlertPreferenceInput) (*CreateUserAlertPreferenceOutput, error) {
+	return c.CreateUserAlertPreferenceWithContext(context.Background(), input)
+}
+
+// CreateUserAlertPreferenceWithContext is the same as CreateUserAlertPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUserAlertPreferenceWithContext(ctx context.Context, input *CreateUserAlertPreferenceInput) (*CreateUserAlertPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -87,7 +93,7 @@ func (c *Client) CreateUserAlertPreference(input *CreateUserAlertPreferenceInput
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/alerts", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(input.UserAlertPreference).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserAlertPreference).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +125,11 @@ type GetUserAlertPreferenceOutput struct {
 
 // GetUserAlertPreference gets an alert notification preference of a user by id. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1alerts~1{id}/get
 func (c *Client) GetUserAlertPreference(input *GetUserAlertPreferenceInput) (*GetUserAlertPreferenceOutput, error) {
+	return c.GetUserAlertPreferenceWithContext(context.Background(), input)
+}
+
+// GetUserAlertPreferenceWithContext is the same as GetUserAlertPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserAlertPreferenceWithContext(ctx context.Context, input *GetUserAlertPreferenceInput) (*GetUserAlertPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -130,7 +141,7 @@ func (c *Client) GetUserAlertPreference(input *GetUserAlertPreferenceInput) (*Ge
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/alerts/%d", apiRoutes.users, *input.UserID, *input.UserAlertPreferenceID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +172,11 @@ type GetUserAlertPreferencesOutput struct {
 
 // GetUserAlertPreferences lists existing alert notification preferences of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1alerts/get
 func (c *Client) GetUserAlertPreferences(input *GetUserAlertPreferencesInput) (*GetUserAlertPreferencesOutput, error) {
+	return c.GetUserAlertPreferencesWithContext(context.Background(), input)
+}
+
+// GetUserAlertPreferencesWithContext is the same as GetUserAlertPreferences with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserAlertPreferencesWithContext(ctx context.Context, input *GetUserAlertPreferencesInput) (*GetUserAlertPreferencesOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -169,7 +185,7 @@ func (c *Client) GetUserAlertPreferences(input *GetUserAlertPreferencesInput) (*
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/alerts", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +218,11 @@ type UpdateUserAlertPreferenceOutput struct {
 
 // UpdateUserAlertPreference updates an existing alert notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1alerts~1{id}/put
 func (c *Client) UpdateUserAlertPreference(input *UpdateUserAlertPreferenceInput) (*UpdateUserAlertPreferenceOutput, error) {
+	return c.UpdateUserAlertPreferenceWithContext(context.Background(), input)
+}
+
+// UpdateUserAlertPreferenceWithContext is the same as UpdateUserAlertPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUserAlertPreferenceWithContext(ctx context.Context, input *UpdateUserAlertPreferenceInput) (*UpdateUserAlertPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -216,7 +237,7 @@ func (c *Client) UpdateUserAlertPreference(input *UpdateUserAlertPreferenceInput
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/alerts/%d", apiRoutes.users, *input.UserID, *input.UserAlertPreferenceID)
-	resp, err := c.httpClient.R().SetBody(input.UserAlertPreference).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserAlertPreference).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +268,11 @@ type DeleteUserAlertPreferenceOutput struct {
 
 // DeleteUserAlertPreference deletes the specified alert notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1alerts~1{id}/delete
 func (c *Client) DeleteUserAlertPreference(input *DeleteUserAlertPreferenceInput) (*DeleteUserAlertPreferenceOutput, error) {
+	return c.DeleteUserAlertPreferenceWithContext(context.Background(), input)
+}
+
+// DeleteUserAlertPreferenceWithContext is the same as DeleteUserAlertPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUserAlertPreferenceWithContext(ctx context.Context, input *DeleteUserAlertPreferenceInput) (*DeleteUserAlertPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -258,7 +284,7 @@ func (c *Client) DeleteUserAlertPreference(input *DeleteUserAlertPreferenceInput
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/alerts/%d", apiRoutes.users, *input.UserID, *input.UserAlertPreferenceID)
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
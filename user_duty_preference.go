@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -51,6 +52,11 @@ type CreateUserDutyPreferenceOutput struct {
 
 // CreateUserDutyPreference creates a new duty notification preference for a user. Requires ADMIN privileges or user id equals your current user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1duties/post
 func (c *Client) CreateUserDutyPreference(input *CreateUserDutyPreferenceInput) (*CreateUserDutyPreferenceOutput, error) {
+	return c.CreateUserDutyPreferenceWithContext(context.Background(), input)
+}
+
+// CreateUserDutyPreferenceWithContext is the same as CreateUserDutyPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUserDutyPreferenceWithContext(ctx context.Context, input *CreateUserDutyPreferenceInput) (*CreateUserDutyPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -62,7 +68,7 @@ func (c *Client) CreateUserDutyPreference(input *CreateUserDutyPreferenceInput)
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/duties", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(input.UserDutyPreference).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserDutyPreference).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +100,11 @@ type GetUserDutyPreferenceOutput struct {
 
 // GetUserDutyPreference gets an duty notification preference of a user by id. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1duties~1{id}/get
 func (c *Client) GetUserDutyPreference(input *GetUserDutyPreferenceInput) (*GetUserDutyPreferenceOutput, error) {
+	return c.GetUserDutyPreferenceWithContext(context.Background(), input)
+}
+
+// GetUserDutyPreferenceWithContext is the same as GetUserDutyPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserDutyPreferenceWithContext(ctx context.Context, input *GetUserDutyPreferenceInput) (*GetUserDutyPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -105,7 +116,7 @@ func (c *Client) GetUserDutyPreference(input *GetUserDutyPreferenceInput) (*GetU
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/duties/%d", apiRoutes.users, *input.UserID, *input.UserDutyPreferenceID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +147,11 @@ type GetUserDutyPreferencesOutput struct {
 
 // GetUserDutyPreferences lists existing duty notification preferences of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1duties/get
 func (c *Client) GetUserDutyPreferences(input *GetUserDutyPreferencesInput) (*GetUserDutyPreferencesOutput, error) {
+	return c.GetUserDutyPreferencesWithContext(context.Background(), input)
+}
+
+// GetUserDutyPreferencesWithContext is the same as GetUserDutyPreferences with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserDutyPreferencesWithContext(ctx context.Context, input *GetUserDutyPreferencesInput) (*GetUserDutyPreferencesOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -144,7 +160,7 @@ func (c *Client) GetUserDutyPreferences(input *GetUserDutyPreferencesInput) (*Ge
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/duties", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -177,6 +193,11 @@ type UpdateUserDutyPreferenceOutput struct {
 
 // UpdateUserDutyPreference updates an existing duty notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1duties~1{id}/put
 func (c *Client) UpdateUserDutyPreference(input *UpdateUserDutyPreferenceInput) (*UpdateUserDutyPreferenceOutput, error) {
+	return c.UpdateUserDutyPreferenceWithContext(context.Background(), input)
+}
+
+// UpdateUserDutyPreferenceWithContext is the same as UpdateUserDutyPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUserDutyPreferenceWithContext(ctx context.Context, input *UpdateUserDutyPreferenceInput) (*UpdateUserDutyPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -191,7 +212,7 @@ func (c *Client) UpdateUserDutyPreference(input *UpdateUserDutyPreferenceInput)
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/duties/%d", apiRoutes.users, *input.UserID, *input.UserDutyPreferenceID)
-	resp, err := c.httpClient.R().SetBody(input.UserDutyPreference).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserDutyPreference).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -222,6 +243,11 @@ type DeleteUserDutyPreferenceOutput struct {
 
 // DeleteUserDutyPreference deletes the specified duty notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1duties~1{id}/delete
 func (c *Client) DeleteUserDutyPreference(input *DeleteUserDutyPreferenceInput) (*DeleteUserDutyPreferenceOutput, error) {
+	return c.DeleteUserDutyPreferenceWithContext(context.Background(), input)
+}
+
+// DeleteUserDutyPreferenceWithContext is the same as DeleteUserDutyPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUserDutyPreferenceWithContext(ctx context.Context, input *DeleteUserDutyPreferenceInput) (*DeleteUserDutyPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -233,7 +259,7 @@ func (c *Client) DeleteUserDutyPreference(input *DeleteUserDutyPreferenceInput)
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/duties/%d", apiRoutes.users, *input.UserID, *input.UserDutyPreferenceID)
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
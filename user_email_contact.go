@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -46,6 +47,11 @@ type CreateUserEmailContactOutput struct {
 
 // CreateUserEmailContact creates a new email contact for a user. Requires ADMIN privileges or user id equals your current user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1emails/post
 func (c *Client) CreateUserEmailContact(input *CreateUserEmailContactInput) (*CreateUserEmailContactOutput, error) {
+	return c.CreateUserEmailContactWithContext(context.Background(), input)
+}
+
+// CreateUserEmailContactWithContext is the same as CreateUserEmailContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUserEmailContactWithContext(ctx context.Context, input *CreateUserEmailContactInput) (*CreateUserEmailContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -57,7 +63,7 @@ func (c *Client) CreateUserEmailContact(input *CreateUserEmailContactInput) (*Cr
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/emails", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(input.UserEmailContact).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserEmailContact).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +95,11 @@ type GetUserEmailContactOutput struct {
 
 // GetUserEmailContact gets an email contact of a user by id. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1emails~1{id}/get
 func (c *Client) GetUserEmailContact(input *GetUserEmailContactInput) (*GetUserEmailContactOutput, error) {
+	return c.GetUserEmailContactWithContext(context.Background(), input)
+}
+
+// GetUserEmailContactWithContext is the same as GetUserEmailContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserEmailContactWithContext(ctx context.Context, input *GetUserEmailContactInput) (*GetUserEmailContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -100,7 +111,7 @@ func (c *Client) GetUserEmailContact(input *GetUserEmailContactInput) (*GetUserE
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/emails/%d", apiRoutes.users, *input.UserID, *input.UserEmailContactID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +142,11 @@ type GetUserEmailContactsOutput struct {
 
 // GetUserEmailContacts lists existing email contacts of a user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1emails/get
 func (c *Client) GetUserEmailContacts(input *GetUserEmailContactsInput) (*GetUserEmailContactsOutput, error) {
+	return c.GetUserEmailContactsWithContext(context.Background(), input)
+}
+
+// GetUserEmailContactsWithContext is the same as GetUserEmailContacts with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserEmailContactsWithContext(ctx context.Context, input *GetUserEmailContactsInput) (*GetUserEmailContactsOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -139,7 +155,7 @@ func (c *Client) GetUserEmailContacts(input *GetUserEmailContactsInput) (*GetUse
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/emails", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +187,11 @@ type SearchUserEmailContactOutput struct {
 
 // SearchUserEmailContact gets the email contact with specified target of a user.
 func (c *Client) SearchUserEmailContact(input *SearchUserEmailContactInput) (*SearchUserEmailContactOutput, error) {
+	return c.SearchUserEmailContactWithContext(context.Background(), input)
+}
+
+// SearchUserEmailContactWithContext is the same as SearchUserEmailContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchUserEmailContactWithContext(ctx context.Context, input *SearchUserEmailContactInput) (*SearchUserEmailContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -182,7 +203,7 @@ func (c *Client) SearchUserEmailContact(input *SearchUserEmailContactInput) (*Se
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/emails/search-target", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(UserEmailContact{Target: *input.UserEmailContactTarget}).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(UserEmailContact{Target: *input.UserEmailContactTarget}).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +236,11 @@ type UpdateUserEmailContactOutput struct {
 
 // UpdateUserEmailContact updates an existing email contact of a user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1emails~1{id}/put
 func (c *Client) UpdateUserEmailContact(input *UpdateUserEmailContactInput) (*UpdateUserEmailContactOutput, error) {
+	return c.UpdateUserEmailContactWithContext(context.Background(), input)
+}
+
+// UpdateUserEmailContactWithContext is the same as UpdateUserEmailContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUserEmailContactWithContext(ctx context.Context, input *UpdateUserEmailContactInput) (*UpdateUserEmailContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -229,7 +255,7 @@ func (c *Client) UpdateUserEmailContact(input *UpdateUserEmailContactInput) (*Up
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/emails/%d", apiRoutes.users, *input.UserID, *input.UserEmailContactID)
-	resp, err := c.httpClient.R().SetBody(input.UserEmailContact).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserEmailContact).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +286,11 @@ type DeleteUserEmailContactOutput struct {
 
 // DeleteUserEmailContact deletes the specified email contact of a user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1emails~1{id}/delete
 func (c *Client) DeleteUserEmailContact(input *DeleteUserEmailContactInput) (*DeleteUserEmailContactOutput, error) {
+	return c.DeleteUserEmailContactWithContext(context.Background(), input)
+}
+
+// DeleteUserEmailContactWithContext is the same as DeleteUserEmailContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUserEmailContactWithContext(ctx context.Context, input *DeleteUserEmailContactInput) (*DeleteUserEmailContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -271,7 +302,7 @@ func (c *Client) DeleteUserEmailContact(input *DeleteUserEmailContactInput) (*De
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/emails/%d", apiRoutes.users, *input.UserID, *input.UserEmailContactID)
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
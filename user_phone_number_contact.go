@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,11 @@ type CreateUserPhoneNumberContactOutput struct {
 
 // CreateUserPhoneNumberContact creates a new phone number contact for a user. Requires ADMIN privileges or user id equals your current user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1phone-numbers/post
 func (c *Client) CreateUserPhoneNumberContact(input *CreateUserPhoneNumberContactInput) (*CreateUserPhoneNumberContactOutput, error) {
+	return c.CreateUserPhoneNumberContactWithContext(context.Background(), input)
+}
+
+// CreateUserPhoneNumberContactWithContext is the same as CreateUserPhoneNumberContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUserPhoneNumberContactWithContext(ctx context.Context, input *CreateUserPhoneNumberContactInput) (*CreateUserPhoneNumberContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -40,7 +46,7 @@ func (c *Client) CreateUserPhoneNumberContact(input *CreateUserPhoneNumberContac
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/phone-numbers", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(input.UserPhoneNumberContact).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserPhoneNumberContact).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +78,11 @@ type GetUserPhoneNumberContactOutput struct {
 
 // GetUserPhoneNumberContact gets a phone number contact of a user by id. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1phone-numbers~1{id}/get
 func (c *Client) GetUserPhoneNumberContact(input *GetUserPhoneNumberContactInput) (*GetUserPhoneNumberContactOutput, error) {
+	return c.GetUserPhoneNumberContactWithContext(context.Background(), input)
+}
+
+// GetUserPhoneNumberContactWithContext is the same as GetUserPhoneNumberContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserPhoneNumberContactWithContext(ctx context.Context, input *GetUserPhoneNumberContactInput) (*GetUserPhoneNumberContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -83,7 +94,7 @@ func (c *Client) GetUserPhoneNumberContact(input *GetUserPhoneNumberContactInput
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/phone-numbers/%d", apiRoutes.users, *input.UserID, *input.UserPhoneNumberContactID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +125,11 @@ type GetUserPhoneNumberContactsOutput struct {
 
 // GetUserPhoneNumberContacts lists existing phone number contacts of a user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1phone-numbers/get
 func (c *Client) GetUserPhoneNumberContacts(input *GetUserPhoneNumberContactsInput) (*GetUserPhoneNumberContactsOutput, error) {
+	return c.GetUserPhoneNumberContactsWithContext(context.Background(), input)
+}
+
+// GetUserPhoneNumberContactsWithContext is the same as GetUserPhoneNumberContacts with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserPhoneNumberContactsWithContext(ctx context.Context, input *GetUserPhoneNumberContactsInput) (*GetUserPhoneNumberContactsOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -122,7 +138,7 @@ func (c *Client) GetUserPhoneNumberContacts(input *GetUserPhoneNumberContactsInp
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/phone-numbers", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +170,11 @@ type SearchUserPhoneNumberContactOutput struct {
 
 // SearchUserPhoneNumberContact gets the phone number contact with specified target of a user.
 func (c *Client) SearchUserPhoneNumberContact(input *SearchUserPhoneNumberContactInput) (*SearchUserPhoneNumberContactOutput, error) {
+	return c.SearchUserPhoneNumberContactWithContext(context.Background(), input)
+}
+
+// SearchUserPhoneNumberContactWithContext is the same as SearchUserPhoneNumberContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) SearchUserPhoneNumberContactWithContext(ctx context.Context, input *SearchUserPhoneNumberContactInput) (*SearchUserPhoneNumberContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -165,7 +186,7 @@ func (c *Client) SearchUserPhoneNumberContact(input *SearchUserPhoneNumberContac
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/phone-numbers/search-target", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(UserPhoneNumberContact{Target: *input.UserPhoneNumberContactTarget}).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(UserPhoneNumberContact{Target: *input.UserPhoneNumberContactTarget}).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +219,11 @@ type UpdateUserPhoneNumberContactOutput struct {
 
 // UpdateUserPhoneNumberContact updates an existing phone number contact of a user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1phone-numbers~1{id}/put
 func (c *Client) UpdateUserPhoneNumberContact(input *UpdateUserPhoneNumberContactInput) (*UpdateUserPhoneNumberContactOutput, error) {
+	return c.UpdateUserPhoneNumberContactWithContext(context.Background(), input)
+}
+
+// UpdateUserPhoneNumberContactWithContext is the same as UpdateUserPhoneNumberContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUserPhoneNumberContactWithContext(ctx context.Context, input *UpdateUserPhoneNumberContactInput) (*UpdateUserPhoneNumberContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -212,7 +238,7 @@ func (c *Client) UpdateUserPhoneNumberContact(input *UpdateUserPhoneNumberContac
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/phone-numbers/%d", apiRoutes.users, *input.UserID, *input.UserPhoneNumberContactID)
-	resp, err := c.httpClient.R().SetBody(input.UserPhoneNumberContact).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserPhoneNumberContact).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +269,11 @@ type DeleteUserPhoneNumberContactOutput struct {
 
 // DeleteUserPhoneNumberContact deletes the specified phone number contact of a user. https://api.ilert.com/api-docs/#tag/Contacts/paths/~1users~1{user-id}~1contacts~1phone-numbers~1{id}/delete
 func (c *Client) DeleteUserPhoneNumberContact(input *DeleteUserPhoneNumberContactInput) (*DeleteUserPhoneNumberContactOutput, error) {
+	return c.DeleteUserPhoneNumberContactWithContext(context.Background(), input)
+}
+
+// DeleteUserPhoneNumberContactWithContext is the same as DeleteUserPhoneNumberContact with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUserPhoneNumberContactWithContext(ctx context.Context, input *DeleteUserPhoneNumberContactInput) (*DeleteUserPhoneNumberContactOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -254,7 +285,7 @@ func (c *Client) DeleteUserPhoneNumberContact(input *DeleteUserPhoneNumberContac
 	}
 
 	url := fmt.Sprintf("%s/%d/contacts/phone-numbers/%d", apiRoutes.users, *input.UserID, *input.UserPhoneNumberContactID)
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
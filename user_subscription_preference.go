@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -35,6 +36,11 @@ type CreateUserSubscriptionPreferenceOutput struct {
 
 // CreateUserSubscriptionPreference creates a new subscription notification preference for a user. Requires ADMIN privileges or user id equals your current user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1subscriptions/post
 func (c *Client) CreateUserSubscriptionPreference(input *CreateUserSubscriptionPreferenceInput) (*CreateUserSubscriptionPreferenceOutput, error) {
+	return c.CreateUserSubscriptionPreferenceWithContext(context.Background(), input)
+}
+
+// CreateUserSubscriptionPreferenceWithContext is the same as CreateUserSubscriptionPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUserSubscriptionPreferenceWithContext(ctx context.Context, input *CreateUserSubscriptionPreferenceInput) (*CreateUserSubscriptionPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -46,7 +52,7 @@ func (c *Client) CreateUserSubscriptionPreference(input *CreateUserSubscriptionP
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/subscriptions", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(input.UserSubscriptionPreference).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserSubscriptionPreference).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +84,11 @@ type GetUserSubscriptionPreferenceOutput struct {
 
 // GetUserSubscriptionPreference gets an subscription notification preference of a user by id. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1subscriptions~1{id}/get
 func (c *Client) GetUserSubscriptionPreference(input *GetUserSubscriptionPreferenceInput) (*GetUserSubscriptionPreferenceOutput, error) {
+	return c.GetUserSubscriptionPreferenceWithContext(context.Background(), input)
+}
+
+// GetUserSubscriptionPreferenceWithContext is the same as GetUserSubscriptionPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserSubscriptionPreferenceWithContext(ctx context.Context, input *GetUserSubscriptionPreferenceInput) (*GetUserSubscriptionPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -89,7 +100,7 @@ func (c *Client) GetUserSubscriptionPreference(input *GetUserSubscriptionPrefere
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/subscriptions/%d", apiRoutes.users, *input.UserID, *input.UserSubscriptionPreferenceID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +131,11 @@ type GetUserSubscriptionPreferencesOutput struct {
 
 // GetUserSubscriptionPreferences lists existing subscription notification preferences of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1subscriptions/get
 func (c *Client) GetUserSubscriptionPreferences(input *GetUserSubscriptionPreferencesInput) (*GetUserSubscriptionPreferencesOutput, error) {
+	return c.GetUserSubscriptionPreferencesWithContext(context.Background(), input)
+}
+
+// GetUserSubscriptionPreferencesWithContext is the same as GetUserSubscriptionPreferences with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserSubscriptionPreferencesWithContext(ctx context.Context, input *GetUserSubscriptionPreferencesInput) (*GetUserSubscriptionPreferencesOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -128,7 +144,7 @@ func (c *Client) GetUserSubscriptionPreferences(input *GetUserSubscriptionPrefer
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/subscriptions", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +177,11 @@ type UpdateUserSubscriptionPreferenceOutput struct {
 
 // UpdateUserSubscriptionPreference updates an existing subscription notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1subscriptions~1{id}/put
 func (c *Client) UpdateUserSubscriptionPreference(input *UpdateUserSubscriptionPreferenceInput) (*UpdateUserSubscriptionPreferenceOutput, error) {
+	return c.UpdateUserSubscriptionPreferenceWithContext(context.Background(), input)
+}
+
+// UpdateUserSubscriptionPreferenceWithContext is the same as UpdateUserSubscriptionPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUserSubscriptionPreferenceWithContext(ctx context.Context, input *UpdateUserSubscriptionPreferenceInput) (*UpdateUserSubscriptionPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -175,7 +196,7 @@ func (c *Client) UpdateUserSubscriptionPreference(input *UpdateUserSubscriptionP
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/subscriptions/%d", apiRoutes.users, *input.UserID, *input.UserSubscriptionPreferenceID)
-	resp, err := c.httpClient.R().SetBody(input.UserSubscriptionPreference).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserSubscriptionPreference).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -206,6 +227,11 @@ type DeleteUserSubscriptionPreferenceOutput struct {
 
 // DeleteUserSubscriptionPreference deletes the specified subscription notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1subscriptions~1{id}/delete
 func (c *Client) DeleteUserSubscriptionPreference(input *DeleteUserSubscriptionPreferenceInput) (*DeleteUserSubscriptionPreferenceOutput, error) {
+	return c.DeleteUserSubscriptionPreferenceWithContext(context.Background(), input)
+}
+
+// DeleteUserSubscriptionPreferenceWithContext is the same as DeleteUserSubscriptionPreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUserSubscriptionPreferenceWithContext(ctx context.Context, input *DeleteUserSubscriptionPreferenceInput) (*DeleteUserSubscriptionPreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -217,7 +243,7 @@ func (c *Client) DeleteUserSubscriptionPreference(input *DeleteUserSubscriptionP
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/subscriptions/%d", apiRoutes.users, *input.UserID, *input.UserSubscriptionPreferenceID)
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -56,6 +57,11 @@ type CreateUserUpdatePreferenceOutput struct {
 
 // CreateUserUpdatePreference creates a new update notification preference for a user. Requires ADMIN privileges or user id equals your current user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1updates/post
 func (c *Client) CreateUserUpdatePreference(input *CreateUserUpdatePreferenceInput) (*CreateUserUpdatePreferenceOutput, error) {
+	return c.CreateUserUpdatePreferenceWithContext(context.Background(), input)
+}
+
+// CreateUserUpdatePreferenceWithContext is the same as CreateUserUpdatePreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) CreateUserUpdatePreferenceWithContext(ctx context.Context, input *CreateUserUpdatePreferenceInput) (*CreateUserUpdatePreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -67,7 +73,7 @@ func (c *Client) CreateUserUpdatePreference(input *CreateUserUpdatePreferenceInp
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/updates", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().SetBody(input.UserUpdatePreference).Post(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserUpdatePreference).Post(url)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +105,11 @@ type GetUserUpdatePreferenceOutput struct {
 
 // GetUserUpdatePreference gets an update notification preference of a user by id. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1updates~1{id}/get
 func (c *Client) GetUserUpdatePreference(input *GetUserUpdatePreferenceInput) (*GetUserUpdatePreferenceOutput, error) {
+	return c.GetUserUpdatePreferenceWithContext(context.Background(), input)
+}
+
+// GetUserUpdatePreferenceWithContext is the same as GetUserUpdatePreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserUpdatePreferenceWithContext(ctx context.Context, input *GetUserUpdatePreferenceInput) (*GetUserUpdatePreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -110,7 +121,7 @@ func (c *Client) GetUserUpdatePreference(input *GetUserUpdatePreferenceInput) (*
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/updates/%d", apiRoutes.users, *input.UserID, *input.UserUpdatePreferenceID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +152,11 @@ type GetUserUpdatePreferencesOutput struct {
 
 // GetUserUpdatePreferences lists existing update notification preferences of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1updates/get
 func (c *Client) GetUserUpdatePreferences(input *GetUserUpdatePreferencesInput) (*GetUserUpdatePreferencesOutput, error) {
+	return c.GetUserUpdatePreferencesWithContext(context.Background(), input)
+}
+
+// GetUserUpdatePreferencesWithContext is the same as GetUserUpdatePreferences with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) GetUserUpdatePreferencesWithContext(ctx context.Context, input *GetUserUpdatePreferencesInput) (*GetUserUpdatePreferencesOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -149,7 +165,7 @@ func (c *Client) GetUserUpdatePreferences(input *GetUserUpdatePreferencesInput)
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/updates", apiRoutes.users, *input.UserID)
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +198,11 @@ type UpdateUserUpdatePreferenceOutput struct {
 
 // UpdateUserUpdatePreference updates an existing update notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1updates~1{id}/put
 func (c *Client) UpdateUserUpdatePreference(input *UpdateUserUpdatePreferenceInput) (*UpdateUserUpdatePreferenceOutput, error) {
+	return c.UpdateUserUpdatePreferenceWithContext(context.Background(), input)
+}
+
+// UpdateUserUpdatePreferenceWithContext is the same as UpdateUserUpdatePreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) UpdateUserUpdatePreferenceWithContext(ctx context.Context, input *UpdateUserUpdatePreferenceInput) (*UpdateUserUpdatePreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -196,7 +217,7 @@ func (c *Client) UpdateUserUpdatePreference(input *UpdateUserUpdatePreferenceInp
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/updates/%d", apiRoutes.users, *input.UserID, *input.UserUpdatePreferenceID)
-	resp, err := c.httpClient.R().SetBody(input.UserUpdatePreference).Put(url)
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(input.UserUpdatePreference).Put(url)
 	if err != nil {
 		return nil, err
 	}
@@ -227,6 +248,11 @@ type DeleteUserUpdatePreferenceOutput struct {
 
 // DeleteUserUpdatePreference deletes the specified update notification preference of a user. https://api.ilert.com/api-docs/#tag/Notification-Preferences/paths/~1users~1{user-id}~1notification-preferences~1updates~1{id}/delete
 func (c *Client) DeleteUserUpdatePreference(input *DeleteUserUpdatePreferenceInput) (*DeleteUserUpdatePreferenceOutput, error) {
+	return c.DeleteUserUpdatePreferenceWithContext(context.Background(), input)
+}
+
+// DeleteUserUpdatePreferenceWithContext is the same as DeleteUserUpdatePreference with the addition of a context, which is used to cancel the request and its retries.
+func (c *Client) DeleteUserUpdatePreferenceWithContext(ctx context.Context, input *DeleteUserUpdatePreferenceInput) (*DeleteUserUpdatePreferenceOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -238,7 +264,7 @@ func (c *Client) DeleteUserUpdatePreference(input *DeleteUserUpdatePreferenceInp
 	}
 
 	url := fmt.Sprintf("%s/%d/notification-preferences/updates/%d", apiRoutes.users, *input.UserID, *input.UserUpdatePreferenceID)
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.httpClient.R().SetContext(ctx).Delete(url)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,173 @@
+// Package webhook receives the payloads that alert actions of webhook type post to your services.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/iLert/ilert-go/v3"
+)
+
+// Payload is the body of an outbound webhook request. The body is shaped by the body template of the alert action,
+// fields the body does not contain are empty.
+type Payload struct {
+	// one of ilert.AlertActionTriggerTypesAll if the body template includes it, else empty
+	TriggerType string               `json:"triggerType"`
+	Alert       *ilert.Alert         `json:"alert,omitempty"`
+	LogEntry    *ilert.AlertLogEntry `json:"logEntry,omitempty"`
+	Incident    *ilert.Incident      `json:"incident,omitempty"`
+	Comment     *ilert.AlertComment  `json:"comment,omitempty"`
+
+	// the undecoded body e.g. for fields customized with the body template of the alert action
+	Raw json.RawMessage `json:"-"`
+}
+
+// HandlerFunc handles a payload, a non-nil error responds with 500 so the request is retried
+type HandlerFunc func(ctx context.Context, payload *Payload) error
+
+// Handler is an http.Handler that validates and decodes outbound webhook requests and dispatches them by trigger type.
+// It responds with 200 if a callback handled the payload, 204 if none is registered for its trigger type,
+// 400 for malformed payloads, 401 for a wrong secret, 405 for methods other than POST, 413 for oversized
+// bodies, 415 for content types other than JSON and 500 if the callback failed.
+type Handler struct {
+	checkSecret  bool
+	secretHeader string
+	secret       string
+	maxBodySize  int64
+	onError      func(r *http.Request, err error)
+	handlers     map[string]HandlerFunc
+	fallback     HandlerFunc
+}
+
+// HandlerOptions allows for options to be passed into the Handler for customization
+type HandlerOptions func(*Handler)
+
+// WithSecret requires requests to carry the shared secret in the given header,
+// e.g. a header configured in ilert.AlertActionParamsWebhook.Headers. An empty header or secret rejects all requests.
+func WithSecret(header string, secret string) HandlerOptions {
+	return func(h *Handler) {
+		h.checkSecret = true
+		h.secretHeader = header
+		h.secret = secret
+	}
+}
+
+// WithMaxBodySize sets the max size of request bodies in bytes
+// Default: 1 MiB
+func WithMaxBodySize(maxBodySize int64) HandlerOptions {
+	return func(h *Handler) {
+		h.maxBodySize = maxBodySize
+	}
+}
+
+// WithOnError sets a callback that is called for every request that is not answered with 200 or 204
+func WithOnError(onError func(r *http.Request, err error)) HandlerOptions {
+	return func(h *Handler) {
+		h.onError = onError
+	}
+}
+
+// NewHandler creates a new webhook handler without callbacks
+func NewHandler(options ...HandlerOptions) *Handler {
+	h := &Handler{
+		maxBodySize: 1 << 20,
+		handlers:    make(map[string]HandlerFunc),
+	}
+	for _, opt := range options {
+		opt(h)
+	}
+	return h
+}
+
+// On registers the callback of a trigger type, usually one of ilert.AlertActionTriggerTypesAll.
+// Other trigger types are accepted as well, e.g. trigger types added to the API after this version.
+func (h *Handler) On(triggerType string, fn HandlerFunc) *Handler {
+	h.handlers[triggerType] = fn
+	return h
+}
+
+// Fallback registers the callback of payloads whose trigger type has no callback or that have no trigger type
+func (h *Handler) Fallback(fn HandlerFunc) *Handler {
+	h.fallback = fn
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	if h.checkSecret && (h.secretHeader == "" || h.secret == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get(h.secretHeader)), []byte(h.secret)) != 1) {
+		h.fail(w, r, http.StatusUnauthorized, fmt.Errorf("missing or wrong %s header", h.secretHeader))
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || mediaType != "application/json" {
+			h.fail(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("content type %s not supported", contentType))
+			return
+		}
+	}
+
+	payload, err := h.decode(w, r)
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		h.fail(w, r, status, err)
+		return
+	}
+
+	fn, ok := h.handlers[payload.TriggerType]
+	if !ok || payload.TriggerType == "" {
+		fn = h.fallback
+	}
+	if fn == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := fn(r.Context(), payload); err != nil {
+		h.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Decode decodes an outbound webhook request body without validating the request
+func Decode(body io.Reader) (*Payload, error) {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	payload := &Payload{}
+	if err := json.Unmarshal(raw, payload); err != nil {
+		return nil, fmt.Errorf("could not decode payload: %w", err)
+	}
+	payload.Raw = raw
+	return payload, nil
+}
+
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request) (*Payload, error) {
+	body := io.Reader(r.Body)
+	if h.maxBodySize > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.maxBodySize)
+	}
+	return Decode(body)
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
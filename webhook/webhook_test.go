@@ -0,0 +1,131 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iLert/ilert-go/v3"
+	"github.com/iLert/ilert-go/v3/webhook"
+)
+
+func TestHandler(t *testing.T) {
+	created := `{"triggerType":"alert-created","alert":{"id":7,"summary":"db down"}}`
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		secret      string
+		body        string
+		status      int
+		handled     string
+	}{
+		{name: "handled", body: created, status: http.StatusOK, handled: "alert-created"},
+		{name: "content type with charset", contentType: "application/json; charset=utf-8", body: created, status: http.StatusOK, handled: "alert-created"},
+		{name: "no callback", body: `{"triggerType":"alert-accepted","alert":{"id":7}}`, status: http.StatusNoContent},
+		{name: "malformed", body: `{"triggerType":`, status: http.StatusBadRequest},
+		{name: "wrong secret", secret: "other", body: created, status: http.StatusUnauthorized},
+		{name: "method", method: http.MethodGet, status: http.StatusMethodNotAllowed},
+		{name: "too large", body: `{"triggerType":"alert-created","alert":{"summary":"` + strings.Repeat("x", 1024) + `"}}`, status: http.StatusRequestEntityTooLarge},
+		{name: "content type", contentType: "text/plain", body: created, status: http.StatusUnsupportedMediaType},
+		{name: "callback error", body: `{"triggerType":"alert-resolved","alert":{"id":7}}`, status: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		handled := ""
+		errs := 0
+		handler := webhook.NewHandler(
+			webhook.WithSecret("X-Webhook-Secret", "secret"),
+			webhook.WithMaxBodySize(512),
+			webhook.WithOnError(func(r *http.Request, err error) { errs++ })).
+			On(ilert.AlertActionTriggerTypes.AlertCreated, func(ctx context.Context, payload *webhook.Payload) error {
+				if payload.Alert == nil || payload.Alert.ID != 7 || string(payload.Raw) != created {
+					t.Errorf("%s: unexpected payload %+v", test.name, payload)
+				}
+				handled = payload.TriggerType
+				return nil
+			}).
+			On(ilert.AlertActionTriggerTypes.AlertResolved, func(ctx context.Context, payload *webhook.Payload) error {
+				return errors.New("database unavailable")
+			})
+		method, contentType, secret := test.method, test.contentType, test.secret
+		if method == "" {
+			method = http.MethodPost
+		}
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		if secret == "" {
+			secret = "secret"
+		}
+		req := httptest.NewRequest(method, "/ilert", strings.NewReader(test.body))
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Webhook-Secret", secret)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.status, rec.Code)
+		}
+		if handled != test.handled {
+			t.Errorf("%s: expected %q to be handled, got %q", test.name, test.handled, handled)
+		}
+		if failed := test.status != http.StatusOK && test.status != http.StatusNoContent; (errs == 1) != failed {
+			t.Errorf("%s: expected the error callback to be called %v, got %d calls", test.name, failed, errs)
+		}
+		if test.status == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != http.MethodPost {
+			t.Errorf("%s: expected the allowed method, got %q", test.name, rec.Header().Get("Allow"))
+		}
+	}
+}
+
+func TestHandlerPassesBodiesWithoutTriggerTypeToFallback(t *testing.T) {
+	body := `{"summary":"db down","priority":"HIGH"}`
+	var fallback *webhook.Payload
+	handler := webhook.NewHandler().
+		On(ilert.AlertActionTriggerTypes.AlertCreated, func(ctx context.Context, payload *webhook.Payload) error {
+			t.Error("expected a body without trigger type not to be handled by a trigger type callback")
+			return nil
+		}).
+		On("", func(ctx context.Context, payload *webhook.Payload) error {
+			t.Error("expected a body without trigger type not to be handled by an empty trigger type callback")
+			return nil
+		}).
+		Fallback(func(ctx context.Context, payload *webhook.Payload) error {
+			fallback = payload
+			return nil
+		})
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ilert", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK || fallback == nil {
+		t.Fatalf("expected the fallback to handle the body, got status %d", rec.Code)
+	}
+	if fallback.TriggerType != "" || fallback.Alert != nil || string(fallback.Raw) != body {
+		t.Errorf("expected only the raw body, got %+v", fallback)
+	}
+
+	rec = httptest.NewRecorder()
+	webhook.NewHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ilert", strings.NewReader(body)))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204 without fallback, got %d", rec.Code)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	payload, err := webhook.Decode(strings.NewReader(`{"triggerType":"alert-comment-added","alert":{"id":7},"comment":{"content":"on it"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.TriggerType != ilert.AlertActionTriggerTypes.AlertCommentAdded || payload.Alert.ID != 7 || payload.Comment == nil || payload.Comment.Content != "on it" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	if _, err := webhook.Decode(strings.NewReader(`[]`)); err == nil {
+		t.Error("expected a body that is not an object to fail")
+	}
+}